@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/configuration"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// app holds the services wired from the config, shared by the server and the other commands
+type app struct {
+	MySQL infra.MySQL
+
+	ResourceRepository repository.ResourceRepository
+
+	HealthService         *service.HealthServiceImpl
+	PersonService         *service.PersonServiceImpl
+	ResourceService       *service.ResourceServiceImpl
+	FamilyService         *service.FamilyServiceImpl
+	DonateResourceService *service.DonateResourceServiceImpl
+	EncryptionService     *service.EncryptionServiceImpl
+	IdempotencyService    *service.IdempotencyServiceImpl
+	SearchService         *service.SearchServiceImpl
+	ImportService         *service.ImportServiceImpl
+	ExportService         *service.ExportServiceImpl
+	ReportService         *service.ReportServiceImpl
+	DashboardService      *service.DashboardServiceImpl
+	ReceiptService        *service.ReceiptServiceImpl
+	WebhookService        *service.WebhookServiceImpl
+	StockService          *service.StockServiceImpl
+	NotificationService   *service.NotificationServiceImpl
+	JobService            *service.JobServiceImpl
+}
+
+func newApp(cfg configuration.Config) (*app, error) {
+	mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
+		cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+
+	cipher, err := infra.CipherConfigure(cfg.Crypto.Keys, cfg.Crypto.ActiveKeyID, cfg.Crypto.BlindIndexKey)
+	if err != nil {
+		mysql.DB.Close()
+		return nil, fmt.Errorf("cannot configure encryption: %w", err)
+	}
+
+	healthRepository := &repository.HealthRepositoryImpl{DB: mysql}
+	personRepository := &repository.PersonRepositoryImpl{DB: mysql, Cipher: cipher}
+	resourceRepository := &repository.ResourceRepositoryImpl{DB: mysql}
+	familyRepository := &repository.FamilyRepositoryImpl{DB: mysql, Cipher: cipher}
+	donateResourceRepository := &repository.DonateResourceRepositoryImpl{DB: mysql}
+	encryptionRepository := &repository.EncryptionRepositoryImpl{DB: mysql, Cipher: cipher}
+	idempotencyRepository := &repository.IdempotencyRepositoryImpl{DB: mysql}
+	searchRepository := &repository.SearchRepositoryImpl{DB: mysql, Cipher: cipher}
+	importRepository := &repository.ImportRepositoryImpl{DB: mysql, Cipher: cipher}
+	reportRepository := &repository.ReportRepositoryImpl{DB: mysql}
+	receiptRepository := &repository.ReceiptRepositoryImpl{DB: mysql}
+	dashboardRepository := &repository.DashboardRepositoryImpl{DB: mysql}
+	webhookRepository := &repository.WebhookRepositoryImpl{DB: mysql, Cipher: cipher}
+	notificationRepository := &repository.NotificationRepositoryImpl{DB: mysql, Cipher: cipher}
+	jobRepository := &repository.JobRepositoryImpl{DB: mysql}
+
+	webhookService := &service.WebhookServiceImpl{
+		WebhookRepository: webhookRepository,
+		HttpClient:        infra.PublicHttpClient(time.Duration(cfg.Webhook.TimeoutMs) * time.Millisecond),
+		BatchSize:         cfg.Webhook.BatchSize,
+		MaxAttempts:       cfg.Webhook.MaxAttempts,
+		Backoff:           time.Duration(cfg.Webhook.BackoffMs) * time.Millisecond,
+	}
+	stockService := &service.StockServiceImpl{Broker: infra.NewBroker[service.StockEvent](cfg.Stream.History)}
+	personService := &service.PersonServiceImpl{PersonRepository: personRepository}
+	resourceService := &service.ResourceServiceImpl{
+		ResourceRepository: resourceRepository,
+		Stock:              stockService,
+		Webhooks:           webhookService,
+		LowStockQuantity:   cfg.Webhook.LowStockQuantity,
+	}
+	familyService := &service.FamilyServiceImpl{
+		FamilyRepository:         familyRepository,
+		PersonRepository:         personRepository,
+		DonateResourceRepository: donateResourceRepository,
+		Webhooks:                 webhookService,
+	}
+	donateResourceService := &service.DonateResourceServiceImpl{
+		DonateResourceRepository: donateResourceRepository,
+		ResourceRepository:       resourceRepository,
+		Webhooks:                 webhookService,
+		Stock:                    stockService,
+		LowStockQuantity:         cfg.Webhook.LowStockQuantity,
+	}
+	encryptionService := &service.EncryptionServiceImpl{EncryptionRepository: encryptionRepository}
+	idempotencyService := &service.IdempotencyServiceImpl{
+		IdempotencyRepository: idempotencyRepository,
+		TTL:                   time.Duration(cfg.Idempotency.TTLMs) * time.Millisecond,
+		Lease:                 time.Duration(cfg.Idempotency.LeaseMs) * time.Millisecond,
+	}
+	searchService := &service.SearchServiceImpl{SearchRepository: searchRepository}
+	importService := &service.ImportServiceImpl{ImportRepository: importRepository, Webhooks: webhookService}
+	exportService := &service.ExportServiceImpl{
+		FamilyRepository:         familyRepository,
+		PersonRepository:         personRepository,
+		ResourceRepository:       resourceRepository,
+		DonateResourceRepository: donateResourceRepository,
+		DateFormat:               cfg.Export.DateFormat,
+		Columns:                  cfg.Export.Columns,
+	}
+	reportService := &service.ReportServiceImpl{ReportRepository: reportRepository, Webhooks: webhookService}
+	dashboardService := &service.DashboardServiceImpl{
+		DashboardRepository: dashboardRepository,
+		TTL:                 time.Duration(cfg.Dashboard.CacheTTLMs) * time.Millisecond,
+	}
+	receiptService := &service.ReceiptServiceImpl{
+		ReceiptRepository:        receiptRepository,
+		DonateResourceRepository: donateResourceRepository,
+		FamilyRepository:         familyRepository,
+		ResourceRepository:       resourceRepository,
+		Organization: service.ReceiptOrganization{
+			Name:     cfg.Receipt.Organization,
+			Document: cfg.Receipt.Document,
+			Address:  cfg.Receipt.Address,
+		},
+	}
+	notificationService := &service.NotificationServiceImpl{
+		NotificationRepository: notificationRepository,
+		PersonRepository:       personRepository,
+		FamilyRepository:       familyRepository,
+		Senders:                notificationSenders(cfg.Notification),
+		Organization:           cfg.Receipt.Organization,
+		BatchSize:              cfg.Notification.BatchSize,
+		MaxAttempts:            cfg.Notification.MaxAttempts,
+		Backoff:                time.Duration(cfg.Notification.BackoffMs) * time.Millisecond,
+		Timeout:                time.Duration(cfg.Notification.TimeoutMs) * time.Millisecond,
+		ReminderAfter:          time.Duration(cfg.Notification.ReminderAfterMs) * time.Millisecond,
+	}
+
+	jobService, err := newJobService(cfg.Scheduler, jobRepository, webhookService, notificationService, idempotencyService,
+		reportService)
+	if err != nil {
+		mysql.DB.Close()
+		return nil, fmt.Errorf("cannot configure scheduler: %w", err)
+	}
+
+	healthService := &service.HealthServiceImpl{
+		HealthRepository: healthRepository,
+		Checks:           healthChecks(cfg, healthRepository, jobService),
+		Timeout:          time.Duration(cfg.Health.TimeoutMs) * time.Millisecond,
+	}
+
+	return &app{
+		MySQL:                 mysql,
+		ResourceRepository:    resourceRepository,
+		HealthService:         healthService,
+		PersonService:         personService,
+		ResourceService:       resourceService,
+		FamilyService:         familyService,
+		DonateResourceService: donateResourceService,
+		EncryptionService:     encryptionService,
+		IdempotencyService:    idempotencyService,
+		SearchService:         searchService,
+		ImportService:         importService,
+		ExportService:         exportService,
+		ReportService:         reportService,
+		DashboardService:      dashboardService,
+		ReceiptService:        receiptService,
+		WebhookService:        webhookService,
+		StockService:          stockService,
+		NotificationService:   notificationService,
+		JobService:            jobService,
+	}, nil
+}
+
+// Close closes the MySQL pool, once nothing uses the services anymore
+func (a *app) Close() error {
+	return a.MySQL.DB.Close()
+}
+
+// notificationSenders sends every channel to the development sink when one is set,
+// otherwise each channel goes to its provider when it is configured
+func notificationSenders(cfg configuration.NotificationConfig) map[string]infra.Sender {
+	switch cfg.Sink {
+	case "log":
+		sender := &infra.LogSender{}
+		return map[string]infra.Sender{"email": sender, "sms": sender, "whatsapp": sender}
+	case "file":
+		sender := &infra.FileSender{Path: cfg.File}
+		return map[string]infra.Sender{"email": sender, "sms": sender, "whatsapp": sender}
+	}
+
+	client := &http.Client{Timeout: time.Duration(cfg.TimeoutMs) * time.Millisecond}
+	senders := map[string]infra.Sender{}
+	if cfg.SMTP.Host != "" {
+		senders["email"] = &infra.SMTPSender{Host: cfg.SMTP.Host, Port: cfg.SMTP.Port, Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password, From: cfg.SMTP.From}
+	}
+	if cfg.SMS.URL != "" {
+		senders["sms"] = &infra.SMSSender{URL: cfg.SMS.URL, Token: cfg.SMS.Token, From: cfg.SMS.From, Client: client}
+	}
+	if cfg.WhatsApp.PhoneNumberID != "" {
+		senders["whatsapp"] = &infra.WhatsAppSender{URL: cfg.WhatsApp.URL, PhoneNumberID: cfg.WhatsApp.PhoneNumberID,
+			Token: cfg.WhatsApp.Token, Client: client}
+	}
+
+	return senders
+}
+
+// healthChecks lists the checks of the readiness probe, the scheduler only where it runs
+func healthChecks(cfg configuration.Config, healthRepository repository.HealthRepository,
+	jobService service.JobService) []service.HealthCheck {
+	queueMaxDelay := time.Duration(cfg.Health.QueueMaxDelayMs) * time.Millisecond
+
+	checks := []service.HealthCheck{service.DatabaseHealthCheck(healthRepository)}
+	if version, err := infra.LatestMigration(cfg.Health.MigrationsDir); err != nil {
+		log.Warn("migration check disabled: ", err)
+	} else {
+		checks = append(checks, service.MigrationHealthCheck(healthRepository, version))
+	}
+	checks = append(checks, service.DiskHealthCheck(cfg.Health.DiskPath, cfg.Health.DiskMinFreeBytes))
+	if cfg.Scheduler.Enabled {
+		checks = append(checks, service.SchedulerHealthCheck(jobService))
+	}
+
+	return append(checks,
+		service.QueueHealthCheck(healthRepository, "webhooks", queueMaxDelay),
+		service.QueueHealthCheck(healthRepository, "notifications", queueMaxDelay),
+	)
+}
+
+// newJobService schedules the maintenance jobs with the cron expressions of the config
+func newJobService(cfg configuration.SchedulerConfig, jobRepository repository.JobRepository,
+	webhookService service.WebhookService, notificationService service.NotificationService,
+	idempotencyService service.IdempotencyService, reportService service.ReportService) (*service.JobServiceImpl, error) {
+	location := time.Local
+	if cfg.Location != "" {
+		l, err := time.LoadLocation(cfg.Location)
+		if err != nil {
+			return nil, err
+		}
+		location = l
+	}
+
+	hostname, _ := os.Hostname()
+	impl := &service.JobServiceImpl{
+		JobRepository: jobRepository,
+		Owner:         fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		Timeout:       time.Duration(cfg.TimeoutMs) * time.Millisecond,
+		Location:      location,
+		Retention:     time.Duration(cfg.RunsRetentionMs) * time.Millisecond,
+	}
+
+	impl.Jobs = []service.ScheduledJob{
+		{Name: "deliver_webhooks", Run: func(ctx context.Context) error {
+			_, err := webhookService.Deliver(ctx)
+			return err
+		}},
+		{Name: "deliver_notifications", Run: func(ctx context.Context) error {
+			_, err := notificationService.Deliver(ctx)
+			return err
+		}},
+		{Name: "remind_basket_pickups", Run: func(ctx context.Context) error {
+			_, err := notificationService.RemindPickups(ctx)
+			return err
+		}},
+		{Name: "report_distributions", Run: func(ctx context.Context) error {
+			_, err := reportService.PublishLastMonth(ctx)
+			return err
+		}},
+		{Name: "purge_idempotency_keys", Run: func(ctx context.Context) error {
+			_, err := idempotencyService.Purge(ctx)
+			return err
+		}},
+		{Name: "purge_job_runs", Run: func(ctx context.Context) error {
+			_, err := impl.Purge(ctx)
+			return err
+		}},
+	}
+
+	known := map[string]bool{}
+	for i, job := range impl.Jobs {
+		impl.Jobs[i].Schedule = cfg.Jobs[job.Name]
+		known[job.Name] = true
+	}
+	for name := range cfg.Jobs {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown job %s", name)
+		}
+	}
+
+	return impl, nil
+}
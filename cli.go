@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/configuration"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// command is a subcommand of the binary. The commands run with the services of the server and
+// print their results as JSON on stdout, the logs going to stderr.
+type command struct {
+	Name    string
+	Usage   string
+	Summary string
+	Run     func(ctx context.Context, cfg configuration.Config, args []string) error
+}
+
+var commands = []command{
+	{Name: "serve", Usage: "serve", Summary: "run the HTTP and gRPC servers, the default command", Run: serveCommand},
+	{Name: "migrate", Usage: "migrate [-dir db/migrations] [up|down]",
+		Summary: "apply the migrations not applied yet, or revert the last one", Run: migrateCommand},
+	{Name: "import families", Usage: "import families [-dry-run] <file.csv|file.xlsx>",
+		Summary: "import families and persons from a file", Run: importFamiliesCommand},
+	{Name: "report distributions", Usage: "report distributions [-month 2006-01] [-group-by resource]",
+		Summary: "report the donations of a month, the current one by default", Run: reportDistributionsCommand},
+	{Name: "stock reconcile", Usage: "stock reconcile [-fix]",
+		Summary: "list the resources with negative quantity, setting them to zero with -fix", Run: stockReconcileCommand},
+	{Name: "seed", Usage: "seed", Summary: "add sample resources and families to an empty database", Run: seedCommand},
+	{Name: "rotate-keys", Usage: "rotate-keys",
+		Summary: "encrypt the personal data again with the active key", Run: rotateKeysCommand},
+	{Name: "config print", Usage: "config print",
+		Summary: "print the effective configuration, with the secrets redacted", Run: configPrintCommand},
+}
+
+// run finds the command named by the first args and runs it with the rest, until it ends or
+// SIGINT or SIGTERM is received
+func run(cfg configuration.Config, args []string) error {
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(os.Stdout)
+		return nil
+	}
+
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.Name)
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != cmd.Name {
+			continue
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		return cmd.Run(ctx, cfg, args[len(words):])
+	}
+
+	usage(os.Stderr)
+	return fmt.Errorf("unknown command %q", strings.Join(args, " "))
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: socialassistanceapi <command>")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-62s %s\n", cmd.Usage, cmd.Summary)
+	}
+}
+
+// newFlagSet parses the flags of the command, which come before its arguments
+func newFlagSet(name string, args []string, define func(fs *flag.FlagSet)) (*flag.FlagSet, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	define(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func serveCommand(ctx context.Context, cfg configuration.Config, args []string) error {
+	if _, err := newFlagSet("serve", args, func(fs *flag.FlagSet) {}); err != nil {
+		return err
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	return serve(ctx, cfg, a)
+}
+
+func migrateCommand(ctx context.Context, cfg configuration.Config, args []string) error {
+	var dir string
+	fs, err := newFlagSet("migrate", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&dir, "dir", cfg.Health.MigrationsDir, "directory of the migrations")
+	})
+	if err != nil {
+		return err
+	}
+
+	direction := fs.Arg(0)
+	if direction == "" {
+		direction = "up"
+	}
+	if direction != "up" && direction != "down" {
+		return fmt.Errorf("migrate must be up or down, not %q", direction)
+	}
+
+	version, err := infra.Migrate(dir, cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
+		cfg.MySQL.Password, direction == "down")
+	if err != nil {
+		return fmt.Errorf("cannot migrate: %w", err)
+	}
+
+	return printJSON(map[string]uint{"version": version})
+}
+
+func importFamiliesCommand(ctx context.Context, cfg configuration.Config, args []string) error {
+	var dryRun bool
+	fs, err := newFlagSet("import families", args, func(fs *flag.FlagSet) {
+		fs.BoolVar(&dryRun, "dry-run", false, "only validate the rows")
+	})
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("import families needs the file to import")
+	}
+
+	path := fs.Arg(0)
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if format != infra.SpreadsheetCSV && format != infra.SpreadsheetXLSX {
+		return errors.New("file must be .csv or .xlsx")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows, err := infra.ReadSpreadsheet(format, file)
+	if err != nil {
+		return fmt.Errorf("invalid file: %w", err)
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	res, err := a.ImportService.Import(ctx, service.ImportCreateDto{
+		Filename: filepath.Base(path),
+		DryRun:   dryRun,
+		Rows:     rows,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot import: %w", err)
+	}
+	if err := printJSON(res); err != nil {
+		return err
+	}
+
+	if model.ImportStatus(res.Data.Status) == model.ImportStatusInvalid {
+		return errors.New("the file has rows with errors, nothing was imported")
+	}
+
+	return nil
+}
+
+func reportDistributionsCommand(ctx context.Context, cfg configuration.Config, args []string) error {
+	var month, groupBy string
+	if _, err := newFlagSet("report distributions", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&month, "month", "", "month as 2006-01, the current one by default")
+		fs.StringVar(&groupBy, "group-by", "", "resource, neighborhood, city or month")
+	}); err != nil {
+		return err
+	}
+
+	dto := service.DistributionReportDto{GroupBy: groupBy}
+	if month != "" {
+		first, err := time.Parse("2006-01", month)
+		if err != nil {
+			return errors.New("month must be as 2006-01")
+		}
+		dto.From = first.Format("2006-01-02")
+		dto.To = first.AddDate(0, 1, -1).Format("2006-01-02")
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	res, err := a.ReportService.Distributions(ctx, dto)
+	if err != nil {
+		return fmt.Errorf("cannot report distributions: %w", err)
+	}
+
+	return printJSON(res)
+}
+
+func stockReconcileCommand(ctx context.Context, cfg configuration.Config, args []string) error {
+	var fix bool
+	if _, err := newFlagSet("stock reconcile", args, func(fs *flag.FlagSet) {
+		fs.BoolVar(&fix, "fix", false, "set the negative quantities to zero")
+	}); err != nil {
+		return err
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	res, err := a.ResourceService.Reconcile(ctx, fix)
+	if err != nil {
+		return fmt.Errorf("cannot reconcile stock: %w", err)
+	}
+
+	return printJSON(res)
+}
+
+// seedResources and seedFamilies are the sample data of the development database
+var (
+	seedResources = []service.CreateResourceDto{
+		{Name: "Arroz", Amount: 5, Measurement: "Kg", Quantity: 20},
+		{Name: "Feijão", Amount: 1, Measurement: "Kg", Quantity: 30},
+		{Name: "Óleo", Amount: 0.9, Measurement: "L", Quantity: 15},
+	}
+	seedFamilies = []struct {
+		Family  service.FamilyCreateDto
+		Persons []string
+	}{
+		{
+			Family: service.FamilyCreateDto{Name: "Sauro", Country: "BR", State: "SP", City: "São Paulo",
+				Neighborhood: "Centro Histórico", Street: "R. Vinte e Cinco de Março", Number: "1000", Zipcode: "01021100"},
+			Persons: []string{"Cláudio", "Maria"},
+		},
+		{
+			Family: service.FamilyCreateDto{Name: "Oliveira", Country: "BR", State: "SP", City: "São Paulo",
+				Neighborhood: "Bela Vista", Street: "R. Treze de Maio", Number: "200", Complement: "3B", Zipcode: "01327000"},
+			Persons: []string{"Ana"},
+		},
+	}
+)
+
+func seedCommand(ctx context.Context, cfg configuration.Config, args []string) error {
+	if _, err := newFlagSet("seed", args, func(fs *flag.FlagSet) {}); err != nil {
+		return err
+	}
+	if cfg.Profile == configuration.ProfileProd {
+		return errors.New("seed is not allowed with the prod profile")
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	resources, err := a.ResourceService.FindAll(ctx, model.Query{Limit: 1})
+	if err != nil {
+		return fmt.Errorf("cannot seed: %w", err)
+	}
+	if len(resources.Data) > 0 {
+		log.Info("the database has resources already, nothing was seeded")
+		return nil
+	}
+
+	resourceIDs := []int{}
+	for _, dto := range seedResources {
+		res, err := a.ResourceService.Create(ctx, dto)
+		if err != nil {
+			return fmt.Errorf("cannot seed resource %s: %w", dto.Name, err)
+		}
+		resourceIDs = append(resourceIDs, res.Data.ID)
+	}
+
+	familyIDs := []int{}
+	for _, seed := range seedFamilies {
+		family, err := a.FamilyService.Create(ctx, seed.Family)
+		if err != nil {
+			return fmt.Errorf("cannot seed family %s: %w", seed.Family.Name, err)
+		}
+		familyIDs = append(familyIDs, family.ID)
+
+		for _, name := range seed.Persons {
+			if _, err := a.PersonService.Create(ctx, service.PersonCreateDto{FamilyID: family.ID, Name: name}); err != nil {
+				return fmt.Errorf("cannot seed person %s: %w", name, err)
+			}
+		}
+	}
+
+	err = a.DonateResourceService.Donate(ctx, service.DonateResourceDonateDto{
+		ResourceID: resourceIDs[0],
+		FamilyID:   familyIDs[0],
+		Quantity:   2,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot seed donation: %w", err)
+	}
+
+	return printJSON(map[string][]int{"resources": resourceIDs, "families": familyIDs})
+}
+
+func rotateKeysCommand(ctx context.Context, cfg configuration.Config, args []string) error {
+	if _, err := newFlagSet("rotate-keys", args, func(fs *flag.FlagSet) {}); err != nil {
+		return err
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	rotated, err := a.EncryptionService.RotateKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot rotate keys: %w", err)
+	}
+
+	return printJSON(map[string]int{"rotated": rotated})
+}
+
+func configPrintCommand(ctx context.Context, cfg configuration.Config, args []string) error {
+	if _, err := newFlagSet("config print", args, func(fs *flag.FlagSet) {}); err != nil {
+		return err
+	}
+
+	configuration.Print(os.Stdout, cfg)
+	return nil
+}
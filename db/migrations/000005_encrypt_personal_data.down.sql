@@ -0,0 +1,9 @@
+ALTER TABLE persons
+   DROP INDEX persons_document_bidx_idx,
+   DROP COLUMN document_bidx,
+   DROP COLUMN document,
+   MODIFY name VARCHAR(255) NOT NULL;
+
+ALTER TABLE families
+   MODIFY number  VARCHAR(15)    NOT NULL,
+   MODIFY name    VARCHAR(255)   NOT NULL;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE families
+   MODIFY name    TEXT  NOT NULL,
+   MODIFY number  TEXT  NOT NULL;
+
+ALTER TABLE persons
+   MODIFY name            TEXT           NOT NULL,
+   ADD    document        VARCHAR(512)   NOT NULL DEFAULT '',
+   ADD    document_bidx   CHAR(64),
+   ADD    INDEX persons_document_bidx_idx (document_bidx);
\ No newline at end of file
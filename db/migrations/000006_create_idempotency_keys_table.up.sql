@@ -0,0 +1,10 @@
+CREATE TABLE idempotency_keys (
+   idempotency_key   VARCHAR(255)   PRIMARY KEY,
+   created_at        DATETIME       NOT NULL,
+   expires_at        DATETIME       NOT NULL,
+   request_hash      CHAR(64)       NOT NULL,
+   status            INT            NOT NULL DEFAULT 0,
+   content_type      VARCHAR(255)   NOT NULL DEFAULT '',
+   body              MEDIUMBLOB,
+   INDEX idempotency_keys_expires_at_idx (expires_at)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS search_tokens;
\ No newline at end of file
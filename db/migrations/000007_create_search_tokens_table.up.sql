@@ -0,0 +1,8 @@
+CREATE TABLE search_tokens (
+   entity      VARCHAR(32)   NOT NULL,
+   entity_id   INT           NOT NULL,
+   field       VARCHAR(32)   NOT NULL,
+   token       CHAR(64)      NOT NULL,
+   PRIMARY KEY (entity, field, token, entity_id),
+   INDEX search_tokens_entity_idx (entity, entity_id)
+);
\ No newline at end of file
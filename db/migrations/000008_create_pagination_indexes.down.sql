@@ -0,0 +1,3 @@
+DROP INDEX families_created_at_id_idx ON families;
+DROP INDEX persons_created_at_id_idx ON persons;
+DROP INDEX resources_created_at_id_idx ON resources;
\ No newline at end of file
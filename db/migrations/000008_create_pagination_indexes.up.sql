@@ -0,0 +1,3 @@
+CREATE INDEX families_created_at_id_idx ON families (created_at, id);
+CREATE INDEX persons_created_at_id_idx ON persons (created_at, id);
+CREATE INDEX resources_created_at_id_idx ON resources (created_at, id);
\ No newline at end of file
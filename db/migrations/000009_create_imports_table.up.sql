@@ -0,0 +1,11 @@
+CREATE TABLE imports (
+   id          INT            AUTO_INCREMENT PRIMARY KEY,
+   created_at  DATETIME       NOT NULL,
+   filename    VARCHAR(255)   NOT NULL,
+   dry_run     BOOLEAN        NOT NULL,
+   status      VARCHAR(16)    NOT NULL,
+   total_rows  INT            NOT NULL,
+   families    INT            NOT NULL,
+   persons     INT            NOT NULL,
+   errors      MEDIUMTEXT     NOT NULL
+);
\ No newline at end of file
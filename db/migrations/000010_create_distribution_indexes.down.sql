@@ -0,0 +1 @@
+DROP INDEX resources_to_families_created_at_idx ON resources_to_families;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX resources_to_families_created_at_idx ON resources_to_families (created_at);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS receipts;
+DROP TABLE IF EXISTS receipt_sequences;
\ No newline at end of file
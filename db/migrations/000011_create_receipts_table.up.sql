@@ -0,0 +1,14 @@
+CREATE TABLE receipt_sequences (
+   organization VARCHAR(255)   PRIMARY KEY,
+   last_number  INT            NOT NULL
+);
+
+CREATE TABLE receipts (
+   id           INT            AUTO_INCREMENT PRIMARY KEY,
+   created_at   DATETIME       NOT NULL,
+   organization VARCHAR(255)   NOT NULL,
+   number       INT            NOT NULL,
+   donation_id  INT            NOT NULL,
+   CONSTRAINT receipts_number_uk UNIQUE (organization, number),
+   CONSTRAINT receipts_donation_uk UNIQUE (organization, donation_id)
+);
\ No newline at end of file
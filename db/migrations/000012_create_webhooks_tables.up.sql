@@ -0,0 +1,34 @@
+CREATE TABLE webhook_subscriptions (
+   id           INT            AUTO_INCREMENT PRIMARY KEY,
+   created_at   DATETIME       NOT NULL,
+   updated_at   DATETIME       NOT NULL,
+   deleted_at   DATETIME,
+   url          VARCHAR(2048)  NOT NULL,
+   events       VARCHAR(255)   NOT NULL,
+   secret       VARCHAR(512)   NOT NULL,
+   active       BOOLEAN        NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE webhook_deliveries (
+   id              INT            AUTO_INCREMENT PRIMARY KEY,
+   created_at      DATETIME       NOT NULL,
+   updated_at      DATETIME       NOT NULL,
+   subscription_id INT            NOT NULL,
+   event           VARCHAR(50)    NOT NULL,
+   payload         TEXT           NOT NULL,
+   status          VARCHAR(20)    NOT NULL,
+   attempts        INT            NOT NULL DEFAULT 0,
+   next_attempt_at DATETIME       NOT NULL,
+   CONSTRAINT webhook_deliveries_subscription_fk FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id),
+   INDEX webhook_deliveries_pending_idx (status, next_attempt_at)
+);
+
+CREATE TABLE webhook_delivery_attempts (
+   id          INT            AUTO_INCREMENT PRIMARY KEY,
+   created_at  DATETIME       NOT NULL,
+   delivery_id INT            NOT NULL,
+   status_code INT            NOT NULL,
+   error       VARCHAR(1000)  NOT NULL,
+   duration_ms INT            NOT NULL,
+   CONSTRAINT webhook_delivery_attempts_delivery_fk FOREIGN KEY (delivery_id) REFERENCES webhook_deliveries(id)
+);
\ No newline at end of file
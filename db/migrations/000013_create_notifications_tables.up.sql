@@ -0,0 +1,28 @@
+CREATE TABLE person_contacts (
+   person_id   INT            PRIMARY KEY,
+   updated_at  DATETIME       NOT NULL,
+   email       VARCHAR(512)   NOT NULL DEFAULT '',
+   phone       VARCHAR(512)   NOT NULL DEFAULT '',
+   channels    VARCHAR(50)    NOT NULL DEFAULT '',
+   CONSTRAINT person_contacts_persons_fk FOREIGN KEY (person_id) REFERENCES persons (id)
+);
+
+CREATE TABLE notifications (
+   id              INT            AUTO_INCREMENT PRIMARY KEY,
+   created_at      DATETIME       NOT NULL,
+   updated_at      DATETIME       NOT NULL,
+   person_id       INT            NOT NULL,
+   channel         VARCHAR(20)    NOT NULL,
+   template        VARCHAR(50)    NOT NULL,
+   recipient       VARCHAR(512)   NOT NULL,
+   subject         VARCHAR(255)   NOT NULL,
+   body            TEXT           NOT NULL,
+   status          VARCHAR(20)    NOT NULL,
+   attempts        INT            NOT NULL DEFAULT 0,
+   next_attempt_at DATETIME       NOT NULL,
+   error           VARCHAR(1000)  NOT NULL DEFAULT '',
+   sent_at         DATETIME,
+   CONSTRAINT notifications_persons_fk FOREIGN KEY (person_id) REFERENCES persons (id),
+   INDEX notifications_pending_idx (status, next_attempt_at),
+   INDEX notifications_created_at_idx (created_at, id)
+);
\ No newline at end of file
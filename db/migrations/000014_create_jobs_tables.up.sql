@@ -0,0 +1,19 @@
+CREATE TABLE job_leases (
+   job          VARCHAR(50)    PRIMARY KEY,
+   owner        VARCHAR(255)   NOT NULL,
+   locked_until DATETIME       NOT NULL
+);
+
+CREATE TABLE job_runs (
+   id           INT            AUTO_INCREMENT PRIMARY KEY,
+   job          VARCHAR(50)    NOT NULL,
+   triggered_by VARCHAR(20)    NOT NULL,
+   owner        VARCHAR(255)   NOT NULL,
+   status       VARCHAR(20)    NOT NULL,
+   started_at   DATETIME       NOT NULL,
+   finished_at  DATETIME,
+   duration_ms  BIGINT         NOT NULL DEFAULT 0,
+   error        VARCHAR(1000)  NOT NULL DEFAULT '',
+   INDEX job_runs_job_idx (job, id),
+   INDEX job_runs_started_at_idx (started_at)
+);
\ No newline at end of file
@@ -16,7 +16,32 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/families": {
+        "/api/health": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "ping the database",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/service.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/jobs": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -25,20 +50,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "family"
+                    "admin"
                 ],
-                "summary": "find all families",
+                "summary": "find the scheduled jobs with their next and last run",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.JobsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/jobs/{job}/runs": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "list the last runs of a job",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "limit pagination",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "job name",
+                        "name": "job",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "offset pagination",
-                        "name": "offset",
+                        "description": "number of runs, up to 100",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -46,12 +100,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.FamiliesResponse"
+                            "$ref": "#/definitions/service.JobRunsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
             },
             "post": {
+                "description": "The job runs in background, its run is followed at the runs of the job.",
                 "consumes": [
                     "application/json"
                 ],
@@ -59,43 +132,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "family"
+                    "admin"
                 ],
-                "summary": "create an family",
+                "summary": "run a job now",
                 "parameters": [
                     {
-                        "description": "Create family",
-                        "name": "family",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/service.FamilyCreateDto"
-                        }
+                        "type": "string",
+                        "description": "job name",
+                        "name": "job",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/service.FamilyResponse"
+                            "$ref": "#/definitions/service.JobRunResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/families/{id}": {
+        "/api/v1/dashboard": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -104,120 +181,157 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "family"
+                    "dashboard"
                 ],
-                "summary": "find family by id",
+                "summary": "operational statistics of families, persons, stock and donations",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "family ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "daily series of the last days including today, as 30d",
+                        "name": "range",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.FamiliesResponse"
+                            "$ref": "#/definitions/service.DashboardResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/donations/{id}/receipt.pdf": {
+            "get": {
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "family"
+                    "donation"
                 ],
-                "summary": "delete an family",
+                "summary": "printable receipt of a donation to be signed by the family",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "family ID",
+                        "description": "donation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
-            },
-            "patch": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/exports/{entity}": {
+            "get": {
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "family"
+                    "export"
                 ],
-                "summary": "update an family",
+                "summary": "export families, persons, resources or donations as CSV or XLSX",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "family ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "families, persons, resources or donations",
+                        "name": "entity",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update family",
-                        "name": "family",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/service.FamilyUpdateDto"
-                        }
+                        "type": "string",
+                        "description": "csv or xlsx, otherwise chosen by the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "exported columns in order, separated by commas",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter as in the lists",
+                        "name": "filter[field]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort as in the lists",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/persons": {
+        "/api/v1/families": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -226,14 +340,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "family"
+                ],
+                "summary": "find all families",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size, up to 50",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the page, taken from next_cursor or previous_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "count the matching rows",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by id, created_at, updated_at, name, country, state, city, neighborhood, street, number, complement or zipcode",
+                        "name": "filter[field][operator]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-created_at",
+                        "description": "sort by fields, descending when prefixed by -",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "persons,donations",
+                        "description": "related resources: persons, donations",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,name,persons",
+                        "description": "only return these fields",
+                        "name": "fields",
+                        "in": "query"
+                    }
                 ],
-                "summary": "find all persons",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.PersonResponse"
+                            "$ref": "#/definitions/api.FamiliesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
@@ -246,43 +413,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "family"
                 ],
-                "summary": "create a person",
+                "summary": "create an family",
                 "parameters": [
                     {
-                        "description": "Create person",
-                        "name": "person",
+                        "description": "Create family",
+                        "name": "family",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/service.PersonCreateDto"
+                            "$ref": "#/definitions/service.FamilyCreateDto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/service.PersonResponse"
+                            "$ref": "#/definitions/service.FamilyResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/persons/{id}": {
+        "/api/v1/families/{id}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -291,29 +476,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "family"
                 ],
-                "summary": "find person by id",
+                "summary": "find family by id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "person ID",
+                        "description": "family ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "persons,donations",
+                        "description": "related resources: persons, donations",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,name,persons",
+                        "description": "only return these fields",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.PersonsResponse"
+                            "$ref": "#/definitions/api.FamilyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
@@ -326,13 +531,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "family"
                 ],
-                "summary": "delete a person",
+                "summary": "delete an family",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "person ID",
+                        "description": "family ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -345,13 +550,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
@@ -364,24 +569,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "family"
                 ],
-                "summary": "update a person",
+                "summary": "update an family",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "person ID",
+                        "description": "family ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update person",
-                        "name": "person",
+                        "description": "Update family",
+                        "name": "family",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/service.PersonUpdateDto"
+                            "$ref": "#/definitions/service.FamilyUpdateDto"
                         }
                     }
                 ],
@@ -392,40 +597,87 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/resources": {
-            "get": {
+        "/api/v1/imports": {
+            "post": {
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resource"
+                    "import"
+                ],
+                "summary": "import families and persons from a CSV or XLSX file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file with the columns family, name, country, state, city, neighborhood, street, number, complement, zipcode, person_name and person_document",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only validate the rows",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "find resource by id",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "validated by a dry run",
                         "schema": {
-                            "$ref": "#/definitions/service.ResourceResponse"
+                            "$ref": "#/definitions/service.ImportResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "imported",
+                        "schema": {
+                            "$ref": "#/definitions/service.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "rows with errors, nothing was imported",
+                        "schema": {
+                            "$ref": "#/definitions/service.ImportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/imports/{id}": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -433,44 +685,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "resource"
+                    "import"
                 ],
-                "summary": "create a resource",
+                "summary": "find import by id",
                 "parameters": [
                     {
-                        "description": "Create resource",
-                        "name": "resource",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/service.CreateResourceDto"
-                        }
+                        "type": "integer",
+                        "description": "import ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/service.ResourceResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/service.ImportResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/resources/{id}": {
-            "patch": {
+        "/api/v1/notifications": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -478,48 +722,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "resource"
+                    "notification"
                 ],
-                "summary": "update a resource",
+                "summary": "find the delivery log of the notifications",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "resource ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "page size, up to 50",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Update resource",
-                        "name": "resource",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/service.UpdateResourceDto"
-                        }
+                        "type": "string",
+                        "description": "cursor of the page, taken from next_cursor or previous_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "count the matching rows",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by id, created_at, person_id, channel, template, status or attempts",
+                        "name": "filter[field][operator]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-created_at",
+                        "description": "sort by fields, descending when prefixed by -",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,channel,status",
+                        "description": "only return these fields",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.NotificationsResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/resources/{id}/donate": {
+            },
             "post": {
+                "description": "Templates are basket_ready and appointment_reminder, which needs the date.\nThe notifications are queued and sent in background, retried when the sender fails.",
                 "consumes": [
                     "application/json"
                 ],
@@ -527,48 +795,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "resource"
+                    "notification"
                 ],
-                "summary": "donate a resource",
+                "summary": "notify a person, or every person of a family, on the channels they opted in to",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "resource ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Donate a resource",
-                        "name": "resource",
+                        "description": "Notify",
+                        "name": "notification",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/service.DonateResourceDonateDto"
+                            "$ref": "#/definitions/service.NotifyDto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/service.NotifyResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/resources/{id}/quantity": {
-            "patch": {
+        "/api/v1/persons": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -576,48 +864,134 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "resource"
+                    "person"
                 ],
-                "summary": "update a resource quantity",
+                "summary": "find all persons",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "resource ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "page size, up to 50",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Update resource quantity",
-                        "name": "resource",
+                        "type": "string",
+                        "description": "cursor of the page, taken from next_cursor or previous_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "count the matching rows",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by id, created_at, updated_at, family_id, name or document",
+                        "name": "filter[field][operator]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-created_at",
+                        "description": "sort by fields, descending when prefixed by -",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,name",
+                        "description": "only return these fields",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "same as filter[document], kept for the former clients",
+                        "name": "document",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.PersonsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "create a person",
+                "parameters": [
+                    {
+                        "description": "Create person",
+                        "name": "person",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/service.UpdateResourceQuantityDto"
+                            "$ref": "#/definitions/service.PersonCreateDto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/service.PersonResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/resources/{id}/return": {
-            "delete": {
+        "/api/v1/persons/{id}": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -625,87 +999,1434 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "resource"
+                    "person"
                 ],
-                "summary": "Return a doneted resource",
+                "summary": "find person by id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "resource ID",
+                        "description": "person ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Return a doneted resource",
-                        "name": "resource",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/service.DonateResourceDonateDto"
-                        }
+                        "type": "string",
+                        "example": "id,name",
+                        "description": "only return these fields",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/service.PersonsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/api.HttpError"
+                            "$ref": "#/definitions/api.Problem"
                         }
                     }
                 }
-            }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "delete a person",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "update a person",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update person",
+                        "name": "person",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.PersonUpdateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/persons/{id}/contact": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "find the contact of a person",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.PersonContactResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "save the contact of a person and the channels the person opted in to",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Save contact",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.SavePersonContactDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.PersonContactResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/distributions": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "report donated quantities and families served by period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "first day as 2006-01-02, the first day of the month by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day as 2006-01-02, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "resource",
+                        "description": "resource, neighborhood, city or month",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.DistributionReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resources": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resource"
+                ],
+                "summary": "find resource by id",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ResourceResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resource"
+                ],
+                "summary": "create a resource",
+                "parameters": [
+                    {
+                        "description": "Create resource",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.CreateResourceDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/service.ResourceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resources/stream": {
+            "get": {
+                "description": "Server-Sent Events named stock with a service.StockEvent as data, and ping every few seconds.\nReconnecting with the Last-Event-ID header, or the last_event_id parameter, sends the missed events.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "resource"
+                ],
+                "summary": "stream the quantity of the resources as they change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the last received event",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the last received event",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.StockEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resources/{id}": {
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resource"
+                ],
+                "summary": "update a resource",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update resource",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.UpdateResourceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resources/{id}/donate": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resource"
+                ],
+                "summary": "donate a resource",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Donate a resource",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.DonateResourceDonateDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resources/{id}/quantity": {
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resource"
+                ],
+                "summary": "update a resource quantity",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update resource quantity",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.UpdateResourceQuantityDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resources/{id}/return": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resource"
+                ],
+                "summary": "Return a doneted resource",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Return a doneted resource",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.DonateResourceDonateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "search families and persons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "maria rua 25",
+                        "description": "words of family names, addresses, person names or a document",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max hits, up to 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "find all webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.WebhooksResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "subscribe a webhook to events",
+                "parameters": [
+                    {
+                        "description": "Create webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.WebhookCreateDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/service.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "find webhook by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "delete a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "update a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.WebhookUpdateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}/deliveries": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "list the last deliveries of a webhook with each attempt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of deliveries, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.WebhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "query and change families, persons, resources and donations with GraphQL",
+                "parameters": [
+                    {
+                        "description": "GraphQL query and variables",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.GraphqlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "liveness probe, up while the process answers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "readiness probe, up while the database, migrations, disk, scheduler and queues are",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/service.ReadinessResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
-        "api.HttpError": {
+        "api.FamiliesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Family"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/service.QueryMeta"
+                },
+                "next": {
+                    "type": "string",
+                    "example": "localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9\u0026limit=10"
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"
+                },
+                "previous": {
+                    "type": "string",
+                    "example": "localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9\u0026limit=10"
+                },
+                "previous_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "api.Family": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "São Paulo"
+                },
+                "complement": {
+                    "type": "string",
+                    "example": "1A"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "BR"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "donations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Donation"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sauro"
+                },
+                "neighborhood": {
+                    "type": "string",
+                    "example": "Centro Histórico"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "persons": {
+                    "description": "Persons and Donations are only present when included",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Person"
+                    }
+                },
+                "state": {
+                    "type": "string",
+                    "example": "SP"
+                },
+                "street": {
+                    "type": "string",
+                    "example": "R. Vinte e Cinco de Março"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "zipcode": {
+                    "type": "string",
+                    "example": "01021100"
+                }
+            }
+        },
+        "api.FamilyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/api.Family"
+                }
+            }
+        },
+        "api.GraphqlRequest": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "api.Problem": {
             "type": "object",
             "properties": {
                 "code": {
+                    "type": "string",
+                    "example": "resource.insufficient_stock"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "resource 1 quantity is 2.0"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ProblemField"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/resources/1/donate"
+                },
+                "status": {
                     "type": "integer",
                     "example": 400
                 },
+                "title": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "api.ProblemField": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "required"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "quantity"
+                },
                 "message": {
                     "type": "string",
-                    "example": "invalid parameter"
+                    "example": "quantity is required"
+                }
+            }
+        },
+        "service.CreateResourceDto": {
+            "type": "object",
+            "required": [
+                "amount",
+                "measurement",
+                "name",
+                "quantity"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "measurement": {
+                    "type": "string",
+                    "example": "Kg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Arroz"
+                },
+                "quantity": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "service.Dashboard": {
+            "type": "object",
+            "properties": {
+                "active_families": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "generated_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "last_month": {
+                    "$ref": "#/definitions/service.DashboardDistributions"
+                },
+                "persons_served": {
+                    "type": "integer",
+                    "example": 310
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DashboardDay"
+                    }
+                },
+                "stock": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DistributionTotal"
+                    }
+                },
+                "this_month": {
+                    "$ref": "#/definitions/service.DashboardDistributions"
+                },
+                "top_resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DashboardResource"
+                    }
+                }
+            }
+        },
+        "service.DashboardDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2000-01-01"
+                },
+                "donations": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "families": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "persons": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 10
+                },
+                "resources": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "service.DashboardDistributions": {
+            "type": "object",
+            "properties": {
+                "donations": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "families": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "service.DashboardResource": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Arroz"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 50
+                },
+                "resource_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "$ref": "#/definitions/service.DistributionTotal"
+                }
+            }
+        },
+        "service.DashboardResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.Dashboard"
+                }
+            }
+        },
+        "service.DistributionReport": {
+            "type": "object",
+            "properties": {
+                "donations": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "families": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "group": {
+                    "type": "string",
+                    "example": "Arroz"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 50
+                },
+                "resource_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "totals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DistributionTotal"
+                    }
+                }
+            }
+        },
+        "service.DistributionReportMeta": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2000-01-01"
+                },
+                "group_by": {
+                    "type": "string",
+                    "example": "resource"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                }
+            }
+        },
+        "service.DistributionReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.DistributionReport"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/service.DistributionReportMeta"
                 }
             }
         },
-        "service.CreateResourceDto": {
+        "service.DistributionTotal": {
             "type": "object",
-            "required": [
-                "amount",
-                "measurement",
-                "name",
-                "quantity"
-            ],
             "properties": {
                 "amount": {
                     "type": "number",
-                    "minimum": 0,
-                    "example": 5
+                    "example": 250
                 },
                 "measurement": {
                     "type": "string",
                     "example": "Kg"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Arroz"
-                },
-                "quantity": {
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 10
                 }
             }
         },
@@ -727,14 +2448,28 @@ const docTemplate = `{
                 }
             }
         },
-        "service.FamiliesResponse": {
+        "service.Donation": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/service.Family"
-                    }
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "family_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 10
+                },
+                "resource_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
@@ -895,6 +2630,364 @@ const docTemplate = `{
                 }
             }
         },
+        "service.FilterMeta": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "city"
+                },
+                "operator": {
+                    "type": "string",
+                    "example": "eq"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "São Paulo"
+                }
+            }
+        },
+        "service.HealthCheckResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "database"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/service.HealthStatus"
+                        }
+                    ],
+                    "example": "up"
+                }
+            }
+        },
+        "service.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/service.HealthStatus"
+                }
+            }
+        },
+        "service.HealthStatus": {
+            "type": "string",
+            "enum": [
+                "up",
+                "down"
+            ],
+            "x-enum-varnames": [
+                "HealthStatusUp",
+                "HealthStatusDown"
+            ]
+        },
+        "service.Import": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRowError"
+                    }
+                },
+                "families": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "filename": {
+                    "type": "string",
+                    "example": "centro.csv"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "persons": {
+                    "type": "integer",
+                    "example": 110
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                }
+            }
+        },
+        "service.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.Import"
+                }
+            }
+        },
+        "service.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "zipcode"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "zipcode is required"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "service.Job": {
+            "type": "object",
+            "properties": {
+                "last_run": {
+                    "$ref": "#/definitions/service.JobRun"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "purge_idempotency_keys"
+                },
+                "next_run_at": {
+                    "type": "string",
+                    "example": "2000-01-01T13:00:00"
+                },
+                "schedule": {
+                    "description": "Schedule is the cron expression, jobs without one only run when triggered",
+                    "type": "string",
+                    "example": "0 * * * *"
+                }
+            }
+        },
+        "service.JobRun": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:01"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "job": {
+                    "type": "string",
+                    "example": "purge_idempotency_keys"
+                },
+                "owner": {
+                    "type": "string",
+                    "example": "api-7d9f-1"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "succeeded"
+                },
+                "triggered_by": {
+                    "type": "string",
+                    "example": "schedule"
+                }
+            }
+        },
+        "service.JobRunResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.JobRun"
+                }
+            }
+        },
+        "service.JobRunsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.JobRun"
+                    }
+                }
+            }
+        },
+        "service.JobsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Job"
+                    }
+                }
+            }
+        },
+        "service.Notification": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Olá, Maria! Lembramos que seu atendimento está marcado para 02/01/2000."
+                },
+                "channel": {
+                    "type": "string",
+                    "example": "whatsapp"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "person_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recipient": {
+                    "type": "string",
+                    "example": "+5521999999999"
+                },
+                "sent_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:05"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "sent"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Lembrete de atendimento"
+                },
+                "template": {
+                    "type": "string",
+                    "example": "appointment_reminder"
+                }
+            }
+        },
+        "service.NotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Notification"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/service.QueryMeta"
+                },
+                "next": {
+                    "type": "string",
+                    "example": "localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9\u0026limit=10"
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"
+                },
+                "previous": {
+                    "type": "string",
+                    "example": "localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9\u0026limit=10"
+                },
+                "previous_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "service.NotifyDto": {
+            "type": "object",
+            "required": [
+                "template"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2000-01-02"
+                },
+                "family_id": {
+                    "description": "FamilyID notifies every person of the family, PersonID only the person",
+                    "type": "integer",
+                    "example": 1
+                },
+                "person_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "place": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Rua Barão da Torre, 100"
+                },
+                "template": {
+                    "type": "string",
+                    "enum": [
+                        "basket_ready",
+                        "appointment_reminder",
+                        "basket_pickup_reminder"
+                    ],
+                    "example": "appointment_reminder"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "09:30"
+                }
+            }
+        },
+        "service.NotifyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Notification"
+                    }
+                }
+            }
+        },
         "service.Person": {
             "type": "object",
             "properties": {
@@ -906,6 +2999,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2000-01-01T12:03:00"
                 },
+                "document": {
+                    "type": "string",
+                    "example": "123.456.789-00"
+                },
                 "family_id": {
                     "type": "integer",
                     "example": 1
@@ -924,6 +3021,45 @@ const docTemplate = `{
                 }
             }
         },
+        "service.PersonContact": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "email",
+                        "whatsapp"
+                    ]
+                },
+                "email": {
+                    "type": "string",
+                    "example": "maria@email.com"
+                },
+                "person_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+5521999999999"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                }
+            }
+        },
+        "service.PersonContactResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.PersonContact"
+                }
+            }
+        },
         "service.PersonCreateDto": {
             "type": "object",
             "required": [
@@ -931,6 +3067,10 @@ const docTemplate = `{
                 "name"
             ],
             "properties": {
+                "document": {
+                    "type": "string",
+                    "example": "123.456.789-00"
+                },
                 "family_id": {
                     "type": "integer",
                     "example": 1
@@ -952,6 +3092,10 @@ const docTemplate = `{
         "service.PersonUpdateDto": {
             "type": "object",
             "properties": {
+                "document": {
+                    "type": "string",
+                    "example": "123.456.789-00"
+                },
                 "family_id": {
                     "type": "integer",
                     "example": 1
@@ -970,6 +3114,68 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/service.Person"
                     }
+                },
+                "meta": {
+                    "$ref": "#/definitions/service.QueryMeta"
+                },
+                "next": {
+                    "type": "string",
+                    "example": "localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9\u0026limit=10"
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"
+                },
+                "previous": {
+                    "type": "string",
+                    "example": "localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9\u0026limit=10"
+                },
+                "previous_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "service.QueryMeta": {
+            "type": "object",
+            "properties": {
+                "filters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.FilterMeta"
+                    }
+                },
+                "sort": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "-created_at"
+                    ]
+                }
+            }
+        },
+        "service.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.HealthCheckResult"
+                    }
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/service.HealthStatus"
+                        }
+                    ],
+                    "example": "up"
                 }
             }
         },
@@ -1026,6 +3232,120 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/service.Resource"
                     }
+                },
+                "meta": {
+                    "$ref": "#/definitions/service.QueryMeta"
+                },
+                "next": {
+                    "type": "string",
+                    "example": "localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9\u0026limit=10"
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"
+                },
+                "previous": {
+                    "type": "string",
+                    "example": "localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9\u0026limit=10"
+                },
+                "previous_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "service.SavePersonContactDto": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "description": "Channels the person opted in to, each needs its email or phone",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "email",
+                        "whatsapp"
+                    ]
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "maria@email.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+5521999999999"
+                }
+            }
+        },
+        "service.SearchHit": {
+            "type": "object",
+            "properties": {
+                "family": {
+                    "$ref": "#/definitions/service.Family"
+                },
+                "highlights": {
+                    "description": "Highlights has the matched fields with the matched words wrapped in \u003cem\u003e, escaped as HTML",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "person": {
+                    "$ref": "#/definitions/service.Person"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "type": {
+                    "type": "string",
+                    "example": "family"
+                }
+            }
+        },
+        "service.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.SearchHit"
+                    }
+                }
+            }
+        },
+        "service.StockEvent": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "measurement": {
+                    "type": "string",
+                    "example": "Kg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Arroz"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 10
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "donate"
+                },
+                "resource_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
@@ -1059,6 +3379,189 @@ const docTemplate = `{
                     "example": 10
                 }
             }
+        },
+        "service.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "family.created",
+                        "donation.created"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "secret": {
+                    "description": "Secret is only returned when the webhook is created",
+                    "type": "string",
+                    "example": "2f1c0e7d9a8b4c3d2f1c0e7d9a8b4c3d"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.org/webhooks"
+                }
+            }
+        },
+        "service.WebhookAttempt": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 500
+                }
+            }
+        },
+        "service.WebhookCreateDto": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "family.created",
+                        "donation.created"
+                    ]
+                },
+                "secret": {
+                    "description": "Secret signs the deliveries, a random one is generated when empty",
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 16,
+                    "example": "2f1c0e7d9a8b4c3d2f1c0e7d9a8b4c3d"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.org/webhooks"
+                }
+            }
+        },
+        "service.WebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.WebhookDelivery"
+                    }
+                }
+            }
+        },
+        "service.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:00"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "family.created"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.WebhookAttempt"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "next_attempt_at": {
+                    "type": "string",
+                    "example": "2000-01-01T12:03:30"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "service.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.Webhook"
+                }
+            }
+        },
+        "service.WebhookUpdateDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "resource.low_stock"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.org/webhooks"
+                }
+            }
+        },
+        "service.WebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Webhook"
+                    }
+                }
+            }
         }
     }
 }`
@@ -1,23 +1,38 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/viniosilva/socialassistanceapi/docs"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
 	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate mockgen -destination ../../mock/api_mock.go -package mock . Api
 type Api interface {
 	Configure()
-	Start()
+	Start() error
+	Shutdown(ctx context.Context) error
 }
 
 type ApiImpl struct {
@@ -28,6 +43,32 @@ type ApiImpl struct {
 	FamilyService         service.FamilyService
 	ResourceService       service.ResourceService
 	DonateResourceService service.DonateResourceService
+	IdempotencyService    service.IdempotencyService
+	SearchService         service.SearchService
+	ImportService         service.ImportService
+	ExportService         service.ExportService
+	ReportService         service.ReportService
+	ReceiptService        service.ReceiptService
+	DashboardService      service.DashboardService
+	WebhookService        service.WebhookService
+	StockService          service.StockService
+	NotificationService   service.NotificationService
+	JobService            service.JobService
+	// MetricsHandler serves /metrics when set
+	MetricsHandler  http.Handler
+	StreamHeartbeat time.Duration
+
+	Server            *http.Server
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	// WriteTimeout also ends the stock stream, whose clients reconnect without losing events
+	WriteTimeout   time.Duration
+	IdleTimeout    time.Duration
+	MaxHeaderBytes int
+	// MaxBodyBytes bounds the request bodies, but the imports bounded by maxImportSize
+	MaxBodyBytes int64
+
+	shutdown chan struct{}
 }
 
 // @title Ipanema Box API
@@ -35,16 +76,32 @@ type ApiImpl struct {
 // @description person, budget and service management
 // @BasePath /api/v1
 func (impl *ApiImpl) Configure() {
+	impl.shutdown = make(chan struct{})
+
 	api := gin.New()
+	// the handlers pass the gin context on, whose request context carries the span
+	api.ContextWithFallback = true
 	api.Use(cors.Default())
 	api.Use(gin.Recovery())
 	api.Use(impl.JSONLogMiddleware())
+	api.Use(impl.MetricsMiddleware)
+	// the bodies are limited before IdempotencyMiddleware reads them
+	api.Use(impl.BodyLimitMiddleware)
+	api.Use(impl.IdempotencyMiddleware)
+	api.Use(impl.ErrorMiddleware)
+	api.NoRoute(func(c *gin.Context) {
+		NewProblem(c, http.StatusNotFound, ProblemCodeRouteNotFound, fmt.Sprintf("%s %s not found", c.Request.Method, c.Request.URL.Path))
+	})
 
 	docs.SwaggerInfo.Host = impl.Addr
 	api.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
+	if impl.MetricsHandler != nil {
+		api.GET("/metrics", gin.WrapH(impl.MetricsHandler))
+	}
 
 	healthApi := &HealthApiImpl{
 		Router:          api.Group("/api/health"),
+		ProbeRouter:     api.Group("/health"),
 		HealthService:   impl.HealthService,
 		TraceMiddleware: impl.TraceMiddleware,
 	}
@@ -52,6 +109,7 @@ func (impl *ApiImpl) Configure() {
 		Router:          api.Group("/api/v1/persons"),
 		PersonService:   impl.PersonService,
 		TraceMiddleware: impl.TraceMiddleware,
+		Addr:            fmt.Sprintf("%s/api/v1/persons", impl.Addr),
 	}
 	familyApi := &FamilyApiImpl{
 		Router:          api.Group("/api/v1/families"),
@@ -63,24 +121,125 @@ func (impl *ApiImpl) Configure() {
 		Router:          api.Group("/api/v1/resources"),
 		ResourceService: impl.ResourceService,
 		TraceMiddleware: impl.TraceMiddleware,
+		Addr:            fmt.Sprintf("%s/api/v1/resources", impl.Addr),
 	}
 	donateResourceApi := &DonateResourceApiImpl{
 		Router:                api.Group("/api/v1/resources"),
 		DonateResourceService: impl.DonateResourceService,
 		TraceMiddleware:       impl.TraceMiddleware,
 	}
+	resourceStreamApi := &ResourceStreamApiImpl{
+		Router:          api.Group("/api/v1/resources"),
+		StockService:    impl.StockService,
+		TraceMiddleware: impl.TraceMiddleware,
+		Heartbeat:       impl.StreamHeartbeat,
+		Done:            impl.shutdown,
+	}
+	searchApi := &SearchApiImpl{
+		Router:          api.Group("/api/v1/search"),
+		SearchService:   impl.SearchService,
+		TraceMiddleware: impl.TraceMiddleware,
+	}
+
+	importApi := &ImportApiImpl{
+		Router:          api.Group("/api/v1/imports"),
+		ImportService:   impl.ImportService,
+		TraceMiddleware: impl.TraceMiddleware,
+	}
+	exportApi := &ExportApiImpl{
+		Router:          api.Group("/api/v1/exports"),
+		ExportService:   impl.ExportService,
+		TraceMiddleware: impl.TraceMiddleware,
+	}
+	reportApi := &ReportApiImpl{
+		Router:          api.Group("/api/v1/reports"),
+		ReportService:   impl.ReportService,
+		TraceMiddleware: impl.TraceMiddleware,
+	}
+	receiptApi := &ReceiptApiImpl{
+		Router:          api.Group("/api/v1/donations"),
+		ReceiptService:  impl.ReceiptService,
+		TraceMiddleware: impl.TraceMiddleware,
+	}
+	dashboardApi := &DashboardApiImpl{
+		Router:           api.Group("/api/v1/dashboard"),
+		DashboardService: impl.DashboardService,
+		TraceMiddleware:  impl.TraceMiddleware,
+	}
+	webhookApi := &WebhookApiImpl{
+		Router:          api.Group("/api/v1/webhooks"),
+		WebhookService:  impl.WebhookService,
+		TraceMiddleware: impl.TraceMiddleware,
+	}
+	notificationApi := &NotificationApiImpl{
+		Router:              api.Group("/api/v1/notifications"),
+		PersonRouter:        api.Group("/api/v1/persons"),
+		NotificationService: impl.NotificationService,
+		TraceMiddleware:     impl.TraceMiddleware,
+		Addr:                fmt.Sprintf("%s/api/v1/notifications", impl.Addr),
+	}
+	jobApi := &JobApiImpl{
+		Router:          api.Group("/api/v1/admin/jobs"),
+		JobService:      impl.JobService,
+		TraceMiddleware: impl.TraceMiddleware,
+	}
+	graphqlApi := &GraphqlApiImpl{
+		Router:                api.Group("/graphql"),
+		FamilyService:         impl.FamilyService,
+		PersonService:         impl.PersonService,
+		ResourceService:       impl.ResourceService,
+		DonateResourceService: impl.DonateResourceService,
+		TraceMiddleware:       impl.TraceMiddleware,
+	}
 
 	healthApi.Configure()
 	personApi.Configure()
 	familyApi.Configure()
 	resourceApi.Configure()
 	donateResourceApi.Configure()
+	resourceStreamApi.Configure()
+	searchApi.Configure()
+	importApi.Configure()
+	exportApi.Configure()
+	reportApi.Configure()
+	receiptApi.Configure()
+	dashboardApi.Configure()
+	webhookApi.Configure()
+	notificationApi.Configure()
+	jobApi.Configure()
+	graphqlApi.Configure()
 
 	impl.Gin = api
+	impl.Server = &http.Server{
+		Addr:              impl.Addr,
+		Handler:           api,
+		ReadTimeout:       impl.ReadTimeout,
+		ReadHeaderTimeout: impl.ReadHeaderTimeout,
+		WriteTimeout:      impl.WriteTimeout,
+		IdleTimeout:       impl.IdleTimeout,
+		MaxHeaderBytes:    impl.MaxHeaderBytes,
+	}
+	impl.Server.RegisterOnShutdown(func() { close(impl.shutdown) })
 }
 
-func (impl *ApiImpl) Start() {
-	impl.Gin.Run(impl.Addr)
+// Start serves the requests until Shutdown
+func (impl *ApiImpl) Start() error {
+	if err := impl.Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting requests, ends the streams and waits for the requests in flight,
+// closing their connections when ctx is done first
+func (impl *ApiImpl) Shutdown(ctx context.Context) error {
+	if err := impl.Server.Shutdown(ctx); err != nil {
+		impl.Server.Close()
+		return err
+	}
+
+	return nil
 }
 
 func (impl *ApiImpl) JSONLogMiddleware() gin.HandlerFunc {
@@ -93,12 +252,11 @@ func (impl *ApiImpl) JSONLogMiddleware() gin.HandlerFunc {
 				"start":       params.TimeStamp.Format("2006-01-02T15:04:05Z07:00"),
 				"remote_addr": params.ClientIP,
 				"duration_ms": params.Latency.Milliseconds(),
-				"trace_id":    params.Request.Header.Get("Trace-Id"),
-				"span_id":     params.Request.Header.Get("Request-Id"),
 			}
 
-			if params.Request.Header.Get("Span-Id") != "" {
-				log["parent_span_id"] = params.Request.Header.Get("Span-Id")
+			if spanContext := trace.SpanContextFromContext(params.Request.Context()); spanContext.IsValid() {
+				log["trace_id"] = spanContext.TraceID().String()
+				log["span_id"] = spanContext.SpanID().String()
 			}
 
 			jsonLog, _ := json.Marshal(log)
@@ -108,19 +266,188 @@ func (impl *ApiImpl) JSONLogMiddleware() gin.HandlerFunc {
 	)
 }
 
+// routeBodyLimits are the limits of the routes whose bodies may be longer than MaxBodyBytes
+var routeBodyLimits = map[string]int64{
+	"/api/v1/imports": maxImportSize,
+}
+
+// BodyLimitMiddleware answers 413 to the requests whose body is longer than MaxBodyBytes, or the
+// limit of their route, once read
+func (impl *ApiImpl) BodyLimitMiddleware(c *gin.Context) {
+	limit := impl.MaxBodyBytes
+	if routeLimit, ok := routeBodyLimits[c.FullPath()]; ok {
+		limit = routeLimit
+	}
+
+	if limit > 0 && c.Request.Body != nil {
+		c.Request.Body = &limitedBody{
+			ReadCloser: http.MaxBytesReader(c.Writer, c.Request.Body, limit),
+			limit:      limit,
+		}
+	}
+
+	c.Next()
+}
+
+// limitedBody fails with a PayloadTooLargeException once its http.MaxBytesReader reached the
+// limit, telling it apart from the errors of the connection
+type limitedBody struct {
+	io.ReadCloser
+	read  int64
+	limit int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if err != nil && err != io.EOF && b.read >= b.limit {
+		err = &exception.PayloadTooLargeException{Err: fmt.Errorf("request body is larger than %d bytes", b.limit)}
+	}
+
+	return n, err
+}
+
+// MetricsMiddleware counts the requests and measures their latency by route template, so
+// /api/v1/families/1 and /api/v1/families/2 are both /api/v1/families/:familyID
+func (impl *ApiImpl) MetricsMiddleware(c *gin.Context) {
+	start := time.Now()
+
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+
+	infra.HttpRequestsTotal.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+	infra.HttpRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+}
+
+// TraceMiddleware continues the trace of the traceparent header, or starts one, in a span of the
+// handler, answering the traceparent of that span so the client can find it. The logger of the
+// request gets its route.
 func (impl *ApiImpl) TraceMiddleware(c *gin.Context) {
-	traceID := c.Request.Header.Get("Trace-Id")
-	if traceID == "" {
-		traceID = strings.Replace(uuid.New().String(), "-", "", -1)[:32]
-		c.Request.Header.Set("Trace-Id", traceID)
+	propagator := otel.GetTextMapPropagator()
+	ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+	ctx, span := infra.StartSpan(ctx, c.Request.Method+" "+c.FullPath(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPMethod(c.Request.Method),
+			semconv.HTTPRoute(c.FullPath()),
+			semconv.HTTPTarget(c.Request.URL.RequestURI()),
+		))
+	defer span.End()
+
+	ctx = infra.WithLogFields(ctx, logrus.Fields{"route": c.FullPath(), "method": c.Request.Method})
+	c.Request = c.Request.WithContext(ctx)
+	propagator.Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+
+	c.Next()
+	// the errors are answered here, before ErrorMiddleware, for the span to get their status
+	WriteErrors(c)
+
+	status := c.Writer.Status()
+	span.SetAttributes(semconv.HTTPStatusCode(status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
 	}
+}
 
-	spanID := strings.Replace(uuid.New().String(), "-", "", -1)[:16]
-	c.Request.Header.Set("Request-Id", spanID)
+// IdempotencyMiddleware stores the response of POST requests sent with an
+// Idempotency-Key header and replays it when the request is retried
+func (impl *ApiImpl) IdempotencyMiddleware(c *gin.Context) {
+	key := c.GetHeader("Idempotency-Key")
+	if impl.IdempotencyService == nil || c.Request.Method != http.MethodPost || key == "" {
+		c.Next()
+		return
+	}
 
-	c.Set("trace_id", c.Request.Header.Get("Trace-Id"))
-	c.Set("span_id", c.Request.Header.Get("Request-Id"))
-	c.Set("parent_span_id", c.Request.Header.Get("Span-Id"))
+	if len(key) > 255 {
+		c.Error(&exception.InvalidParamException{Param: "Idempotency-Key"})
+		WriteErrors(c)
+		c.Abort()
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		WriteErrors(c)
+		c.Abort()
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+	hash.Write(body)
+
+	stored, err := impl.IdempotencyService.Begin(c, key, hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		c.Error(err)
+		WriteErrors(c)
+		c.Abort()
+		return
+	}
+
+	if stored != nil {
+		c.Header("Idempotent-Replayed", "true")
+		if len(stored.Body) == 0 {
+			c.Status(stored.Status)
+		} else {
+			c.Data(stored.Status, stored.ContentType, stored.Body)
+		}
+		c.Abort()
+		return
+	}
+
+	writer := &bodyRecorder{ResponseWriter: c.Writer}
+	c.Writer = writer
+
+	// a panicking handler releases the key too, before gin.Recovery answers it
+	defer func() {
+		if r := recover(); r != nil {
+			impl.releaseIdempotencyKey(c, key)
+			panic(r)
+		}
+	}()
 
 	c.Next()
+	// the errors are answered before the response is stored
+	WriteErrors(c)
+
+	if c.Writer.Status() >= http.StatusInternalServerError {
+		impl.releaseIdempotencyKey(c, key)
+		return
+	}
+
+	if err := impl.IdempotencyService.Complete(c, key, c.Writer.Status(), c.Writer.Header().Get("Content-Type"),
+		writer.body.Bytes()); err != nil {
+		infra.Logger(c.Request.Context()).WithFields(logrus.Fields{"idempotency_key": key}).
+			Warnf("the response was not stored, the key stays in progress until its lease ends: %s", err)
+	}
+}
+
+// releaseIdempotencyKey frees the key of a failed request, for it to be retried
+func (impl *ApiImpl) releaseIdempotencyKey(c *gin.Context, key string) {
+	if err := impl.IdempotencyService.Release(c, key); err != nil {
+		infra.Logger(c.Request.Context()).WithFields(logrus.Fields{"idempotency_key": key}).
+			Warnf("the key was not released, it stays in progress until its lease ends: %s", err)
+	}
+}
+
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
 }
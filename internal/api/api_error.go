@@ -1,23 +1,193 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
 )
 
-type HttpError struct {
-	Code    int    `json:"code" example:"400"`
-	Message string `json:"message" example:"invalid parameter"`
+const ProblemContentType = "application/problem+json"
+
+const (
+	ProblemCodeInvalidRequest = "invalid_request"
+	ProblemCodeRouteNotFound  = "route_not_found"
+	ProblemCodeInternal       = "internal_error"
+)
+
+// Problem is the RFC 7807 body of the error responses. Code is stable and tells the errors
+// apart, while Detail is meant for people and may change.
+type Problem struct {
+	Type     string         `json:"type" example:"about:blank"`
+	Title    string         `json:"title" example:"Bad Request"`
+	Status   int            `json:"status" example:"400"`
+	Detail   string         `json:"detail" example:"resource 1 quantity is 2.0"`
+	Instance string         `json:"instance" example:"/api/v1/resources/1/donate"`
+	Code     string         `json:"code" example:"resource.insufficient_stock"`
+	Errors   []ProblemField `json:"errors,omitempty"`
+}
+
+// ProblemField is an invalid field of the request, Code being the failed binding rule
+type ProblemField struct {
+	Field   string `json:"field" example:"quantity"`
+	Code    string `json:"code" example:"required"`
+	Message string `json:"message" example:"quantity is required"`
+}
+
+// exceptionStatus is the status of the responses of each exception
+var exceptionStatus = map[reflect.Type]int{
+	reflect.TypeOf(&exception.NotFoundException{}):        http.StatusNotFound,
+	reflect.TypeOf(&exception.InvalidQueryException{}):    http.StatusBadRequest,
+	reflect.TypeOf(&exception.InvalidFileException{}):     http.StatusBadRequest,
+	reflect.TypeOf(&exception.InvalidParamException{}):    http.StatusBadRequest,
+	reflect.TypeOf(&exception.EmptyModelException{}):      http.StatusBadRequest,
+	reflect.TypeOf(&exception.NegativeException{}):        http.StatusBadRequest,
+	reflect.TypeOf(&exception.ConflictException{}):        http.StatusConflict,
+	reflect.TypeOf(&exception.PayloadMismatchException{}): http.StatusUnprocessableEntity,
+	reflect.TypeOf(&exception.PayloadTooLargeException{}): http.StatusRequestEntityTooLarge,
 }
 
-func NewHttpError(c *gin.Context, code int, msg string) {
-	c.JSON(code, HttpError{Code: code, Message: msg})
+func init() {
+	// the binding errors name the fields as the clients send them
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, tag := range []string{"json", "form"} {
+				if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+					return name
+				}
+			}
+			return field.Name
+		})
+		// the URLs called by the API, as the webhooks, are only http or https
+		v.RegisterValidation("http_url", func(fl validator.FieldLevel) bool {
+			u, err := url.Parse(fl.Field().String())
+			return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+		})
+	}
 }
 
-func NewHttpInternalServerError(c *gin.Context) {
-	c.JSON(http.StatusInternalServerError, HttpError{
-		Code:    http.StatusInternalServerError,
-		Message: "Internal server error",
+// NewProblem answers a problem with the status and code. The handlers leave their errors with
+// c.Error instead, for ErrorMiddleware to answer.
+func NewProblem(c *gin.Context, status int, code, detail string, fields ...ProblemField) {
+	body, _ := json.Marshal(Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+		Errors:   fields,
 	})
+
+	// set over the content type of the response the handler had started, as an export
+	c.Header("Content-Type", ProblemContentType)
+	c.Data(status, ProblemContentType, body)
+}
+
+// NewProblemFromError answers the problem err maps to: the exceptions have their status and code,
+// even when a binding failed on them as on a body too large, the other binding errors are invalid
+// requests with the fields at fault, and anything else is an internal error whose detail is not
+// shown.
+func NewProblemFromError(c *gin.Context, err *gin.Error) {
+	var coded exception.Coded
+	if errors.As(err.Err, &coded) {
+		if status, ok := exceptionStatus[reflect.TypeOf(coded)]; ok {
+			fields := bindingFields(coded)
+			if e, ok := coded.(*exception.InvalidParamException); ok {
+				fields = []ProblemField{{Field: e.Param, Code: "invalid", Message: e.Error()}}
+			}
+			NewProblem(c, status, coded.ErrorCode(), coded.Error(), fields...)
+			return
+		}
+	}
+
+	if err.IsType(gin.ErrorTypeBind) {
+		NewProblem(c, http.StatusBadRequest, ProblemCodeInvalidRequest, bindingDetail(err.Err), bindingFields(err.Err)...)
+		return
+	}
+
+	NewProblem(c, http.StatusInternalServerError, ProblemCodeInternal, "Internal server error")
+}
+
+// WriteErrors answers the last error left by the handler, unless the response is written already
+func WriteErrors(c *gin.Context) {
+	if err := c.Errors.Last(); err != nil && !c.Writer.Written() {
+		NewProblemFromError(c, err)
+	}
+}
+
+// ErrorMiddleware is the one place where the errors of the handlers become responses
+func (impl *ApiImpl) ErrorMiddleware(c *gin.Context) {
+	c.Next()
+
+	WriteErrors(c)
+}
+
+func bindingDetail(err error) string {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return "the request has invalid fields"
+	}
+
+	return err.Error()
+}
+
+func bindingFields(err error) []ProblemField {
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		return []ProblemField{{
+			Field:   typeError.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("%s must be %s", typeError.Field, typeError.Type.String()),
+		}}
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fields := make([]ProblemField, len(validationErrors))
+	for i, e := range validationErrors {
+		fields[i] = ProblemField{Field: e.Field(), Code: e.Tag(), Message: fieldMessage(e)}
+	}
+
+	return fields
+}
+
+func fieldMessage(e validator.FieldError) string {
+	switch e.Tag() {
+	case "required", "required_if", "required_without":
+		return fmt.Sprintf("%s is required", e.Field())
+	case "gte", "min":
+		return fmt.Sprintf("%s must be at least %s", e.Field(), e.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", e.Field(), e.Param())
+	case "lte", "max":
+		return fmt.Sprintf("%s must be at most %s", e.Field(), e.Param())
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", e.Field(), e.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", e.Field(), strings.Join(strings.Fields(e.Param()), ", "))
+	case "email":
+		return fmt.Sprintf("%s must be an email", e.Field())
+	case "url":
+		return fmt.Sprintf("%s must be a URL", e.Field())
+	case "http_url":
+		return fmt.Sprintf("%s must be an http or https URL", e.Field())
+	case "e164":
+		return fmt.Sprintf("%s must be a phone number as +5511999999999", e.Field())
+	case "datetime":
+		return fmt.Sprintf("%s must be a date as %s", e.Field(), e.Param())
+	default:
+		return fmt.Sprintf("%s is invalid", e.Field())
+	}
 }
@@ -1,23 +1,64 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
 )
 
+var filterParamRegexp = regexp.MustCompile(`^filter\[([a-z_]+)\](?:\[([a-z]+)\])?$`)
+
+var filterOperators = map[string]model.FilterOperator{
+	"eq":       model.FilterOperatorEq,
+	"ne":       model.FilterOperatorNe,
+	"contains": model.FilterOperatorContains,
+	"in":       model.FilterOperatorIn,
+	"gt":       model.FilterOperatorGt,
+	"gte":      model.FilterOperatorGte,
+	"lt":       model.FilterOperatorLt,
+	"lte":      model.FilterOperatorLte,
+}
+
 type PaginationQuery struct {
-	Limit  int `form:"limit,default=10" example:"10" binding:"lte=50"`
-	Offset int `form:"offset,default=0" example:"10" binding:"gte=0"`
+	Limit  int    `form:"limit,default=10" example:"10" binding:"gte=1,lte=50"`
+	Cursor string `form:"cursor" example:"eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"`
+	Total  bool   `form:"total" example:"true"`
 }
 
-type PaginationResponse struct {
-	Previous string `json:"previous" example:"localhost:8080/api/v1/families?limit=10&offset=0"`
-	Next     string `json:"next" example:"localhost:8080/api/v1/families?limit=10&offset=20"`
-	Total    int    `json:"total" example:"100"`
+// ParsePaginationQuery binds limit, cursor and total into query
+func ParsePaginationQuery(c *gin.Context, query *model.Query) error {
+	var p PaginationQuery
+	if err := c.ShouldBindQuery(&p); err != nil {
+		return &exception.InvalidQueryException{Err: err}
+	}
+
+	query.Limit = p.Limit
+	query.Total = p.Total
+
+	if p.Cursor != "" {
+		cursor, err := model.DecodeCursor(p.Cursor)
+		if err != nil {
+			return &exception.InvalidQueryException{Err: err}
+		}
+		query.Cursor = cursor
+	}
+
+	return nil
 }
 
-func BuildPreviousURL(addr string, limit, offset int) string {
-	if offset == 0 {
+// BuildCursorURL returns addr with the current query string pointing to cursor
+func BuildCursorURL(addr string, values url.Values, cursor string) string {
+	if cursor == "" {
 		return ""
 	}
 
@@ -26,35 +67,173 @@ func BuildPreviousURL(addr string, limit, offset int) string {
 		return ""
 	}
 
-	newOffset := offset - limit
-
 	q := url.Query()
-	q.Add("limit", fmt.Sprint(limit))
-
-	if newOffset > 0 {
-		q.Add("offset", fmt.Sprint(newOffset))
+	for key, vs := range values {
+		if key == "cursor" {
+			continue
+		}
+		q[key] = vs
 	}
+	q.Set("cursor", cursor)
 
 	url.RawQuery = q.Encode()
 
 	return url.String()
 }
 
-func BuildNextURL(addr string, limit, offset, total int) string {
-	if offset+limit >= total {
-		return ""
+// SetPaginationURLs fills the previous and next links of res from its cursors
+func SetPaginationURLs(addr string, values url.Values, res *service.PaginationResponse) {
+	res.Previous = BuildCursorURL(addr, values, res.PreviousCursor)
+	res.Next = BuildCursorURL(addr, values, res.NextCursor)
+}
+
+// ParseQuery reads filters as filter[field]=value or filter[field][operator]=value
+// and sorting as sort=field,-other_field from the query string
+func ParseQuery(values url.Values) (model.Query, error) {
+	query := model.Query{Filters: []model.Filter{}, Sorts: []model.Sort{}}
+
+	for key, vs := range values {
+		if !strings.HasPrefix(key, "filter") {
+			continue
+		}
+
+		match := filterParamRegexp.FindStringSubmatch(key)
+		if match == nil {
+			return query, &exception.InvalidQueryException{Err: fmt.Errorf("invalid filter %s", key)}
+		}
+
+		operator := model.FilterOperatorEq
+		if match[2] != "" {
+			op, ok := filterOperators[match[2]]
+			if !ok {
+				return query, &exception.InvalidQueryException{Err: fmt.Errorf("invalid filter operator %s", match[2])}
+			}
+			operator = op
+		}
+
+		for _, v := range vs {
+			query.Filters = append(query.Filters, model.Filter{Field: match[1], Operator: operator, Value: v})
+		}
 	}
 
-	url, err := url.Parse(addr)
+	sort.Slice(query.Filters, func(i, j int) bool {
+		if query.Filters[i].Field != query.Filters[j].Field {
+			return query.Filters[i].Field < query.Filters[j].Field
+		}
+		return query.Filters[i].Operator < query.Filters[j].Operator
+	})
+
+	if s := values.Get("sort"); s != "" {
+		for _, field := range strings.Split(s, ",") {
+			field = strings.TrimSpace(field)
+			desc := strings.HasPrefix(field, "-")
+			field = strings.TrimPrefix(field, "-")
+
+			if field == "" {
+				return query, &exception.InvalidQueryException{Err: fmt.Errorf("invalid sort %s", s)}
+			}
+			query.Sorts = append(query.Sorts, model.Sort{Field: field, Desc: desc})
+		}
+	}
+
+	return query, nil
+}
+
+// ParseInclude reads a comma separated list of related resources, accepting only the allowed ones
+func ParseInclude(value string, allowed ...string) ([]string, error) {
+	include := []string{}
+	if value == "" {
+		return include, nil
+	}
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+
+		ok := false
+		for _, a := range allowed {
+			if name == a {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return nil, &exception.InvalidQueryException{Err: fmt.Errorf("include %s is not allowed", name)}
+		}
+
+		include = append(include, name)
+	}
+
+	return include, nil
+}
+
+// JSONWithFields writes res as JSON. When the request has fields=a,b only these fields,
+// and id, are kept in the object or in each object of the data field of res.
+func JSONWithFields(c *gin.Context, code int, res interface{}) {
+	value := c.Query("fields")
+	if value == "" {
+		c.JSON(code, res)
+		return
+	}
+
+	fields := map[string]bool{"id": true}
+	for _, field := range strings.Split(value, ",") {
+		fields[strings.TrimSpace(field)] = true
+	}
+
+	b, err := json.Marshal(res)
 	if err != nil {
-		return ""
+		c.Error(err)
+		return
 	}
 
-	q := url.Query()
-	q.Add("limit", fmt.Sprint(limit))
-	q.Add("offset", fmt.Sprint(offset+limit))
+	var body map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		c.Error(err)
+		return
+	}
 
-	url.RawQuery = q.Encode()
+	switch data := body["data"].(type) {
+	case map[string]interface{}:
+		body["data"] = pickFields(data, fields)
+	case []interface{}:
+		for i, item := range data {
+			if obj, ok := item.(map[string]interface{}); ok {
+				data[i] = pickFields(obj, fields)
+			}
+		}
+	}
 
-	return url.String()
+	c.JSON(code, body)
+}
+
+func pickFields(obj map[string]interface{}, fields map[string]bool) map[string]interface{} {
+	res := map[string]interface{}{}
+	for key, value := range obj {
+		if fields[key] {
+			res[key] = value
+		}
+	}
+
+	return res
+}
+
+var exportContentTypes = map[string]string{
+	infra.SpreadsheetCSV:  "text/csv",
+	infra.SpreadsheetXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ExportFormat returns the format query value when given, otherwise the format accepted by
+// the Accept header, csv by default
+func ExportFormat(format, accept string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+
+	if strings.Contains(accept, exportContentTypes[infra.SpreadsheetXLSX]) {
+		return infra.SpreadsheetXLSX
+	}
+
+	return infra.SpreadsheetCSV
 }
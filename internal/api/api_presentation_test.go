@@ -1,41 +1,51 @@
 package api
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
 )
 
-func Test_ApiPresentation_BuildPreviousURL(t *testing.T) {
+func Test_ApiPresentation_BuildCursorURL(t *testing.T) {
 	cases := map[string]struct {
 		inputHost   string
-		inputLimit  int
-		inputOffset int
+		inputQuery  string
+		inputCursor string
 		expectedUrl string
 	}{
-		"should return url with offset when offset is greater than zero": {
+		"should return url keeping the query string": {
 			inputHost:   "http://localhost:8080",
-			inputLimit:  10,
-			inputOffset: 20,
-			expectedUrl: "http://localhost:8080?limit=10&offset=10",
+			inputQuery:  "filter[city]=Santos&limit=10&sort=-created_at",
+			inputCursor: "abc",
+			expectedUrl: "http://localhost:8080?cursor=abc&filter%5Bcity%5D=Santos&limit=10&sort=-created_at",
 		},
-		"should return url without offset when offset is zero": {
+		"should replace current cursor": {
 			inputHost:   "http://localhost:8080",
-			inputLimit:  10,
-			inputOffset: 10,
-			expectedUrl: "http://localhost:8080?limit=10",
+			inputQuery:  "cursor=abc&limit=10",
+			inputCursor: "def",
+			expectedUrl: "http://localhost:8080?cursor=def&limit=10",
 		},
-		"should return empty url when offset is zero": {
+		"should return empty url when cursor is empty": {
 			inputHost:   "http://localhost:8080",
-			inputLimit:  10,
-			inputOffset: 0,
+			inputQuery:  "limit=10",
+			inputCursor: "",
 			expectedUrl: "",
 		},
 	}
 	for name, cs := range cases {
 		t.Run(name, func(t *testing.T) {
+			// given
+			values, _ := url.ParseQuery(cs.inputQuery)
+
 			// when
-			url := BuildPreviousURL(cs.inputHost, cs.inputLimit, cs.inputOffset)
+			url := BuildCursorURL(cs.inputHost, values, cs.inputCursor)
 
 			// then
 			assert.Equal(t, cs.expectedUrl, url)
@@ -43,36 +53,156 @@ func Test_ApiPresentation_BuildPreviousURL(t *testing.T) {
 	}
 }
 
-func Test_ApiPresentation_BuildNextURL(t *testing.T) {
+func Test_ApiPresentation_ParseQuery(t *testing.T) {
 	cases := map[string]struct {
-		inputHost   string
-		inputLimit  int
-		inputOffset int
-		inputTotal  int
-		expectedUrl string
+		inputQuery    string
+		expectedQuery model.Query
+		expectedErr   error
 	}{
-		"should return url when total is greater than limit": {
-			inputHost:   "http://localhost:8080",
-			inputLimit:  10,
-			inputOffset: 0,
-			inputTotal:  100,
-			expectedUrl: "http://localhost:8080?limit=10&offset=10",
+		"should parse filters and sort": {
+			inputQuery: "filter[city]=São Paulo&filter[name][contains]=silva&sort=-created_at,name&limit=10",
+			expectedQuery: model.Query{
+				Filters: []model.Filter{
+					{Field: "city", Operator: model.FilterOperatorEq, Value: "São Paulo"},
+					{Field: "name", Operator: model.FilterOperatorContains, Value: "silva"},
+				},
+				Sorts: []model.Sort{{Field: "created_at", Desc: true}, {Field: "name"}},
+			},
 		},
-		"should return empty url when total is equal to offset + limit": {
-			inputHost:   "http://localhost:8080",
-			inputLimit:  10,
-			inputOffset: 0,
-			inputTotal:  10,
-			expectedUrl: "",
+		"should return empty query": {
+			inputQuery:    "limit=10",
+			expectedQuery: model.Query{Filters: []model.Filter{}, Sorts: []model.Sort{}},
+		},
+		"should throw invalid query exception when operator is unknown": {
+			inputQuery:  "filter[name][like]=silva",
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("invalid filter operator like")},
+		},
+		"should throw invalid query exception when filter is malformed": {
+			inputQuery:  "filter[name=silva",
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("invalid filter filter[name")},
+		},
+		"should throw invalid query exception when sort is empty": {
+			inputQuery:  "sort=-",
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("invalid sort -")},
 		},
 	}
 	for name, cs := range cases {
 		t.Run(name, func(t *testing.T) {
+			// given
+			values, _ := url.ParseQuery(cs.inputQuery)
+
 			// when
-			url := BuildNextURL(cs.inputHost, cs.inputLimit, cs.inputOffset, cs.inputTotal)
+			query, err := ParseQuery(values)
 
 			// then
-			assert.Equal(t, cs.expectedUrl, url)
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErr == nil {
+				assert.Equal(t, cs.expectedQuery, query)
+			}
+		})
+	}
+}
+
+func Test_ApiPresentation_ParseInclude(t *testing.T) {
+	cases := map[string]struct {
+		inputValue      string
+		expectedInclude []string
+		expectedErr     error
+	}{
+		"should parse include": {
+			inputValue:      "persons, donations",
+			expectedInclude: []string{"persons", "donations"},
+		},
+		"should return empty include": {
+			inputValue:      "",
+			expectedInclude: []string{},
+		},
+		"should throw invalid query exception when include is not allowed": {
+			inputValue:  "persons,notes",
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("include notes is not allowed")},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			include, err := ParseInclude(cs.inputValue, "persons", "donations")
+
+			// then
+			assert.Equal(t, cs.expectedInclude, include)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_ApiPresentation_JSONWithFields(t *testing.T) {
+	cases := map[string]struct {
+		inputQuery   string
+		inputRes     interface{}
+		expectedBody string
+	}{
+		"should keep only requested fields of data list": {
+			inputQuery: "fields=name",
+			inputRes: map[string]interface{}{
+				"meta": map[string]interface{}{"sort": []string{}},
+				"data": []map[string]interface{}{{"id": 1, "name": "Sauro", "city": "Santos"}},
+			},
+			expectedBody: `{"data":[{"id":1,"name":"Sauro"}],"meta":{"sort":[]}}`,
+		},
+		"should keep only requested fields of data object": {
+			inputQuery:   "fields=name,quantity",
+			inputRes:     map[string]interface{}{"data": map[string]interface{}{"id": 1, "name": "Arroz", "quantity": 1.5, "amount": 5}},
+			expectedBody: `{"data":{"id":1,"name":"Arroz","quantity":1.5}}`,
+		},
+		"should return whole response when fields is empty": {
+			inputQuery:   "",
+			inputRes:     map[string]interface{}{"data": map[string]interface{}{"id": 1, "name": "Arroz"}},
+			expectedBody: `{"data":{"id":1,"name":"Arroz"}}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodGet, "/?"+cs.inputQuery, nil)
+
+			// when
+			JSONWithFields(c, http.StatusOK, cs.inputRes)
+
+			// then
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, cs.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_ApiPresentation_ExportFormat(t *testing.T) {
+	cases := map[string]struct {
+		inputFormat    string
+		inputAccept    string
+		expectedFormat string
+	}{
+		"should return format query": {
+			inputFormat:    "XLSX",
+			inputAccept:    "text/csv",
+			expectedFormat: "xlsx",
+		},
+		"should return xlsx when accepted": {
+			inputAccept:    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			expectedFormat: "xlsx",
+		},
+		"should return csv by default": {
+			inputAccept:    "*/*",
+			expectedFormat: "csv",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			format := ExportFormat(cs.inputFormat, cs.inputAccept)
+
+			// then
+			assert.Equal(t, cs.expectedFormat, format)
 		})
 	}
 }
@@ -0,0 +1,430 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_Api_IdempotencyMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		inputMethod       string
+		inputKey          string
+		inputMaxBodyBytes int64
+		inputHandler      gin.HandlerFunc
+		expectedCode      int
+		expectedBody      string
+		prepareMock       func(mockIdempotencyService *mock.MockIdempotencyService)
+	}{
+		"should store response when key is new": {
+			inputMethod:  http.MethodPost,
+			inputKey:     "key",
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":1}`,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {
+				mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(nil, nil)
+				mockIdempotencyService.EXPECT().Complete(gomock.Any(), "key", http.StatusCreated,
+					"application/json; charset=utf-8", []byte(`{"id":1}`)).Return(nil)
+			},
+		},
+		"should replay stored response": {
+			inputMethod:  http.MethodPost,
+			inputKey:     "key",
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":2}`,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {
+				mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(&model.IdempotencyKey{
+					Key: "key", Status: http.StatusCreated, ContentType: "application/json", Body: []byte(`{"id":2}`),
+				}, nil)
+			},
+		},
+		"should return unprocessable entity when payload is different": {
+			inputMethod:  http.MethodPost,
+			inputKey:     "key",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"mismatch","instance":"/","code":"idempotency_key.payload_mismatch"}`,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {
+				mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).
+					Return(nil, &exception.PayloadMismatchException{Code: "idempotency_key.payload_mismatch", Err: fmt.Errorf("mismatch")})
+			},
+		},
+		"should return conflict when request is in progress": {
+			inputMethod:  http.MethodPost,
+			inputKey:     "key",
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"type":"about:blank","title":"Conflict","status":409,"detail":"in progress","instance":"/","code":"idempotency_key.in_progress"}`,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {
+				mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).
+					Return(nil, &exception.ConflictException{Code: "idempotency_key.in_progress", Err: fmt.Errorf("in progress")})
+			},
+		},
+		"should release key when handler fails": {
+			inputMethod:  http.MethodPost,
+			inputKey:     "key",
+			inputHandler: func(c *gin.Context) { c.Status(http.StatusInternalServerError) },
+			expectedCode: http.StatusInternalServerError,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {
+				mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(nil, nil)
+				mockIdempotencyService.EXPECT().Release(gomock.Any(), "key").Return(nil)
+			},
+		},
+		"should release key when handler panics": {
+			inputMethod:  http.MethodPost,
+			inputKey:     "key",
+			inputHandler: func(c *gin.Context) { panic("error") },
+			expectedCode: http.StatusInternalServerError,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {
+				mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(nil, nil)
+				mockIdempotencyService.EXPECT().Release(gomock.Any(), "key").Return(nil)
+			},
+		},
+		"should store error response": {
+			inputMethod: http.MethodPost,
+			inputKey:    "key",
+			inputHandler: func(c *gin.Context) {
+				c.Error(&exception.NotFoundException{Code: "family.not_found", Err: fmt.Errorf("family 1 not found")})
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"family 1 not found","instance":"/","code":"family.not_found"}`,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {
+				mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(nil, nil)
+				mockIdempotencyService.EXPECT().Complete(gomock.Any(), "key", http.StatusNotFound, ProblemContentType,
+					[]byte(`{"type":"about:blank","title":"Not Found","status":404,"detail":"family 1 not found","instance":"/","code":"family.not_found"}`)).
+					Return(nil)
+			},
+		},
+		"should answer response when it is not stored": {
+			inputMethod:  http.MethodPost,
+			inputKey:     "key",
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":1}`,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {
+				mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(nil, nil)
+				mockIdempotencyService.EXPECT().Complete(gomock.Any(), "key", http.StatusCreated,
+					"application/json; charset=utf-8", []byte(`{"id":1}`)).Return(fmt.Errorf("error"))
+			},
+		},
+		"should throw payload too large before storing body larger than limit": {
+			inputMethod:       http.MethodPost,
+			inputKey:          "key",
+			inputMaxBodyBytes: 10,
+			expectedCode:      http.StatusRequestEntityTooLarge,
+			expectedBody: `{"type":"about:blank","title":"Request Entity Too Large","status":413,` +
+				`"detail":"request body is larger than 10 bytes","instance":"/","code":"payload_too_large"}`,
+			prepareMock: func(mockIdempotencyService *mock.MockIdempotencyService) {},
+		},
+		"should ignore request without key": {
+			inputMethod:  http.MethodPost,
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":1}`,
+			prepareMock:  func(mockIdempotencyService *mock.MockIdempotencyService) {},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockIdempotencyService := mock.NewMockIdempotencyService(ctrl)
+			cs.prepareMock(mockIdempotencyService)
+
+			impl := &ApiImpl{IdempotencyService: mockIdempotencyService, MaxBodyBytes: cs.inputMaxBodyBytes}
+
+			handler := cs.inputHandler
+			if handler == nil {
+				handler = func(c *gin.Context) { c.JSON(http.StatusCreated, gin.H{"id": 1}) }
+			}
+
+			router := gin.New()
+			router.Use(gin.RecoveryWithWriter(io.Discard), impl.BodyLimitMiddleware, impl.IdempotencyMiddleware)
+			router.POST("/", handler)
+
+			// when
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(cs.inputMethod, "/", strings.NewReader(`{"name":"Sauro"}`))
+			if cs.inputKey != "" {
+				req.Header.Set("Idempotency-Key", cs.inputKey)
+			}
+			router.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, cs.expectedCode, rec.Code)
+			assert.Equal(t, cs.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_Api_MetricsMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		inputPath     string
+		expectedRoute string
+		expectedCode  string
+	}{
+		"should count request by route template": {
+			inputPath:     "/families/1",
+			expectedRoute: "/families/:familyID",
+			expectedCode:  "200",
+		},
+		"should count request without route as unmatched": {
+			inputPath:     "/unknown",
+			expectedRoute: "unmatched",
+			expectedCode:  "404",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			impl := &ApiImpl{}
+
+			router := gin.New()
+			router.Use(impl.MetricsMiddleware)
+			router.GET("/families/:familyID", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			counter := infra.HttpRequestsTotal.WithLabelValues(http.MethodGet, cs.expectedRoute, cs.expectedCode)
+			before := testutil.ToFloat64(counter)
+
+			// when
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, cs.inputPath, nil)
+			router.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
+
+func Test_Api_TraceMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		inputTraceparent string
+		expectedTraceID  string
+	}{
+		"should continue trace of traceparent": {
+			inputTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedTraceID:  "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		"should start trace without traceparent": {},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			otel.SetTracerProvider(sdktrace.NewTracerProvider())
+			otel.SetTextMapPropagator(propagation.TraceContext{})
+
+			impl := &ApiImpl{}
+
+			var handlerTraceID string
+			router := gin.New()
+			router.ContextWithFallback = true
+			router.GET("/families/:familyID", impl.TraceMiddleware, func(c *gin.Context) {
+				handlerTraceID = trace.SpanContextFromContext(c).TraceID().String()
+				c.Status(http.StatusOK)
+			})
+
+			// when
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/families/1", nil)
+			if cs.inputTraceparent != "" {
+				req.Header.Set("traceparent", cs.inputTraceparent)
+			}
+			router.ServeHTTP(rec, req)
+
+			// then
+			traceparent := strings.Split(rec.Header().Get("traceparent"), "-")
+			assert.Len(t, traceparent, 4)
+			assert.Equal(t, handlerTraceID, traceparent[1])
+			if cs.expectedTraceID != "" {
+				assert.Equal(t, cs.expectedTraceID, traceparent[1])
+			}
+		})
+	}
+}
+
+func Test_Api_BodyLimitMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		inputPath        string
+		inputBody        string
+		inputContentType string
+		expectedCode     int
+		expectedBody     string
+	}{
+		"should read body within limit": {
+			inputPath:        "/api/v1/families",
+			inputBody:        `{"name":"Sauro"}`,
+			inputContentType: "application/json",
+			expectedCode:     http.StatusOK,
+		},
+		"should throw payload too large when body is too large": {
+			inputPath:        "/api/v1/families",
+			inputBody:        `{"name":"Sauro Sauro Sauro"}`,
+			inputContentType: "application/json",
+			expectedCode:     http.StatusRequestEntityTooLarge,
+			expectedBody: `{"type":"about:blank","title":"Request Entity Too Large","status":413,` +
+				`"detail":"request body is larger than 20 bytes","instance":"/api/v1/families","code":"payload_too_large"}`,
+		},
+		"should throw payload too large when multipart body is too large": {
+			inputPath:        "/api/v1/families",
+			inputBody:        `{"name":"Sauro Sauro Sauro"}`,
+			inputContentType: "multipart/form-data; boundary=x",
+			expectedCode:     http.StatusRequestEntityTooLarge,
+			expectedBody: `{"type":"about:blank","title":"Request Entity Too Large","status":413,` +
+				`"detail":"request body is larger than 20 bytes","instance":"/api/v1/families","code":"payload_too_large"}`,
+		},
+		"should read import body within its own limit": {
+			inputPath:        "/api/v1/imports",
+			inputBody:        `{"name":"Sauro Sauro Sauro"}`,
+			inputContentType: "multipart/form-data; boundary=x",
+			expectedCode:     http.StatusOK,
+		},
+		"should throw payload too large when import body is larger than its own limit": {
+			inputPath:        "/api/v1/imports",
+			inputBody:        strings.Repeat("x", maxImportSize+1),
+			inputContentType: "multipart/form-data; boundary=x",
+			expectedCode:     http.StatusRequestEntityTooLarge,
+			expectedBody: `{"type":"about:blank","title":"Request Entity Too Large","status":413,` +
+				`"detail":"request body is larger than 10485760 bytes","instance":"/api/v1/imports","code":"payload_too_large"}`,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			impl := &ApiImpl{MaxBodyBytes: 20}
+			handler := func(c *gin.Context) {
+				if _, err := io.ReadAll(c.Request.Body); err != nil {
+					c.Error(err).SetType(gin.ErrorTypeBind)
+					return
+				}
+				c.Status(http.StatusOK)
+			}
+
+			router := gin.New()
+			router.Use(impl.BodyLimitMiddleware, impl.ErrorMiddleware)
+			router.POST("/api/v1/families", handler)
+			router.POST("/api/v1/imports", handler)
+
+			// when
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, cs.inputPath, strings.NewReader(cs.inputBody))
+			req.Header.Set("Content-Type", cs.inputContentType)
+			router.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, cs.expectedCode, rec.Code)
+			assert.Equal(t, cs.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_Api_ErrorMiddleware(t *testing.T) {
+	type dto struct {
+		FamilyID int     `json:"family_id" binding:"required"`
+		Quantity float64 `json:"quantity" binding:"gte=0"`
+		URL      string  `json:"url" binding:"omitempty,http_url"`
+	}
+
+	cases := map[string]struct {
+		inputBody    string
+		inputErr     error
+		expectedCode int
+		expectedBody string
+	}{
+		"should answer validation errors by field": {
+			inputBody:    `{"quantity":-1}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"the request has invalid fields",` +
+				`"instance":"/","code":"invalid_request","errors":[` +
+				`{"field":"family_id","code":"required","message":"family_id is required"},` +
+				`{"field":"quantity","code":"gte","message":"quantity must be at least 0"}]}`,
+		},
+		"should answer url errors by field": {
+			inputBody:    `{"family_id":1,"url":"file:///etc/passwd"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"the request has invalid fields",` +
+				`"instance":"/","code":"invalid_request","errors":[` +
+				`{"field":"url","code":"http_url","message":"url must be an http or https URL"}]}`,
+		},
+		"should answer type errors by field": {
+			inputBody:    `{"family_id":"1"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,` +
+				`"detail":"json: cannot unmarshal string into Go struct field dto.family_id of type int",` +
+				`"instance":"/","code":"invalid_request","errors":[` +
+				`{"field":"family_id","code":"type","message":"family_id must be int"}]}`,
+		},
+		"should answer exception with its status and code": {
+			inputBody:    `{"family_id":1}`,
+			inputErr:     &exception.NegativeException{Code: "resource.insufficient_stock", Err: fmt.Errorf("resource 1 quantity is 0.0")},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"resource 1 quantity is 0.0",` +
+				`"instance":"/","code":"resource.insufficient_stock"}`,
+		},
+		"should answer exception with default code": {
+			inputBody:    `{"family_id":1}`,
+			inputErr:     &exception.NotFoundException{Err: fmt.Errorf("family 1 not found")},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"family 1 not found",` +
+				`"instance":"/","code":"not_found"}`,
+		},
+		"should hide detail of unknown errors": {
+			inputBody:    `{"family_id":1}`,
+			inputErr:     fmt.Errorf("dial tcp 127.0.0.1:3306: connect: connection refused"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal server error",` +
+				`"instance":"/","code":"internal_error"}`,
+		},
+		"should keep response without errors": {
+			inputBody:    `{"family_id":1}`,
+			expectedCode: http.StatusNoContent,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			impl := &ApiImpl{}
+
+			router := gin.New()
+			router.Use(impl.ErrorMiddleware)
+			router.POST("/", func(c *gin.Context) {
+				var d dto
+				if err := c.ShouldBindJSON(&d); err != nil {
+					c.Error(err).SetType(gin.ErrorTypeBind)
+					return
+				}
+				if cs.inputErr != nil {
+					c.Error(cs.inputErr)
+					return
+				}
+				c.Status(http.StatusNoContent)
+			})
+
+			// when
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(cs.inputBody))
+			router.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, cs.expectedCode, rec.Code)
+			assert.Equal(t, cs.expectedBody, rec.Body.String())
+			if cs.expectedBody != "" {
+				assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/dashboard_api_mock.go -package mock . DashboardApi
+type DashboardApi interface {
+	Configure()
+}
+
+type DashboardApiImpl struct {
+	Router           *gin.RouterGroup
+	DashboardService service.DashboardService
+	TraceMiddleware  func(c *gin.Context)
+}
+
+type DashboardQuery struct {
+	Range string `form:"range" example:"30d"`
+}
+
+func (impl *DashboardApiImpl) Configure() {
+	impl.Router.GET("", impl.TraceMiddleware, impl.Dashboard)
+}
+
+// @Summary operational statistics of families, persons, stock and donations
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Param range query string false "daily series of the last days including today, as 30d"
+// @Success 200 {object} service.DashboardResponse
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/dashboard [get]
+func (impl *DashboardApiImpl) Dashboard(c *gin.Context) {
+	var query DashboardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := impl.DashboardService.Dashboard(c, query.Range)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
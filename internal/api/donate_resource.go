@@ -31,33 +31,30 @@ func (impl *DonateResourceApiImpl) Configure() {
 // @Produce	json
 // @Param	id				path	int								true	"resource ID"
 // @Param	resource		body	service.DonateResourceDonateDto	true	"Donate a resource"
+// @Param	Idempotency-Key	header	string	false	"key to safely retry the request"
 // @Success	204
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	409	{object}	Problem
+// @Failure	422	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/resources/{id}/donate [post]
 func (impl *DonateResourceApiImpl) Donate(c *gin.Context) {
 	resourceID, err := strconv.Atoi(c.Param("resourceID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid resourceID")
+		c.Error(&exception.InvalidParamException{Param: "resourceID"})
 		return
 	}
 
 	var dto service.DonateResourceDonateDto
 	if err := c.ShouldBindJSON(&dto); err != nil {
-		NewHttpError(c, http.StatusBadRequest, err.Error())
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 	dto.ResourceID = resourceID
 
 	err = impl.DonateResourceService.Donate(c, dto)
 	if err != nil {
-		if _, ok := err.(*exception.NegativeException); ok {
-			NewHttpError(c, http.StatusBadRequest, err.Error())
-		} else if _, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, err.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -71,25 +68,19 @@ func (impl *DonateResourceApiImpl) Donate(c *gin.Context) {
 // @Param	id				path	int								true	"resource ID"
 // @Param	resource		body	service.DonateResourceDonateDto	true	"Return a doneted resource"
 // @Success	204
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/resources/{id}/return [delete]
 func (impl *DonateResourceApiImpl) Return(c *gin.Context) {
 	resourceID, err := strconv.Atoi(c.Param("resourceID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid resourceID")
+		c.Error(&exception.InvalidParamException{Param: "resourceID"})
 		return
 	}
 
 	err = impl.DonateResourceService.Return(c, resourceID)
 	if err != nil {
-		if _, ok := err.(*exception.NegativeException); ok {
-			NewHttpError(c, http.StatusBadRequest, err.Error())
-		} else if _, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, err.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
 		return
 	}
 
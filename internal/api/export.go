@@ -0,0 +1,102 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/export_api_mock.go -package mock . ExportApi
+type ExportApi interface {
+	Configure()
+}
+
+type ExportApiImpl struct {
+	Router          *gin.RouterGroup
+	ExportService   service.ExportService
+	TraceMiddleware func(c *gin.Context)
+}
+
+func (impl *ExportApiImpl) Configure() {
+	impl.Router.GET("/:entity", impl.TraceMiddleware, impl.Export)
+}
+
+// @Summary	export families, persons, resources or donations as CSV or XLSX
+// @Tags	export
+// @Produce	text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param	entity			path	string	true	"families, persons, resources or donations"
+// @Param	format			query	string	false	"csv or xlsx, otherwise chosen by the Accept header"
+// @Param	fields			query	string	false	"exported columns in order, separated by commas"
+// @Param	filter[field]	query	string	false	"filter as in the lists"
+// @Param	sort			query	string	false	"sort as in the lists"
+// @Success	200	{file}		file
+// @Failure	400	{object}	Problem
+// @Failure	404	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/exports/{entity} [get]
+func (impl *ExportApiImpl) Export(c *gin.Context) {
+	query, err := ParseQuery(c.Request.URL.Query())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	fields := []string{}
+	for _, field := range strings.Split(c.Query("fields"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	entity := c.Param("entity")
+	format := ExportFormat(c.Query("format"), c.GetHeader("Accept"))
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		c.Error(&exception.InvalidQueryException{Err: fmt.Errorf("format must be csv or xlsx")})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`,
+		entity, time.Now().Format("20060102150405"), format))
+
+	err = impl.ExportService.Export(c, service.ExportDto{
+		Entity: entity,
+		Format: format,
+		Query:  query,
+		Fields: fields,
+	}, c.Writer)
+	if err != nil {
+		if c.Writer.Written() {
+			abortConnection(c)
+			return
+		}
+
+		c.Header("Content-Disposition", "")
+		c.Error(err)
+		return
+	}
+
+	if !c.Writer.Written() {
+		c.Status(http.StatusOK)
+	}
+}
+
+// abortConnection closes the connection of a response already started, for the client to see the
+// download failed instead of keeping a truncated file sent with 200
+func abortConnection(c *gin.Context) {
+	c.Abort()
+
+	conn, _, err := c.Writer.Hijack()
+	if err != nil {
+		infra.Logger(c.Request.Context()).Warnf("the connection was not aborted: %s", err)
+		return
+	}
+	conn.Close()
+}
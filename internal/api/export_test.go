@@ -0,0 +1,106 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_ExportApi_Export(t *testing.T) {
+	cases := map[string]struct {
+		inputQuery          string
+		expectedCode        int
+		expectedContentType string
+		expectedBody        string
+		expectedErr         bool
+		prepareMock         func(mockExportService *mock.MockExportService)
+	}{
+		"should export csv": {
+			inputQuery:          "?format=csv",
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody:        "id\n1\n",
+			prepareMock: func(mockExportService *mock.MockExportService) {
+				mockExportService.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx interface{}, dto service.ExportDto, w io.Writer) error {
+						_, err := w.Write([]byte("id\n1\n"))
+						return err
+					})
+			},
+		},
+		"should throw bad request error when format is unsupported": {
+			inputQuery:          "?format=ods",
+			expectedCode:        http.StatusBadRequest,
+			expectedContentType: ProblemContentType,
+			expectedBody:        `{"type":"about:blank","title":"Bad Request","status":400,"detail":"format must be csv or xlsx","instance":"/families","code":"invalid_query"}`,
+			prepareMock:         func(mockExportService *mock.MockExportService) {},
+		},
+		"should throw not found error before writing": {
+			inputQuery:          "?format=csv",
+			expectedCode:        http.StatusNotFound,
+			expectedContentType: ProblemContentType,
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"export of families not found","instance":"/families","code":"export.not_found"}`,
+			prepareMock: func(mockExportService *mock.MockExportService) {
+				mockExportService.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&exception.NotFoundException{Code: "export.not_found", Err: fmt.Errorf("export of families not found")})
+			},
+		},
+		"should abort connection when export fails while writing": {
+			inputQuery:  "?format=csv",
+			expectedErr: true,
+			prepareMock: func(mockExportService *mock.MockExportService) {
+				mockExportService.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx interface{}, dto service.ExportDto, w io.Writer) error {
+						w.Write([]byte("id\n1\n"))
+						w.(http.Flusher).Flush()
+						return fmt.Errorf("error")
+					})
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockExportService := mock.NewMockExportService(ctrl)
+			cs.prepareMock(mockExportService)
+
+			impl := &ExportApiImpl{ExportService: mockExportService}
+
+			router := gin.New()
+			router.Use((&ApiImpl{}).ErrorMiddleware)
+			router.GET("/:entity", impl.Export)
+
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			// when
+			res, err := http.Get(server.URL + "/families" + cs.inputQuery)
+			var body []byte
+			if err == nil {
+				defer res.Body.Close()
+				body, err = io.ReadAll(res.Body)
+			}
+
+			// then
+			assert.Equal(t, cs.expectedErr, err != nil)
+			if cs.expectedErr {
+				return
+			}
+			assert.Equal(t, cs.expectedCode, res.StatusCode)
+			assert.Equal(t, cs.expectedContentType, res.Header.Get("Content-Type"))
+			assert.Equal(t, cs.expectedBody, string(body))
+		})
+	}
+}
@@ -15,6 +15,9 @@ type FamilyApi interface {
 	Configure()
 }
 
+// familyIncludes has no notes, which don't exist in the API yet
+var familyIncludes = []string{"persons", "donations"}
+
 type FamilyApiImpl struct {
 	Router          *gin.RouterGroup
 	FamilyService   service.FamilyService
@@ -34,20 +37,34 @@ func (impl *FamilyApiImpl) Configure() {
 // @Tags family
 // @Accept json
 // @Produce json
-// @Param limit query integer false "limit pagination"
-// @Param offset query integer false "offset pagination"
-// @Success 200 {object} service.FamiliesResponse
+// @Param limit query integer false "page size, up to 50"
+// @Param cursor query string false "cursor of the page, taken from next_cursor or previous_cursor"
+// @Param total query boolean false "count the matching rows"
+// @Param filter[field][operator] query string false "filter by id, created_at, updated_at, name, country, state, city, neighborhood, street, number, complement or zipcode"
+// @Param sort query string false "sort by fields, descending when prefixed by -" example(-created_at)
+// @Param include query string false "related resources: persons, donations" example(persons,donations)
+// @Param fields query string false "only return these fields" example(id,name,persons)
+// @Success 200 {object} FamiliesResponse
+// @Failure 400 {object} Problem
 // @Router /api/v1/families [get]
 func (impl *FamilyApiImpl) FindAll(c *gin.Context) {
-	var p PaginationQuery
-	if err := c.BindQuery(&p); err != nil {
-		c.JSON(http.StatusBadRequest, err.Error())
+	query, err := ParseQuery(c.Request.URL.Query())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := ParsePaginationQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+	if query.Include, err = ParseInclude(c.Query("include"), familyIncludes...); err != nil {
+		c.Error(err)
 		return
 	}
 
-	res, total, err := impl.FamilyService.FindAll(c, p.Limit, p.Offset)
+	res, pagination, err := impl.FamilyService.FindAll(c, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, nil)
+		c.Error(err)
 		return
 	}
 
@@ -56,13 +73,13 @@ func (impl *FamilyApiImpl) FindAll(c *gin.Context) {
 		data = append(data, *impl.Scan(d))
 	}
 
-	c.JSON(http.StatusOK, FamiliesResponse{
-		PaginationResponse: PaginationResponse{
-			Previous: BuildPreviousURL(impl.Addr, p.Limit, p.Offset),
-			Next:     BuildNextURL(impl.Addr, p.Limit, p.Offset, total),
-			Total:    total,
-		},
-		Data: data,
+	paginationResponse := service.NewPaginationResponse(pagination)
+	SetPaginationURLs(impl.Addr, c.Request.URL.Query(), &paginationResponse)
+
+	JSONWithFields(c, http.StatusOK, FamiliesResponse{
+		PaginationResponse: paginationResponse,
+		Meta:               service.NewQueryMeta(query, service.FamilyEncryptedFields...),
+		Data:               data,
 	})
 }
 
@@ -71,28 +88,33 @@ func (impl *FamilyApiImpl) FindAll(c *gin.Context) {
 // @Accept	json
 // @Produce	json
 // @Param	id	path		int	true	"family ID"
-// @Success	200	{object}	service.FamiliesResponse
-// @Failure	404	{object}	HttpError
+// @Param	include	query	string	false	"related resources: persons, donations"	example(persons,donations)
+// @Param	fields	query	string	false	"only return these fields"	example(id,name,persons)
+// @Success	200	{object}	FamilyResponse
+// @Failure	400	{object}	Problem
+// @Failure	404	{object}	Problem
 // @Router	/api/v1/families/{id} [get]
 func (impl *FamilyApiImpl) FindOneByID(c *gin.Context) {
 	familyID, err := strconv.Atoi(c.Param("familyID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid familyID")
+		c.Error(&exception.InvalidParamException{Param: "familyID"})
 		return
 	}
 
-	res, err := impl.FamilyService.FindOneById(c, familyID)
+	include, err := ParseInclude(c.Query("include"), familyIncludes...)
 	if err != nil {
-		if e, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, e.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
+		return
+	}
+
+	res, err := impl.FamilyService.FindOneById(c, familyID, include)
+	if err != nil {
+		c.Error(err)
 
 		return
 	}
 
-	c.JSON(http.StatusOK, FamilyResponse{Data: impl.Scan(*res)})
+	JSONWithFields(c, http.StatusOK, FamilyResponse{Data: impl.Scan(*res)})
 }
 
 // @Summary	create an family
@@ -100,20 +122,23 @@ func (impl *FamilyApiImpl) FindOneByID(c *gin.Context) {
 // @Accept	json
 // @Produce	json
 // @Param	family		body	service.FamilyCreateDto	true	"Create family"
+// @Param	Idempotency-Key	header	string	false	"key to safely retry the request"
 // @Success	201	{object}	service.FamilyResponse
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	409	{object}	Problem
+// @Failure	422	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/families [post]
 func (impl *FamilyApiImpl) Create(c *gin.Context) {
 	var dto service.FamilyCreateDto
 	if err := c.ShouldBindJSON(&dto); err != nil {
-		NewHttpError(c, http.StatusBadRequest, err.Error())
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	res, err := impl.FamilyService.Create(c, dto)
 	if err != nil {
-		NewHttpInternalServerError(c)
+		c.Error(err)
 		return
 	}
 
@@ -127,31 +152,25 @@ func (impl *FamilyApiImpl) Create(c *gin.Context) {
 // @Param	id			path	int							true	"family ID"
 // @Param	family		body	service.FamilyUpdateDto		true	"Update family"
 // @Success	204
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/families/{id} [patch]
 func (impl *FamilyApiImpl) Update(c *gin.Context) {
 	familyID, err := strconv.Atoi(c.Param("familyID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid familyID")
+		c.Error(&exception.InvalidParamException{Param: "familyID"})
 		return
 	}
 
 	var dto service.FamilyUpdateDto
 	if err = c.ShouldBindJSON(&dto); err != nil {
-		NewHttpError(c, http.StatusBadRequest, err.Error())
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 	dto.ID = familyID
 
 	if err = impl.FamilyService.Update(c, dto); err != nil {
-		if e, ok := err.(*exception.EmptyModelException); ok {
-			NewHttpError(c, http.StatusBadRequest, e.Error())
-		} else if e, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, e.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -164,18 +183,18 @@ func (impl *FamilyApiImpl) Update(c *gin.Context) {
 // @Produce	json
 // @Param	id	path		int	true	"family ID"
 // @Success	204
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/families/{id} [delete]
 func (impl *FamilyApiImpl) Delete(c *gin.Context) {
 	familyID, err := strconv.Atoi(c.Param("familyID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid familyID")
+		c.Error(&exception.InvalidParamException{Param: "familyID"})
 		return
 	}
 
 	if err = impl.FamilyService.Delete(c, familyID); err != nil {
-		NewHttpInternalServerError(c)
+		c.Error(err)
 		return
 	}
 
@@ -183,7 +202,7 @@ func (impl *FamilyApiImpl) Delete(c *gin.Context) {
 }
 
 func (impl *FamilyApiImpl) Scan(data model.Family) *Family {
-	return &Family{
+	family := &Family{
 		ID:           data.ID,
 		CreatedAt:    data.CreatedAt.Format("2006-01-02T15:04:05"),
 		UpdatedAt:    data.UpdatedAt.Format("2006-01-02T15:04:05"),
@@ -197,4 +216,35 @@ func (impl *FamilyApiImpl) Scan(data model.Family) *Family {
 		Complement:   data.Complement,
 		Zipcode:      data.Zipcode,
 	}
+
+	if data.Persons != nil {
+		persons := []service.Person{}
+		for _, p := range data.Persons {
+			persons = append(persons, service.Person{
+				ID:        p.ID,
+				CreatedAt: p.CreatedAt.Format("2006-01-02T15:04:05"),
+				UpdatedAt: p.UpdatedAt.Format("2006-01-02T15:04:05"),
+				FamilyID:  p.FamilyID,
+				Name:      p.Name,
+				Document:  p.Document,
+			})
+		}
+		family.Persons = &persons
+	}
+
+	if data.Donations != nil {
+		donations := []service.Donation{}
+		for _, d := range data.Donations {
+			donations = append(donations, service.Donation{
+				ID:         d.ID,
+				CreatedAt:  d.CreatedAt.Format("2006-01-02T15:04:05"),
+				ResourceID: d.ResourceID,
+				FamilyID:   d.FamilyID,
+				Quantity:   d.Quantity,
+			})
+		}
+		family.Donations = &donations
+	}
+
+	return family
 }
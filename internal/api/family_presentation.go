@@ -1,5 +1,7 @@
 package api
 
+import "github.com/viniosilva/socialassistanceapi/internal/service"
+
 type Family struct {
 	ID           int    `json:"id" example:"1"`
 	Name         string `json:"name" example:"Sauro"`
@@ -14,6 +16,9 @@ type Family struct {
 	Number       string `json:"number" example:"1000"`
 	Complement   string `json:"complement" example:"1A"`
 	Zipcode      string `json:"zipcode" example:"01021100"`
+	// Persons and Donations are only present when included
+	Persons   *[]service.Person   `json:"persons,omitempty"`
+	Donations *[]service.Donation `json:"donations,omitempty"`
 }
 
 type FamilyResponse struct {
@@ -21,6 +26,7 @@ type FamilyResponse struct {
 }
 
 type FamiliesResponse struct {
-	PaginationResponse
-	Data []Family `json:"data"`
+	service.PaginationResponse
+	Meta service.QueryMeta `json:"meta"`
+	Data []Family          `json:"data"`
 }
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:embed graphql_schema.graphql
+var graphqlSchema string
+
+//go:generate mockgen -destination ../../mock/graphql_api_mock.go -package mock . GraphqlApi
+type GraphqlApi interface {
+	Configure()
+}
+
+type GraphqlApiImpl struct {
+	Router                *gin.RouterGroup
+	FamilyService         service.FamilyService
+	PersonService         service.PersonService
+	ResourceService       service.ResourceService
+	DonateResourceService service.DonateResourceService
+	TraceMiddleware       func(c *gin.Context)
+
+	schema *graphql.Schema
+}
+
+type GraphqlRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (impl *GraphqlApiImpl) Configure() {
+	impl.schema = graphql.MustParseSchema(graphqlSchema, &graphqlResolver{
+		FamilyService:         impl.FamilyService,
+		PersonService:         impl.PersonService,
+		ResourceService:       impl.ResourceService,
+		DonateResourceService: impl.DonateResourceService,
+	}, graphql.MaxDepth(8), graphql.UseStringDescriptions())
+
+	impl.Router.POST("", impl.TraceMiddleware, impl.Query)
+}
+
+// @Summary	query and change families, persons, resources and donations with GraphQL
+// @Tags	graphql
+// @Accept	json
+// @Produce	json
+// @Param	request	body		GraphqlRequest	true	"GraphQL query and variables"
+// @Success	200		{object}	object
+// @Failure	400		{object}	Problem
+// @Router	/graphql [post]
+func (impl *GraphqlApiImpl) Query(c *gin.Context) {
+	var req GraphqlRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	loaders := newGraphqlLoaders(impl.FamilyService, impl.ResourceService)
+	ctx := context.WithValue(c, graphqlLoadersKey{}, loaders)
+
+	c.JSON(http.StatusOK, impl.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+type graphqlLoadersKey struct{}
+
+// graphqlLoaders batch the loads of the related entities of a request, so listing families with
+// their persons makes one call for all persons instead of one call by family
+type graphqlLoaders struct {
+	families        *dataloader.Loader[int, *Family]
+	familyPersons   *dataloader.Loader[int, []service.Person]
+	familyDonations *dataloader.Loader[int, []service.Donation]
+	resources       *dataloader.Loader[int, *service.Resource]
+}
+
+func newGraphqlLoaders(familyService service.FamilyService, resourceService service.ResourceService) *graphqlLoaders {
+	familyApi := &FamilyApiImpl{}
+
+	include := func(name string) func(ctx context.Context, ids []int) (map[int]*Family, error) {
+		return func(ctx context.Context, ids []int) (map[int]*Family, error) {
+			families := make([]model.Family, len(ids))
+			for i, id := range ids {
+				families[i].ID = id
+			}
+
+			if err := familyService.Include(ctx, families, []string{name}); err != nil {
+				return nil, err
+			}
+
+			res := map[int]*Family{}
+			for _, family := range families {
+				res[family.ID] = familyApi.Scan(family)
+			}
+			return res, nil
+		}
+	}
+	persons := include("persons")
+	donations := include("donations")
+
+	return &graphqlLoaders{
+		families: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int) []*dataloader.Result[*Family] {
+			families, _, err := familyService.FindAll(ctx, graphqlQueryByIDs(ids))
+			byID := map[int]*Family{}
+			for _, family := range families {
+				byID[family.ID] = familyApi.Scan(family)
+			}
+			return graphqlResults(ids, err, func(id int) *Family { return byID[id] })
+		}),
+		familyPersons: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int) []*dataloader.Result[[]service.Person] {
+			families, err := persons(ctx, ids)
+			return graphqlResults(ids, err, func(id int) []service.Person {
+				return *families[id].Persons
+			})
+		}),
+		familyDonations: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int) []*dataloader.Result[[]service.Donation] {
+			families, err := donations(ctx, ids)
+			return graphqlResults(ids, err, func(id int) []service.Donation {
+				return *families[id].Donations
+			})
+		}),
+		resources: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int) []*dataloader.Result[*service.Resource] {
+			res, err := resourceService.FindAll(ctx, graphqlQueryByIDs(ids))
+			byID := map[int]*service.Resource{}
+			for i := range res.Data {
+				byID[res.Data[i].ID] = &res.Data[i]
+			}
+			return graphqlResults(ids, err, func(id int) *service.Resource { return byID[id] })
+		}),
+	}
+}
+
+func graphqlLoadersFrom(ctx context.Context) *graphqlLoaders {
+	return ctx.Value(graphqlLoadersKey{}).(*graphqlLoaders)
+}
+
+func graphqlQueryByIDs(ids []int) model.Query {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.Itoa(id)
+	}
+
+	return model.Query{
+		Filters: []model.Filter{{Field: "id", Operator: model.FilterOperatorIn, Value: strings.Join(values, ",")}},
+		Sorts:   []model.Sort{},
+	}
+}
+
+// graphqlResults returns the value of each key in order, or err for all of them
+func graphqlResults[V any](ids []int, err error, value func(id int) V) []*dataloader.Result[V] {
+	res := make([]*dataloader.Result[V], len(ids))
+	for i, id := range ids {
+		if err != nil {
+			res[i] = &dataloader.Result[V]{Error: err}
+		} else {
+			res[i] = &dataloader.Result[V]{Data: value(id)}
+		}
+	}
+
+	return res
+}
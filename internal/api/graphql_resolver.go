@@ -0,0 +1,662 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+type graphqlResolver struct {
+	FamilyService         service.FamilyService
+	PersonService         service.PersonService
+	ResourceService       service.ResourceService
+	DonateResourceService service.DonateResourceService
+}
+
+// graphqlError keeps the messages of the exceptions the REST api shows and hides the others
+type graphqlError struct {
+	Message string
+	Code    string
+}
+
+func (e *graphqlError) Error() string {
+	return e.Message
+}
+
+func (e *graphqlError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+func newGraphqlError(err error) error {
+	switch err.(type) {
+	case *exception.NotFoundException:
+		return &graphqlError{Message: err.Error(), Code: "NOT_FOUND"}
+	case *exception.InvalidQueryException, *exception.NegativeException, *exception.EmptyModelException:
+		return &graphqlError{Message: err.Error(), Code: "BAD_REQUEST"}
+	}
+
+	return &graphqlError{Message: "internal server error", Code: "INTERNAL_SERVER_ERROR"}
+}
+
+func graphqlValidate(dto interface{}) error {
+	if err := binding.Validator.ValidateStruct(dto); err != nil {
+		return &graphqlError{Message: err.Error(), Code: "BAD_REQUEST"}
+	}
+
+	return nil
+}
+
+func graphqlParseID(id graphql.ID) (int, error) {
+	res, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, &graphqlError{Message: fmt.Sprintf("invalid id %s", id), Code: "BAD_REQUEST"}
+	}
+
+	return res, nil
+}
+
+func graphqlID(id int) graphql.ID {
+	return graphql.ID(strconv.Itoa(id))
+}
+
+func graphqlString(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+type graphqlFilterInput struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+type graphqlListArgs struct {
+	Filter *[]graphqlFilterInput
+	Sort   *string
+	Limit  int32
+	Cursor *string
+}
+
+// query parses the arguments as the filter, sort, limit and cursor parameters of the REST lists
+func (args graphqlListArgs) query() (model.Query, error) {
+	values := url.Values{}
+	if args.Filter != nil {
+		for _, f := range *args.Filter {
+			values.Add(fmt.Sprintf("filter[%s][%s]", f.Field, f.Operator), f.Value)
+		}
+	}
+	if args.Sort != nil {
+		values.Set("sort", *args.Sort)
+	}
+
+	query, err := ParseQuery(values)
+	if err != nil {
+		return query, newGraphqlError(err)
+	}
+
+	query.Limit = int(args.Limit)
+	if query.Limit < 1 || query.Limit > 50 {
+		return query, &graphqlError{Message: "limit must be between 1 and 50", Code: "BAD_REQUEST"}
+	}
+
+	if args.Cursor != nil && *args.Cursor != "" {
+		cursor, err := model.DecodeCursor(*args.Cursor)
+		if err != nil {
+			return query, &graphqlError{Message: err.Error(), Code: "BAD_REQUEST"}
+		}
+		query.Cursor = cursor
+	}
+
+	return query, nil
+}
+
+type graphqlConnection[T any] struct {
+	nodes      []T
+	pagination model.Pagination
+}
+
+func (c *graphqlConnection[T]) Nodes() []T {
+	return c.nodes
+}
+
+func (c *graphqlConnection[T]) PreviousCursor() *string {
+	if c.pagination.PreviousCursor == "" {
+		return nil
+	}
+	return &c.pagination.PreviousCursor
+}
+
+func (c *graphqlConnection[T]) NextCursor() *string {
+	if c.pagination.NextCursor == "" {
+		return nil
+	}
+	return &c.pagination.NextCursor
+}
+
+func (r *graphqlResolver) Families(ctx context.Context, args graphqlListArgs) (*graphqlConnection[*graphqlFamily], error) {
+	query, err := args.query()
+	if err != nil {
+		return nil, err
+	}
+
+	families, pagination, err := r.FamilyService.FindAll(ctx, query)
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	familyApi := &FamilyApiImpl{}
+	nodes := []*graphqlFamily{}
+	for _, family := range families {
+		nodes = append(nodes, &graphqlFamily{familyApi.Scan(family)})
+	}
+
+	return &graphqlConnection[*graphqlFamily]{nodes: nodes, pagination: pagination}, nil
+}
+
+func (r *graphqlResolver) Family(ctx context.Context, args struct{ ID graphql.ID }) (*graphqlFamily, error) {
+	familyID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	family, err := r.FamilyService.FindOneById(ctx, familyID, []string{})
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, nil
+		}
+		return nil, newGraphqlError(err)
+	}
+
+	return &graphqlFamily{(&FamilyApiImpl{}).Scan(*family)}, nil
+}
+
+func (r *graphqlResolver) Persons(ctx context.Context, args graphqlListArgs) (*graphqlConnection[*graphqlPerson], error) {
+	query, err := args.query()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.PersonService.FindAll(ctx, query)
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	nodes := []*graphqlPerson{}
+	for _, person := range res.Data {
+		nodes = append(nodes, &graphqlPerson{person})
+	}
+
+	return &graphqlConnection[*graphqlPerson]{nodes: nodes, pagination: model.Pagination{
+		PreviousCursor: res.PreviousCursor,
+		NextCursor:     res.NextCursor,
+	}}, nil
+}
+
+func (r *graphqlResolver) Person(ctx context.Context, args struct{ ID graphql.ID }) (*graphqlPerson, error) {
+	personID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.PersonService.FindOneById(ctx, personID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, nil
+		}
+		return nil, newGraphqlError(err)
+	}
+
+	return &graphqlPerson{*res.Data}, nil
+}
+
+func (r *graphqlResolver) Resources(ctx context.Context, args graphqlListArgs) (*graphqlConnection[*graphqlResource], error) {
+	query, err := args.query()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.ResourceService.FindAll(ctx, query)
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	nodes := []*graphqlResource{}
+	for _, resource := range res.Data {
+		nodes = append(nodes, &graphqlResource{resource})
+	}
+
+	return &graphqlConnection[*graphqlResource]{nodes: nodes, pagination: model.Pagination{
+		PreviousCursor: res.PreviousCursor,
+		NextCursor:     res.NextCursor,
+	}}, nil
+}
+
+func (r *graphqlResolver) Resource(ctx context.Context, args struct{ ID graphql.ID }) (*graphqlResource, error) {
+	resourceID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.ResourceService.FindOneById(ctx, resourceID)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); ok {
+			return nil, nil
+		}
+		return nil, newGraphqlError(err)
+	}
+
+	return &graphqlResource{*res.Data}, nil
+}
+
+type graphqlFamilyCreateInput struct {
+	Name         string
+	Country      string
+	State        string
+	City         string
+	Neighborhood string
+	Street       string
+	Number       string
+	Complement   *string
+	Zipcode      string
+}
+
+type graphqlFamilyUpdateInput struct {
+	Name         *string
+	Country      *string
+	State        *string
+	City         *string
+	Neighborhood *string
+	Street       *string
+	Number       *string
+	Complement   *string
+	Zipcode      *string
+}
+
+func (r *graphqlResolver) CreateFamily(ctx context.Context, args struct{ Input graphqlFamilyCreateInput }) (*graphqlFamily, error) {
+	dto := service.FamilyCreateDto{
+		Name:         args.Input.Name,
+		Country:      args.Input.Country,
+		State:        args.Input.State,
+		City:         args.Input.City,
+		Neighborhood: args.Input.Neighborhood,
+		Street:       args.Input.Street,
+		Number:       args.Input.Number,
+		Complement:   graphqlString(args.Input.Complement),
+		Zipcode:      args.Input.Zipcode,
+	}
+	if err := graphqlValidate(dto); err != nil {
+		return nil, err
+	}
+
+	family, err := r.FamilyService.Create(ctx, dto)
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return &graphqlFamily{(&FamilyApiImpl{}).Scan(*family)}, nil
+}
+
+func (r *graphqlResolver) UpdateFamily(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input graphqlFamilyUpdateInput
+}) (*graphqlFamily, error) {
+	familyID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.FamilyService.Update(ctx, service.FamilyUpdateDto{
+		ID:           familyID,
+		Name:         graphqlString(args.Input.Name),
+		Country:      graphqlString(args.Input.Country),
+		State:        graphqlString(args.Input.State),
+		City:         graphqlString(args.Input.City),
+		Neighborhood: graphqlString(args.Input.Neighborhood),
+		Street:       graphqlString(args.Input.Street),
+		Number:       graphqlString(args.Input.Number),
+		Complement:   graphqlString(args.Input.Complement),
+		Zipcode:      graphqlString(args.Input.Zipcode),
+	}); err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	family, err := r.FamilyService.FindOneById(ctx, familyID, []string{})
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return &graphqlFamily{(&FamilyApiImpl{}).Scan(*family)}, nil
+}
+
+func (r *graphqlResolver) DeleteFamily(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	familyID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.FamilyService.Delete(ctx, familyID); err != nil {
+		return false, newGraphqlError(err)
+	}
+
+	return true, nil
+}
+
+type graphqlPersonCreateInput struct {
+	FamilyID graphql.ID
+	Name     string
+	Document *string
+}
+
+type graphqlPersonUpdateInput struct {
+	FamilyID *graphql.ID
+	Name     *string
+	Document *string
+}
+
+func (r *graphqlResolver) CreatePerson(ctx context.Context, args struct{ Input graphqlPersonCreateInput }) (*graphqlPerson, error) {
+	familyID, err := graphqlParseID(args.Input.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := service.PersonCreateDto{
+		FamilyID: familyID,
+		Name:     args.Input.Name,
+		Document: graphqlString(args.Input.Document),
+	}
+	if err := graphqlValidate(dto); err != nil {
+		return nil, err
+	}
+
+	res, err := r.PersonService.Create(ctx, dto)
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return &graphqlPerson{*res.Data}, nil
+}
+
+func (r *graphqlResolver) UpdatePerson(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input graphqlPersonUpdateInput
+}) (*graphqlPerson, error) {
+	personID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	familyID := 0
+	if args.Input.FamilyID != nil {
+		if familyID, err = graphqlParseID(*args.Input.FamilyID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.PersonService.Update(ctx, service.PersonUpdateDto{
+		ID:       personID,
+		FamilyID: familyID,
+		Name:     graphqlString(args.Input.Name),
+		Document: graphqlString(args.Input.Document),
+	}); err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	res, err := r.PersonService.FindOneById(ctx, personID)
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return &graphqlPerson{*res.Data}, nil
+}
+
+func (r *graphqlResolver) DeletePerson(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	personID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.PersonService.Delete(ctx, personID); err != nil {
+		return false, newGraphqlError(err)
+	}
+
+	return true, nil
+}
+
+type graphqlResourceCreateInput struct {
+	Name        string
+	Amount      float64
+	Measurement string
+	Quantity    float64
+}
+
+type graphqlResourceUpdateInput struct {
+	Name        *string
+	Amount      *float64
+	Measurement *string
+}
+
+func (r *graphqlResolver) CreateResource(ctx context.Context, args struct{ Input graphqlResourceCreateInput }) (*graphqlResource, error) {
+	dto := service.CreateResourceDto{
+		Name:        args.Input.Name,
+		Amount:      args.Input.Amount,
+		Measurement: args.Input.Measurement,
+		Quantity:    args.Input.Quantity,
+	}
+	if err := graphqlValidate(dto); err != nil {
+		return nil, err
+	}
+
+	res, err := r.ResourceService.Create(ctx, dto)
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return &graphqlResource{*res.Data}, nil
+}
+
+func (r *graphqlResolver) UpdateResource(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input graphqlResourceUpdateInput
+}) (*graphqlResource, error) {
+	resourceID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := service.UpdateResourceDto{
+		ID:          resourceID,
+		Name:        graphqlString(args.Input.Name),
+		Measurement: graphqlString(args.Input.Measurement),
+	}
+	if args.Input.Amount != nil {
+		dto.Amount = *args.Input.Amount
+	}
+	if err := graphqlValidate(dto); err != nil {
+		return nil, err
+	}
+
+	if err := r.ResourceService.Update(ctx, dto); err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return r.Resource(ctx, struct{ ID graphql.ID }{args.ID})
+}
+
+func (r *graphqlResolver) UpdateResourceQuantity(ctx context.Context, args struct {
+	ID       graphql.ID
+	Quantity float64
+}) (*graphqlResource, error) {
+	resourceID, err := graphqlParseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := service.UpdateResourceQuantityDto{Quantity: args.Quantity}
+	if err := graphqlValidate(dto); err != nil {
+		return nil, err
+	}
+
+	if err := r.ResourceService.UpdateQuantity(ctx, resourceID, dto); err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return r.Resource(ctx, struct{ ID graphql.ID }{args.ID})
+}
+
+func (r *graphqlResolver) DonateResource(ctx context.Context, args struct {
+	ResourceID graphql.ID
+	FamilyID   graphql.ID
+	Quantity   float64
+}) (*graphqlResource, error) {
+	resourceID, err := graphqlParseID(args.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	familyID, err := graphqlParseID(args.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := service.DonateResourceDonateDto{ResourceID: resourceID, FamilyID: familyID, Quantity: args.Quantity}
+	if err := graphqlValidate(dto); err != nil {
+		return nil, err
+	}
+
+	if err := r.DonateResourceService.Donate(ctx, dto); err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return r.Resource(ctx, struct{ ID graphql.ID }{args.ResourceID})
+}
+
+func (r *graphqlResolver) ReturnResource(ctx context.Context, args struct{ ResourceID graphql.ID }) (*graphqlResource, error) {
+	resourceID, err := graphqlParseID(args.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.DonateResourceService.Return(ctx, resourceID); err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	return r.Resource(ctx, struct{ ID graphql.ID }{args.ResourceID})
+}
+
+type graphqlFamily struct {
+	data *Family
+}
+
+func (f *graphqlFamily) ID() graphql.ID       { return graphqlID(f.data.ID) }
+func (f *graphqlFamily) CreatedAt() string    { return f.data.CreatedAt }
+func (f *graphqlFamily) UpdatedAt() string    { return f.data.UpdatedAt }
+func (f *graphqlFamily) Name() string         { return f.data.Name }
+func (f *graphqlFamily) Country() string      { return f.data.Country }
+func (f *graphqlFamily) State() string        { return f.data.State }
+func (f *graphqlFamily) City() string         { return f.data.City }
+func (f *graphqlFamily) Neighborhood() string { return f.data.Neighborhood }
+func (f *graphqlFamily) Street() string       { return f.data.Street }
+func (f *graphqlFamily) Number() string       { return f.data.Number }
+func (f *graphqlFamily) Complement() string   { return f.data.Complement }
+func (f *graphqlFamily) Zipcode() string      { return f.data.Zipcode }
+
+func (f *graphqlFamily) Persons(ctx context.Context) ([]*graphqlPerson, error) {
+	persons, err := graphqlLoadersFrom(ctx).familyPersons.Load(ctx, f.data.ID)()
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	res := []*graphqlPerson{}
+	for _, person := range persons {
+		res = append(res, &graphqlPerson{person})
+	}
+
+	return res, nil
+}
+
+func (f *graphqlFamily) Donations(ctx context.Context) ([]*graphqlDonation, error) {
+	donations, err := graphqlLoadersFrom(ctx).familyDonations.Load(ctx, f.data.ID)()
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+
+	res := []*graphqlDonation{}
+	for _, donation := range donations {
+		res = append(res, &graphqlDonation{donation})
+	}
+
+	return res, nil
+}
+
+type graphqlPerson struct {
+	data service.Person
+}
+
+func (p *graphqlPerson) ID() graphql.ID       { return graphqlID(p.data.ID) }
+func (p *graphqlPerson) CreatedAt() string    { return p.data.CreatedAt }
+func (p *graphqlPerson) UpdatedAt() string    { return p.data.UpdatedAt }
+func (p *graphqlPerson) FamilyID() graphql.ID { return graphqlID(p.data.FamilyID) }
+func (p *graphqlPerson) Name() string         { return p.data.Name }
+func (p *graphqlPerson) Document() string     { return p.data.Document }
+
+func (p *graphqlPerson) Family(ctx context.Context) (*graphqlFamily, error) {
+	return graphqlLoadFamily(ctx, p.data.FamilyID)
+}
+
+type graphqlResource struct {
+	data service.Resource
+}
+
+func (r *graphqlResource) ID() graphql.ID      { return graphqlID(r.data.ID) }
+func (r *graphqlResource) CreatedAt() string   { return r.data.CreatedAt }
+func (r *graphqlResource) UpdatedAt() string   { return r.data.UpdatedAt }
+func (r *graphqlResource) Name() string        { return r.data.Name }
+func (r *graphqlResource) Amount() float64     { return r.data.Amount }
+func (r *graphqlResource) Measurement() string { return r.data.Measurement }
+func (r *graphqlResource) Quantity() float64   { return r.data.Quantity }
+
+type graphqlDonation struct {
+	data service.Donation
+}
+
+func (d *graphqlDonation) ID() graphql.ID         { return graphqlID(d.data.ID) }
+func (d *graphqlDonation) CreatedAt() string      { return d.data.CreatedAt }
+func (d *graphqlDonation) ResourceID() graphql.ID { return graphqlID(d.data.ResourceID) }
+func (d *graphqlDonation) FamilyID() graphql.ID   { return graphqlID(d.data.FamilyID) }
+func (d *graphqlDonation) Quantity() float64      { return d.data.Quantity }
+
+func (d *graphqlDonation) Resource(ctx context.Context) (*graphqlResource, error) {
+	resource, err := graphqlLoadersFrom(ctx).resources.Load(ctx, d.data.ResourceID)()
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+	if resource == nil {
+		return nil, nil
+	}
+
+	return &graphqlResource{*resource}, nil
+}
+
+func (d *graphqlDonation) Family(ctx context.Context) (*graphqlFamily, error) {
+	return graphqlLoadFamily(ctx, d.data.FamilyID)
+}
+
+func graphqlLoadFamily(ctx context.Context, familyID int) (*graphqlFamily, error) {
+	family, err := graphqlLoadersFrom(ctx).families.Load(ctx, familyID)()
+	if err != nil {
+		return nil, newGraphqlError(err)
+	}
+	if family == nil {
+		return nil, nil
+	}
+
+	return &graphqlFamily{family}, nil
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_GraphqlApi_Query(t *testing.T) {
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		inputBody    string
+		expectedCode int
+		expectedBody string
+		prepareMock  func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+			mockResourceService *mock.MockResourceService)
+	}{
+		"should load persons and donation resources of all families in batches": {
+			inputBody:    `{"query":"{ families(limit: 2) { nodes { name persons { name } donations { quantity resource { name } } } nextCursor } }"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"families":{"nodes":[` +
+				`{"name":"Sauro","persons":[{"name":"Cláudio"}],"donations":[{"quantity":2,"resource":{"name":"Arroz"}}]},` +
+				`{"name":"Silva","persons":[],"donations":[{"quantity":1,"resource":{"name":"Arroz"}}]}` +
+				`],"nextCursor":"abc"}}}`,
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockResourceService *mock.MockResourceService) {
+				mockFamilyService.EXPECT().FindAll(gomock.Any(), model.Query{Filters: []model.Filter{}, Sorts: []model.Sort{}, Limit: 2}).
+					Return([]model.Family{{ID: 1, Name: "Sauro", CreatedAt: DATETIME}, {ID: 2, Name: "Silva", CreatedAt: DATETIME}},
+						model.Pagination{NextCursor: "abc"}, nil)
+				mockFamilyService.EXPECT().Include(gomock.Any(), gomock.Len(2), []string{"persons"}).
+					DoAndReturn(func(ctx interface{}, families []model.Family, include []string) error {
+						for i := range families {
+							families[i].Persons = []model.Person{}
+							if families[i].ID == 1 {
+								families[i].Persons = []model.Person{{ID: 1, FamilyID: 1, Name: "Cláudio"}}
+							}
+						}
+						return nil
+					}).Times(1)
+				mockFamilyService.EXPECT().Include(gomock.Any(), gomock.Len(2), []string{"donations"}).
+					DoAndReturn(func(ctx interface{}, families []model.Family, include []string) error {
+						for i := range families {
+							families[i].Donations = []model.ResourceToFamily{
+								{ID: families[i].ID, FamilyID: families[i].ID, ResourceID: 1, Quantity: float64(3 - families[i].ID)},
+							}
+						}
+						return nil
+					}).Times(1)
+				mockResourceService.EXPECT().FindAll(gomock.Any(), model.Query{Filters: []model.Filter{
+					{Field: "id", Operator: model.FilterOperatorIn, Value: "1"}}, Sorts: []model.Sort{}}).
+					Return(service.ResourcesResponse{Data: []service.Resource{{ID: 1, Name: "Arroz"}}}, nil).Times(1)
+			},
+		},
+		"should return null when family does not exist": {
+			inputBody:    `{"query":"query($id: ID!) { family(id: $id) { name } }","variables":{"id":"1"}}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"family":null}}`,
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockResourceService *mock.MockResourceService) {
+				mockFamilyService.EXPECT().FindOneById(gomock.Any(), 1, []string{}).
+					Return(nil, &exception.NotFoundException{Err: fmt.Errorf("family 1 not found")})
+			},
+		},
+		"should return validation error of mutation": {
+			inputBody:    `{"query":"mutation { createPerson(input: {familyId: \"1\", name: \"\"}) { id } }"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"errors":[{"message":"Key: 'PersonCreateDto.name' Error:Field validation for 'name' failed on the 'required' tag",` +
+				`"path":["createPerson"],"extensions":{"code":"BAD_REQUEST"}}],"data":null}`,
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockResourceService *mock.MockResourceService) {
+			},
+		},
+		"should hide internal errors": {
+			inputBody:    `{"query":"{ person(id: \"1\") { name } }"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"errors":[{"message":"internal server error","path":["person"],"extensions":{"code":"INTERNAL_SERVER_ERROR"}}],"data":{"person":null}}`,
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockResourceService *mock.MockResourceService) {
+				mockPersonService.EXPECT().FindOneById(gomock.Any(), 1).Return(service.PersonResponse{}, fmt.Errorf("error"))
+			},
+		},
+		"should return bad request when query is missing": {
+			inputBody:    `{}`,
+			expectedCode: http.StatusBadRequest,
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockResourceService *mock.MockResourceService) {
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFamilyService := mock.NewMockFamilyService(ctrl)
+			mockPersonService := mock.NewMockPersonService(ctrl)
+			mockResourceService := mock.NewMockResourceService(ctrl)
+			cs.prepareMock(mockFamilyService, mockPersonService, mockResourceService)
+
+			router := gin.New()
+			router.Use((&ApiImpl{}).ErrorMiddleware)
+			impl := &GraphqlApiImpl{
+				Router:          router.Group("/graphql"),
+				FamilyService:   mockFamilyService,
+				PersonService:   mockPersonService,
+				ResourceService: mockResourceService,
+				TraceMiddleware: func(c *gin.Context) {},
+			}
+			impl.Configure()
+
+			// when
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/graphql", strings.NewReader(cs.inputBody))
+			router.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, cs.expectedCode, rec.Code)
+			if cs.expectedBody != "" {
+				assert.JSONEq(t, cs.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
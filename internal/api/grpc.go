@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/api/pb"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate mockgen -destination ../../mock/grpc_api_mock.go -package mock . GrpcApi
+type GrpcApi interface {
+	Configure()
+	Start() error
+	Shutdown(ctx context.Context) error
+}
+
+type GrpcApiImpl struct {
+	Server                *grpc.Server
+	Addr                  string
+	FamilyService         service.FamilyService
+	PersonService         service.PersonService
+	ResourceService       service.ResourceService
+	DonateResourceService service.DonateResourceService
+}
+
+func (impl *GrpcApiImpl) Configure() {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor(),
+		impl.LogInterceptor, impl.RecoveryInterceptor))
+
+	pb.RegisterFamilyServiceServer(server, &grpcFamilyServer{FamilyService: impl.FamilyService})
+	pb.RegisterPersonServiceServer(server, &grpcPersonServer{PersonService: impl.PersonService})
+	pb.RegisterResourceServiceServer(server, &grpcResourceServer{ResourceService: impl.ResourceService})
+	pb.RegisterDonationServiceServer(server, &grpcDonationServer{DonateResourceService: impl.DonateResourceService})
+
+	healthServer := health.NewServer()
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	impl.Server = server
+}
+
+func (impl *GrpcApiImpl) Start() error {
+	listener, err := net.Listen("tcp", impl.Addr)
+	if err != nil {
+		return err
+	}
+
+	return impl.Server.Serve(listener)
+}
+
+// Shutdown stops accepting calls and waits for the calls in flight, cancelling them when ctx is done first
+func (impl *GrpcApiImpl) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		impl.Server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		impl.Server.Stop()
+		return ctx.Err()
+	}
+}
+
+// LogInterceptor adds the route to the logger of the call and logs it as the JSON log of the http api,
+// with the trace of the span started from the traceparent metadata
+func (impl *GrpcApiImpl) LogInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = infra.WithLogFields(ctx, logrus.Fields{"route": info.FullMethod})
+
+	start := time.Now()
+	res, err := handler(ctx, req)
+
+	fields := logrus.Fields{
+		"status":      status.Code(err).String(),
+		"path":        info.FullMethod,
+		"method":      "GRPC",
+		"start":       start.Format("2006-01-02T15:04:05Z07:00"),
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	infra.Logger(ctx).WithFields(fields).Info()
+
+	return res, err
+}
+
+// RecoveryInterceptor answers Internal instead of stopping the server when a handler panics
+func (impl *GrpcApiImpl) RecoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			infra.Logger(ctx).WithFields(logrus.Fields{"path": info.FullMethod}).Error(r)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// exceptionGrpcCodes is the code of each exception, as exceptionStatus is its http status
+var exceptionGrpcCodes = map[reflect.Type]codes.Code{
+	reflect.TypeOf(&exception.NotFoundException{}):        codes.NotFound,
+	reflect.TypeOf(&exception.InvalidQueryException{}):    codes.InvalidArgument,
+	reflect.TypeOf(&exception.InvalidFileException{}):     codes.InvalidArgument,
+	reflect.TypeOf(&exception.InvalidParamException{}):    codes.InvalidArgument,
+	reflect.TypeOf(&exception.EmptyModelException{}):      codes.InvalidArgument,
+	reflect.TypeOf(&exception.NegativeException{}):        codes.FailedPrecondition,
+	reflect.TypeOf(&exception.ConflictException{}):        codes.Aborted,
+	reflect.TypeOf(&exception.PayloadMismatchException{}): codes.InvalidArgument,
+	reflect.TypeOf(&exception.PayloadTooLargeException{}): codes.ResourceExhausted,
+}
+
+// NewGrpcError keeps the messages of the exceptions the http api shows, wrapped or not, and hides
+// the others
+func NewGrpcError(err error) error {
+	var coded exception.Coded
+	if errors.As(err, &coded) {
+		if code, ok := exceptionGrpcCodes[reflect.TypeOf(coded)]; ok {
+			return status.Error(code, coded.Error())
+		}
+	}
+
+	return status.Error(codes.Internal, "internal server error")
+}
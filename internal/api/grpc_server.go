@@ -0,0 +1,469 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/viniosilva/socialassistanceapi/internal/api/pb"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func grpcValidate(dto interface{}) error {
+	if err := binding.Validator.ValidateStruct(dto); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+func grpcValidateID(id int32) error {
+	if id < 1 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid id %d", id))
+	}
+
+	return nil
+}
+
+// grpcQuery parses the request as the filter, sort, limit and cursor parameters of the REST lists
+func grpcQuery(req *pb.ListRequest) (model.Query, error) {
+	values := url.Values{}
+	for _, f := range req.Filters {
+		operator := f.Operator
+		if operator == "" {
+			operator = string(model.FilterOperatorEq)
+		}
+		values.Add(fmt.Sprintf("filter[%s][%s]", f.Field, operator), f.Value)
+	}
+	if req.Sort != "" {
+		values.Set("sort", req.Sort)
+	}
+
+	query, err := ParseQuery(values)
+	if err != nil {
+		return query, NewGrpcError(err)
+	}
+
+	query.Limit = int(req.Limit)
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+	if query.Limit < 1 || query.Limit > 50 {
+		return query, status.Error(codes.InvalidArgument, "limit must be between 1 and 50")
+	}
+	query.Total = req.Total
+
+	if req.Cursor != "" {
+		cursor, err := model.DecodeCursor(req.Cursor)
+		if err != nil {
+			return query, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query.Cursor = cursor
+	}
+
+	return query, nil
+}
+
+func grpcPagination(previousCursor, nextCursor string, total *int) *pb.Pagination {
+	pagination := &pb.Pagination{PreviousCursor: previousCursor, NextCursor: nextCursor}
+	if total != nil {
+		t := int32(*total)
+		pagination.Total = &t
+	}
+
+	return pagination
+}
+
+type grpcFamilyServer struct {
+	pb.UnimplementedFamilyServiceServer
+	FamilyService service.FamilyService
+}
+
+func (s *grpcFamilyServer) ListFamilies(ctx context.Context, req *pb.ListRequest) (*pb.ListFamiliesResponse, error) {
+	query, err := grpcQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	families, pagination, err := s.FamilyService.FindAll(ctx, query)
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	res := &pb.ListFamiliesResponse{
+		Families:   []*pb.Family{},
+		Pagination: grpcPagination(pagination.PreviousCursor, pagination.NextCursor, pagination.Total),
+	}
+	for _, family := range families {
+		res.Families = append(res.Families, s.Scan(family))
+	}
+
+	return res, nil
+}
+
+func (s *grpcFamilyServer) GetFamily(ctx context.Context, req *pb.GetFamilyRequest) (*pb.Family, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	include, err := ParseInclude(strings.Join(req.Include, ","), "persons", "donations")
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	family, err := s.FamilyService.FindOneById(ctx, int(req.Id), include)
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return s.Scan(*family), nil
+}
+
+func (s *grpcFamilyServer) CreateFamily(ctx context.Context, req *pb.FamilyRequest) (*pb.Family, error) {
+	dto := service.FamilyCreateDto{
+		Name:         req.Name,
+		Country:      req.Country,
+		State:        req.State,
+		City:         req.City,
+		Neighborhood: req.Neighborhood,
+		Street:       req.Street,
+		Number:       req.Number,
+		Complement:   req.Complement,
+		Zipcode:      req.Zipcode,
+	}
+	if err := grpcValidate(dto); err != nil {
+		return nil, err
+	}
+
+	family, err := s.FamilyService.Create(ctx, dto)
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return s.Scan(*family), nil
+}
+
+func (s *grpcFamilyServer) UpdateFamily(ctx context.Context, req *pb.FamilyRequest) (*emptypb.Empty, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	err := s.FamilyService.Update(ctx, service.FamilyUpdateDto{
+		ID:           int(req.Id),
+		Name:         req.Name,
+		Country:      req.Country,
+		State:        req.State,
+		City:         req.City,
+		Neighborhood: req.Neighborhood,
+		Street:       req.Street,
+		Number:       req.Number,
+		Complement:   req.Complement,
+		Zipcode:      req.Zipcode,
+	})
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcFamilyServer) DeleteFamily(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	if err := s.FamilyService.Delete(ctx, int(req.Id)); err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcFamilyServer) Scan(data model.Family) *pb.Family {
+	family := (&FamilyApiImpl{}).Scan(data)
+
+	res := &pb.Family{
+		Id:           int32(family.ID),
+		CreatedAt:    family.CreatedAt,
+		UpdatedAt:    family.UpdatedAt,
+		Name:         family.Name,
+		Country:      family.Country,
+		State:        family.State,
+		City:         family.City,
+		Neighborhood: family.Neighborhood,
+		Street:       family.Street,
+		Number:       family.Number,
+		Complement:   family.Complement,
+		Zipcode:      family.Zipcode,
+	}
+
+	if family.Persons != nil {
+		for _, p := range *family.Persons {
+			res.Persons = append(res.Persons, grpcPerson(p))
+		}
+	}
+
+	if family.Donations != nil {
+		for _, d := range *family.Donations {
+			res.Donations = append(res.Donations, &pb.Donation{
+				Id:         int32(d.ID),
+				CreatedAt:  d.CreatedAt,
+				ResourceId: int32(d.ResourceID),
+				FamilyId:   int32(d.FamilyID),
+				Quantity:   d.Quantity,
+			})
+		}
+	}
+
+	return res
+}
+
+type grpcPersonServer struct {
+	pb.UnimplementedPersonServiceServer
+	PersonService service.PersonService
+}
+
+func grpcPerson(data service.Person) *pb.Person {
+	return &pb.Person{
+		Id:        int32(data.ID),
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+		FamilyId:  int32(data.FamilyID),
+		Name:      data.Name,
+		Document:  data.Document,
+	}
+}
+
+func (s *grpcPersonServer) ListPersons(ctx context.Context, req *pb.ListRequest) (*pb.ListPersonsResponse, error) {
+	query, err := grpcQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	persons, err := s.PersonService.FindAll(ctx, query)
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	res := &pb.ListPersonsResponse{
+		Persons:    []*pb.Person{},
+		Pagination: grpcPagination(persons.PreviousCursor, persons.NextCursor, persons.Total),
+	}
+	for _, person := range persons.Data {
+		res.Persons = append(res.Persons, grpcPerson(person))
+	}
+
+	return res, nil
+}
+
+func (s *grpcPersonServer) GetPerson(ctx context.Context, req *pb.IdRequest) (*pb.Person, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	person, err := s.PersonService.FindOneById(ctx, int(req.Id))
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return grpcPerson(*person.Data), nil
+}
+
+func (s *grpcPersonServer) CreatePerson(ctx context.Context, req *pb.PersonRequest) (*pb.Person, error) {
+	dto := service.PersonCreateDto{FamilyID: int(req.FamilyId), Name: req.Name, Document: req.Document}
+	if err := grpcValidate(dto); err != nil {
+		return nil, err
+	}
+
+	person, err := s.PersonService.Create(ctx, dto)
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return grpcPerson(*person.Data), nil
+}
+
+func (s *grpcPersonServer) UpdatePerson(ctx context.Context, req *pb.PersonRequest) (*emptypb.Empty, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	err := s.PersonService.Update(ctx, service.PersonUpdateDto{
+		ID:       int(req.Id),
+		FamilyID: int(req.FamilyId),
+		Name:     req.Name,
+		Document: req.Document,
+	})
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcPersonServer) DeletePerson(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	if err := s.PersonService.Delete(ctx, int(req.Id)); err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+type grpcResourceServer struct {
+	pb.UnimplementedResourceServiceServer
+	ResourceService service.ResourceService
+}
+
+func grpcResource(data service.Resource) *pb.Resource {
+	return &pb.Resource{
+		Id:          int32(data.ID),
+		CreatedAt:   data.CreatedAt,
+		UpdatedAt:   data.UpdatedAt,
+		Name:        data.Name,
+		Amount:      data.Amount,
+		Measurement: data.Measurement,
+		Quantity:    data.Quantity,
+	}
+}
+
+func (s *grpcResourceServer) ListResources(ctx context.Context, req *pb.ListRequest) (*pb.ListResourcesResponse, error) {
+	query, err := grpcQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := s.ResourceService.FindAll(ctx, query)
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	res := &pb.ListResourcesResponse{
+		Resources:  []*pb.Resource{},
+		Pagination: grpcPagination(resources.PreviousCursor, resources.NextCursor, resources.Total),
+	}
+	for _, resource := range resources.Data {
+		res.Resources = append(res.Resources, grpcResource(resource))
+	}
+
+	return res, nil
+}
+
+func (s *grpcResourceServer) GetResource(ctx context.Context, req *pb.IdRequest) (*pb.Resource, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	resource, err := s.ResourceService.FindOneById(ctx, int(req.Id))
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return grpcResource(*resource.Data), nil
+}
+
+func (s *grpcResourceServer) CreateResource(ctx context.Context, req *pb.ResourceRequest) (*pb.Resource, error) {
+	dto := service.CreateResourceDto{
+		Name:        req.Name,
+		Amount:      req.Amount,
+		Measurement: req.Measurement,
+		Quantity:    req.Quantity,
+	}
+	if err := grpcValidate(dto); err != nil {
+		return nil, err
+	}
+
+	resource, err := s.ResourceService.Create(ctx, dto)
+	if err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return grpcResource(*resource.Data), nil
+}
+
+func (s *grpcResourceServer) UpdateResource(ctx context.Context, req *pb.ResourceRequest) (*emptypb.Empty, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	dto := service.UpdateResourceDto{
+		ID:          int(req.Id),
+		Name:        req.Name,
+		Amount:      req.Amount,
+		Measurement: req.Measurement,
+	}
+	if err := grpcValidate(dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.ResourceService.Update(ctx, dto); err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcResourceServer) UpdateResourceQuantity(ctx context.Context,
+	req *pb.UpdateResourceQuantityRequest) (*emptypb.Empty, error) {
+	if err := grpcValidateID(req.Id); err != nil {
+		return nil, err
+	}
+
+	dto := service.UpdateResourceQuantityDto{Quantity: req.Quantity}
+	if err := grpcValidate(dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.ResourceService.UpdateQuantity(ctx, int(req.Id), dto); err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+type grpcDonationServer struct {
+	pb.UnimplementedDonationServiceServer
+	DonateResourceService service.DonateResourceService
+}
+
+func (s *grpcDonationServer) DonateResource(ctx context.Context, req *pb.DonateRequest) (*emptypb.Empty, error) {
+	if err := grpcValidateID(req.ResourceId); err != nil {
+		return nil, err
+	}
+
+	dto := service.DonateResourceDonateDto{
+		ResourceID: int(req.ResourceId),
+		FamilyID:   int(req.FamilyId),
+		Quantity:   req.Quantity,
+	}
+	if err := grpcValidate(dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.DonateResourceService.Donate(ctx, dto); err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcDonationServer) ReturnResource(ctx context.Context, req *pb.ReturnRequest) (*emptypb.Empty, error) {
+	if err := grpcValidateID(req.ResourceId); err != nil {
+		return nil, err
+	}
+
+	if err := s.DonateResourceService.Return(ctx, int(req.ResourceId)); err != nil {
+		return nil, NewGrpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/api/pb"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func Test_NewGrpcError(t *testing.T) {
+	cases := map[string]struct {
+		inputErr        error
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		"should map not found": {
+			inputErr:        &exception.NotFoundException{Err: fmt.Errorf("family 1 not found")},
+			expectedCode:    codes.NotFound,
+			expectedMessage: "family 1 not found",
+		},
+		"should map negative": {
+			inputErr:        &exception.NegativeException{Err: fmt.Errorf("resource quantity cannot be negative")},
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: "resource quantity cannot be negative",
+		},
+		"should map empty model": {
+			inputErr:        &exception.EmptyModelException{Err: fmt.Errorf("empty model")},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "empty model",
+		},
+		"should map wrapped not found": {
+			inputErr:        fmt.Errorf("cannot donate: %w", &exception.NotFoundException{Err: fmt.Errorf("resource 1 not found")}),
+			expectedCode:    codes.NotFound,
+			expectedMessage: "resource 1 not found",
+		},
+		"should map invalid query": {
+			inputErr:        &exception.InvalidQueryException{Err: fmt.Errorf("invalid filter")},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "invalid filter",
+		},
+		"should map invalid param": {
+			inputErr:        &exception.InvalidParamException{Param: "familyID"},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "invalid familyID",
+		},
+		"should map invalid file": {
+			inputErr:        &exception.InvalidFileException{Err: fmt.Errorf("file must be .csv or .xlsx")},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "file must be .csv or .xlsx",
+		},
+		"should map conflict": {
+			inputErr:        &exception.ConflictException{Err: fmt.Errorf("job deliver_webhooks is already running")},
+			expectedCode:    codes.Aborted,
+			expectedMessage: "job deliver_webhooks is already running",
+		},
+		"should map payload mismatch": {
+			inputErr:        &exception.PayloadMismatchException{Err: fmt.Errorf("mismatch")},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "mismatch",
+		},
+		"should map payload too large": {
+			inputErr:        &exception.PayloadTooLargeException{Err: fmt.Errorf("request body is larger than 10 bytes")},
+			expectedCode:    codes.ResourceExhausted,
+			expectedMessage: "request body is larger than 10 bytes",
+		},
+		"should hide other errors": {
+			inputErr:        fmt.Errorf("error"),
+			expectedCode:    codes.Internal,
+			expectedMessage: "internal server error",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			err := NewGrpcError(cs.inputErr)
+
+			// then
+			s, _ := status.FromError(err)
+			assert.Equal(t, cs.expectedCode, s.Code())
+			assert.Equal(t, cs.expectedMessage, s.Message())
+		})
+	}
+}
+
+func Test_GrpcApi(t *testing.T) {
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		call            func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error)
+		expectedRes     interface{}
+		expectedCode    codes.Code
+		expectedMessage string
+		prepareMock     func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+			mockDonateResourceService *mock.MockDonateResourceService)
+	}{
+		"should list families with the filters of the rest api": {
+			call: func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+				res, err := pb.NewFamilyServiceClient(conn).ListFamilies(ctx, &pb.ListRequest{
+					Filters: []*pb.Filter{{Field: "city", Value: "São Paulo"}},
+					Sort:    "-created_at",
+				})
+				if err != nil {
+					return nil, err
+				}
+				return res.Families[0].Name + " " + res.Pagination.NextCursor, nil
+			},
+			expectedRes:  "Sauro abc",
+			expectedCode: codes.OK,
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockDonateResourceService *mock.MockDonateResourceService) {
+				mockFamilyService.EXPECT().FindAll(gomock.Any(), model.Query{
+					Filters: []model.Filter{{Field: "city", Operator: model.FilterOperatorEq, Value: "São Paulo"}},
+					Sorts:   []model.Sort{{Field: "created_at", Desc: true}},
+					Limit:   10,
+				}).Return([]model.Family{{ID: 1, Name: "Sauro", CreatedAt: DATETIME}}, model.Pagination{NextCursor: "abc"}, nil)
+			},
+		},
+		"should return not found when family does not exist": {
+			call: func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+				return pb.NewFamilyServiceClient(conn).GetFamily(ctx, &pb.GetFamilyRequest{Id: 1})
+			},
+			expectedCode:    codes.NotFound,
+			expectedMessage: "family 1 not found",
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockDonateResourceService *mock.MockDonateResourceService) {
+				mockFamilyService.EXPECT().FindOneById(gomock.Any(), 1, []string{}).
+					Return(nil, &exception.NotFoundException{Err: fmt.Errorf("family 1 not found")})
+			},
+		},
+		"should return invalid argument when person is invalid": {
+			call: func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+				return pb.NewPersonServiceClient(conn).CreatePerson(ctx, &pb.PersonRequest{FamilyId: 1})
+			},
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "Key: 'PersonCreateDto.name' Error:Field validation for 'name' failed on the 'required' tag",
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockDonateResourceService *mock.MockDonateResourceService) {
+			},
+		},
+		"should create person": {
+			call: func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+				res, err := pb.NewPersonServiceClient(conn).CreatePerson(ctx, &pb.PersonRequest{FamilyId: 1, Name: "Cláudio"})
+				if err != nil {
+					return nil, err
+				}
+				return res.Id, nil
+			},
+			expectedRes:  int32(1),
+			expectedCode: codes.OK,
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockDonateResourceService *mock.MockDonateResourceService) {
+				mockPersonService.EXPECT().Create(gomock.Any(), service.PersonCreateDto{FamilyID: 1, Name: "Cláudio"}).
+					Return(service.PersonResponse{Data: &service.Person{ID: 1, FamilyID: 1, Name: "Cláudio"}}, nil)
+			},
+		},
+		"should return failed precondition when donation leaves the resource negative": {
+			call: func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+				return pb.NewDonationServiceClient(conn).DonateResource(ctx, &pb.DonateRequest{ResourceId: 1, FamilyId: 1, Quantity: 5})
+			},
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: "resource quantity cannot be negative",
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockDonateResourceService *mock.MockDonateResourceService) {
+				mockDonateResourceService.EXPECT().Donate(gomock.Any(), service.DonateResourceDonateDto{ResourceID: 1, FamilyID: 1, Quantity: 5}).
+					Return(&exception.NegativeException{Err: fmt.Errorf("resource quantity cannot be negative")})
+			},
+		},
+		"should serve the health service": {
+			call: func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+				res, err := healthpb.NewHealthClient(conn).Check(ctx,
+					&healthpb.HealthCheckRequest{Service: "socialassistance.v1.FamilyService"})
+				if err != nil {
+					return nil, err
+				}
+				return res.Status, nil
+			},
+			expectedRes:  healthpb.HealthCheckResponse_SERVING,
+			expectedCode: codes.OK,
+			prepareMock: func(mockFamilyService *mock.MockFamilyService, mockPersonService *mock.MockPersonService,
+				mockDonateResourceService *mock.MockDonateResourceService) {
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFamilyService := mock.NewMockFamilyService(ctrl)
+			mockPersonService := mock.NewMockPersonService(ctrl)
+			mockDonateResourceService := mock.NewMockDonateResourceService(ctrl)
+			cs.prepareMock(mockFamilyService, mockPersonService, mockDonateResourceService)
+
+			impl := &GrpcApiImpl{
+				FamilyService:         mockFamilyService,
+				PersonService:         mockPersonService,
+				ResourceService:       mock.NewMockResourceService(ctrl),
+				DonateResourceService: mockDonateResourceService,
+			}
+			impl.Configure()
+
+			listener := bufconn.Listen(1024 * 1024)
+			go impl.Server.Serve(listener)
+			defer impl.Server.Stop()
+
+			conn, err := grpc.Dial("bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+				grpc.WithTransportCredentials(insecure.NewCredentials()))
+			assert.Nil(t, err)
+			defer conn.Close()
+
+			// when
+			res, err := cs.call(context.Background(), conn)
+
+			// then
+			s, _ := status.FromError(err)
+			assert.Equal(t, cs.expectedCode, s.Code())
+			if cs.expectedCode == codes.OK {
+				assert.Equal(t, cs.expectedRes, res)
+			} else {
+				assert.Equal(t, cs.expectedMessage, s.Message())
+			}
+		})
+	}
+}
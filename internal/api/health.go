@@ -13,16 +13,56 @@ type HealthApi interface {
 }
 
 type HealthApiImpl struct {
-	Router          *gin.RouterGroup
+	Router *gin.RouterGroup
+	// ProbeRouter serves the liveness and readiness probes of the orchestrators
+	ProbeRouter     *gin.RouterGroup
 	HealthService   service.HealthService
 	TraceMiddleware func(c *gin.Context)
 }
 
 func (impl *HealthApiImpl) Configure() {
 	impl.Router.GET("", impl.TraceMiddleware, impl.HealthCheck)
+	// the probes are not traced, as they would flood the traces every few seconds
+	impl.ProbeRouter.GET("/live", impl.Live)
+	impl.ProbeRouter.GET("/ready", impl.Ready)
 }
 
+// @Summary	ping the database
+// @Tags	health
+// @Produce	json
+// @Success	200	{object}	service.HealthResponse
+// @Failure	503	{object}	service.HealthResponse
+// @Router	/api/health [get]
 func (impl *HealthApiImpl) HealthCheck(c *gin.Context) {
 	res := impl.HealthService.Ping(c)
-	c.JSON(http.StatusOK, res)
+	c.JSON(healthCode(res.Status), res)
+}
+
+// @Summary	liveness probe, up while the process answers
+// @Tags	health
+// @Produce	json
+// @Success	200	{object}	service.HealthResponse
+// @Router	/health/live [get]
+func (impl *HealthApiImpl) Live(c *gin.Context) {
+	res := impl.HealthService.Live(c)
+	c.JSON(healthCode(res.Status), res)
+}
+
+// @Summary	readiness probe, up while the database, migrations, disk, scheduler and queues are
+// @Tags	health
+// @Produce	json
+// @Success	200	{object}	service.ReadinessResponse
+// @Failure	503	{object}	service.ReadinessResponse
+// @Router	/health/ready [get]
+func (impl *HealthApiImpl) Ready(c *gin.Context) {
+	res := impl.HealthService.Ready(c)
+	c.JSON(healthCode(res.Status), res)
+}
+
+func healthCode(status service.HealthStatus) int {
+	if status == service.HealthStatusUp {
+		return http.StatusOK
+	}
+
+	return http.StatusServiceUnavailable
 }
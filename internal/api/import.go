@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+const maxImportSize = 10 << 20
+
+//go:generate mockgen -destination ../../mock/import_api_mock.go -package mock . ImportApi
+type ImportApi interface {
+	Configure()
+}
+
+type ImportApiImpl struct {
+	Router          *gin.RouterGroup
+	ImportService   service.ImportService
+	TraceMiddleware func(c *gin.Context)
+}
+
+type ImportQuery struct {
+	DryRun bool `form:"dry_run" example:"true"`
+}
+
+func (impl *ImportApiImpl) Configure() {
+	impl.Router.POST("", impl.TraceMiddleware, impl.Create)
+	impl.Router.GET("/:importID", impl.TraceMiddleware, impl.FindOneByID)
+}
+
+// @Summary	import families and persons from a CSV or XLSX file
+// @Tags	import
+// @Accept	multipart/form-data
+// @Produce	json
+// @Param	file			formData	file	true	"CSV or XLSX file with the columns family, name, country, state, city, neighborhood, street, number, complement, zipcode, person_name and person_document"
+// @Param	dry_run			query		boolean	false	"only validate the rows"
+// @Param	Idempotency-Key	header		string	false	"key to safely retry the request"
+// @Success	201	{object}	service.ImportResponse	"imported"
+// @Success	200	{object}	service.ImportResponse	"validated by a dry run"
+// @Failure	400	{object}	Problem
+// @Failure	422	{object}	service.ImportResponse	"rows with errors, nothing was imported"
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/imports [post]
+func (impl *ImportApiImpl) Create(c *gin.Context) {
+	var query ImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.Error(&exception.InvalidFileException{Err: fmt.Errorf("invalid file: %w", err)})
+		return
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	if format != infra.SpreadsheetCSV && format != infra.SpreadsheetXLSX {
+		c.Error(&exception.InvalidFileException{Err: errors.New("file must be .csv or .xlsx")})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	rows, err := infra.ReadSpreadsheet(format, file)
+	if err != nil {
+		c.Error(&exception.InvalidFileException{Err: fmt.Errorf("invalid file: %w", err)})
+		return
+	}
+
+	res, err := impl.ImportService.Import(c, service.ImportCreateDto{
+		Filename: filepath.Base(header.Filename),
+		DryRun:   query.DryRun,
+		Rows:     rows,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	switch model.ImportStatus(res.Data.Status) {
+	case model.ImportStatusInvalid:
+		c.JSON(http.StatusUnprocessableEntity, res)
+	case model.ImportStatusValidated:
+		c.JSON(http.StatusOK, res)
+	default:
+		c.JSON(http.StatusCreated, res)
+	}
+}
+
+// @Summary	find import by id
+// @Tags	import
+// @Accept	json
+// @Produce	json
+// @Param	id	path		int	true	"import ID"
+// @Success	200	{object}	service.ImportResponse
+// @Failure	404	{object}	Problem
+// @Router	/api/v1/imports/{id} [get]
+func (impl *ImportApiImpl) FindOneByID(c *gin.Context) {
+	importID, err := strconv.Atoi(c.Param("importID"))
+	if err != nil {
+		c.Error(&exception.InvalidParamException{Param: "importID"})
+		return
+	}
+
+	res, err := impl.ImportService.FindOneById(c, importID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/job_api_mock.go -package mock . JobApi
+type JobApi interface {
+	Configure()
+}
+
+type JobApiImpl struct {
+	Router          *gin.RouterGroup
+	JobService      service.JobService
+	TraceMiddleware func(c *gin.Context)
+}
+
+type JobRunsQuery struct {
+	Limit int `form:"limit,default=20" example:"20" binding:"gte=1,lte=100"`
+}
+
+func (impl *JobApiImpl) Configure() {
+	impl.Router.GET("", impl.TraceMiddleware, impl.FindAll)
+	impl.Router.GET("/:job/runs", impl.TraceMiddleware, impl.FindRuns)
+	impl.Router.POST("/:job/runs", impl.TraceMiddleware, impl.Trigger)
+}
+
+// @Summary	find the scheduled jobs with their next and last run
+// @Tags	admin
+// @Accept	json
+// @Produce	json
+// @Success	200	{object}	service.JobsResponse
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/admin/jobs [get]
+func (impl *JobApiImpl) FindAll(c *gin.Context) {
+	res, err := impl.JobService.FindAll(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary	list the last runs of a job
+// @Tags	admin
+// @Accept	json
+// @Produce	json
+// @Param	job		path		string	true	"job name"
+// @Param	limit	query		integer	false	"number of runs, up to 100"
+// @Success	200		{object}	service.JobRunsResponse
+// @Failure	400		{object}	Problem
+// @Failure	404		{object}	Problem
+// @Failure	500		{object}	Problem
+// @Router	/api/v1/admin/jobs/{job}/runs [get]
+func (impl *JobApiImpl) FindRuns(c *gin.Context) {
+	var query JobRunsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := impl.JobService.FindRuns(c, c.Param("job"), query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary	run a job now
+// @Description	The job runs in background, its run is followed at the runs of the job.
+// @Tags	admin
+// @Accept	json
+// @Produce	json
+// @Param	job	path		string	true	"job name"
+// @Success	202	{object}	service.JobRunResponse
+// @Failure	404	{object}	Problem
+// @Failure	409	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/admin/jobs/{job}/runs [post]
+func (impl *JobApiImpl) Trigger(c *gin.Context) {
+	res, err := impl.JobService.Trigger(c, c.Param("job"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, res)
+}
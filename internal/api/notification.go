@@ -0,0 +1,156 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/notification_api_mock.go -package mock . NotificationApi
+type NotificationApi interface {
+	Configure()
+}
+
+type NotificationApiImpl struct {
+	Router              *gin.RouterGroup
+	PersonRouter        *gin.RouterGroup
+	NotificationService service.NotificationService
+	TraceMiddleware     func(c *gin.Context)
+	Addr                string
+}
+
+func (impl *NotificationApiImpl) Configure() {
+	impl.Router.GET("", impl.TraceMiddleware, impl.FindAll)
+	impl.Router.POST("", impl.TraceMiddleware, impl.Notify)
+	impl.PersonRouter.GET("/:personID/contact", impl.TraceMiddleware, impl.FindContact)
+	impl.PersonRouter.PUT("/:personID/contact", impl.TraceMiddleware, impl.SaveContact)
+}
+
+// @Summary	find the delivery log of the notifications
+// @Tags	notification
+// @Accept	json
+// @Produce	json
+// @Param	limit	query	integer	false	"page size, up to 50"
+// @Param	cursor	query	string	false	"cursor of the page, taken from next_cursor or previous_cursor"
+// @Param	total	query	boolean	false	"count the matching rows"
+// @Param	filter[field][operator]	query	string	false	"filter by id, created_at, person_id, channel, template, status or attempts"
+// @Param	sort	query	string	false	"sort by fields, descending when prefixed by -"	example(-created_at)
+// @Param	fields	query	string	false	"only return these fields"	example(id,channel,status)
+// @Success	200	{object}	service.NotificationsResponse
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/notifications [get]
+func (impl *NotificationApiImpl) FindAll(c *gin.Context) {
+	query, err := ParseQuery(c.Request.URL.Query())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := ParsePaginationQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	res, err := impl.NotificationService.FindAll(c, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	SetPaginationURLs(impl.Addr, c.Request.URL.Query(), &res.PaginationResponse)
+	JSONWithFields(c, http.StatusOK, res)
+}
+
+// @Summary	notify a person, or every person of a family, on the channels they opted in to
+// @Description	Templates are basket_ready and appointment_reminder, which needs the date.
+// @Description	The notifications are queued and sent in background, retried when the sender fails.
+// @Tags	notification
+// @Accept	json
+// @Produce	json
+// @Param	notification	body	service.NotifyDto	true	"Notify"
+// @Param	Idempotency-Key	header	string	false	"key to safely retry the request"
+// @Success	202	{object}	service.NotifyResponse
+// @Failure	400	{object}	Problem
+// @Failure	404	{object}	Problem
+// @Failure	409	{object}	Problem
+// @Failure	422	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/notifications [post]
+func (impl *NotificationApiImpl) Notify(c *gin.Context) {
+	var dto service.NotifyDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := impl.NotificationService.Notify(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, res)
+}
+
+// @Summary	find the contact of a person
+// @Tags	notification
+// @Accept	json
+// @Produce	json
+// @Param	id	path		int	true	"person ID"
+// @Success	200	{object}	service.PersonContactResponse
+// @Failure	400	{object}	Problem
+// @Failure	404	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/persons/{id}/contact [get]
+func (impl *NotificationApiImpl) FindContact(c *gin.Context) {
+	personID, err := strconv.Atoi(c.Param("personID"))
+	if err != nil {
+		c.Error(&exception.InvalidParamException{Param: "personID"})
+		return
+	}
+
+	res, err := impl.NotificationService.FindContact(c, personID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary	save the contact of a person and the channels the person opted in to
+// @Tags	notification
+// @Accept	json
+// @Produce	json
+// @Param	id		path	int							true	"person ID"
+// @Param	contact	body	service.SavePersonContactDto	true	"Save contact"
+// @Success	200	{object}	service.PersonContactResponse
+// @Failure	400	{object}	Problem
+// @Failure	404	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/persons/{id}/contact [put]
+func (impl *NotificationApiImpl) SaveContact(c *gin.Context) {
+	personID, err := strconv.Atoi(c.Param("personID"))
+	if err != nil {
+		c.Error(&exception.InvalidParamException{Param: "personID"})
+		return
+	}
+
+	var dto service.SavePersonContactDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	dto.PersonID = personID
+
+	res, err := impl.NotificationService.SaveContact(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,861 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.0
+// source: socialassistance/v1/socialassistance.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	FamilyService_ListFamilies_FullMethodName = "/socialassistance.v1.FamilyService/ListFamilies"
+	FamilyService_GetFamily_FullMethodName    = "/socialassistance.v1.FamilyService/GetFamily"
+	FamilyService_CreateFamily_FullMethodName = "/socialassistance.v1.FamilyService/CreateFamily"
+	FamilyService_UpdateFamily_FullMethodName = "/socialassistance.v1.FamilyService/UpdateFamily"
+	FamilyService_DeleteFamily_FullMethodName = "/socialassistance.v1.FamilyService/DeleteFamily"
+)
+
+// FamilyServiceClient is the client API for FamilyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FamilyServiceClient interface {
+	ListFamilies(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListFamiliesResponse, error)
+	GetFamily(ctx context.Context, in *GetFamilyRequest, opts ...grpc.CallOption) (*Family, error)
+	CreateFamily(ctx context.Context, in *FamilyRequest, opts ...grpc.CallOption) (*Family, error)
+	UpdateFamily(ctx context.Context, in *FamilyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteFamily(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type familyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFamilyServiceClient(cc grpc.ClientConnInterface) FamilyServiceClient {
+	return &familyServiceClient{cc}
+}
+
+func (c *familyServiceClient) ListFamilies(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListFamiliesResponse, error) {
+	out := new(ListFamiliesResponse)
+	err := c.cc.Invoke(ctx, FamilyService_ListFamilies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *familyServiceClient) GetFamily(ctx context.Context, in *GetFamilyRequest, opts ...grpc.CallOption) (*Family, error) {
+	out := new(Family)
+	err := c.cc.Invoke(ctx, FamilyService_GetFamily_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *familyServiceClient) CreateFamily(ctx context.Context, in *FamilyRequest, opts ...grpc.CallOption) (*Family, error) {
+	out := new(Family)
+	err := c.cc.Invoke(ctx, FamilyService_CreateFamily_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *familyServiceClient) UpdateFamily(ctx context.Context, in *FamilyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FamilyService_UpdateFamily_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *familyServiceClient) DeleteFamily(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FamilyService_DeleteFamily_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FamilyServiceServer is the server API for FamilyService service.
+// All implementations must embed UnimplementedFamilyServiceServer
+// for forward compatibility
+type FamilyServiceServer interface {
+	ListFamilies(context.Context, *ListRequest) (*ListFamiliesResponse, error)
+	GetFamily(context.Context, *GetFamilyRequest) (*Family, error)
+	CreateFamily(context.Context, *FamilyRequest) (*Family, error)
+	UpdateFamily(context.Context, *FamilyRequest) (*emptypb.Empty, error)
+	DeleteFamily(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedFamilyServiceServer()
+}
+
+// UnimplementedFamilyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFamilyServiceServer struct {
+}
+
+func (UnimplementedFamilyServiceServer) ListFamilies(context.Context, *ListRequest) (*ListFamiliesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFamilies not implemented")
+}
+func (UnimplementedFamilyServiceServer) GetFamily(context.Context, *GetFamilyRequest) (*Family, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFamily not implemented")
+}
+func (UnimplementedFamilyServiceServer) CreateFamily(context.Context, *FamilyRequest) (*Family, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFamily not implemented")
+}
+func (UnimplementedFamilyServiceServer) UpdateFamily(context.Context, *FamilyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFamily not implemented")
+}
+func (UnimplementedFamilyServiceServer) DeleteFamily(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFamily not implemented")
+}
+func (UnimplementedFamilyServiceServer) mustEmbedUnimplementedFamilyServiceServer() {}
+
+// UnsafeFamilyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FamilyServiceServer will
+// result in compilation errors.
+type UnsafeFamilyServiceServer interface {
+	mustEmbedUnimplementedFamilyServiceServer()
+}
+
+func RegisterFamilyServiceServer(s grpc.ServiceRegistrar, srv FamilyServiceServer) {
+	s.RegisterService(&FamilyService_ServiceDesc, srv)
+}
+
+func _FamilyService_ListFamilies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FamilyServiceServer).ListFamilies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FamilyService_ListFamilies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FamilyServiceServer).ListFamilies(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FamilyService_GetFamily_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFamilyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FamilyServiceServer).GetFamily(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FamilyService_GetFamily_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FamilyServiceServer).GetFamily(ctx, req.(*GetFamilyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FamilyService_CreateFamily_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FamilyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FamilyServiceServer).CreateFamily(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FamilyService_CreateFamily_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FamilyServiceServer).CreateFamily(ctx, req.(*FamilyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FamilyService_UpdateFamily_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FamilyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FamilyServiceServer).UpdateFamily(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FamilyService_UpdateFamily_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FamilyServiceServer).UpdateFamily(ctx, req.(*FamilyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FamilyService_DeleteFamily_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FamilyServiceServer).DeleteFamily(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FamilyService_DeleteFamily_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FamilyServiceServer).DeleteFamily(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FamilyService_ServiceDesc is the grpc.ServiceDesc for FamilyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FamilyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "socialassistance.v1.FamilyService",
+	HandlerType: (*FamilyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFamilies",
+			Handler:    _FamilyService_ListFamilies_Handler,
+		},
+		{
+			MethodName: "GetFamily",
+			Handler:    _FamilyService_GetFamily_Handler,
+		},
+		{
+			MethodName: "CreateFamily",
+			Handler:    _FamilyService_CreateFamily_Handler,
+		},
+		{
+			MethodName: "UpdateFamily",
+			Handler:    _FamilyService_UpdateFamily_Handler,
+		},
+		{
+			MethodName: "DeleteFamily",
+			Handler:    _FamilyService_DeleteFamily_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "socialassistance/v1/socialassistance.proto",
+}
+
+const (
+	PersonService_ListPersons_FullMethodName  = "/socialassistance.v1.PersonService/ListPersons"
+	PersonService_GetPerson_FullMethodName    = "/socialassistance.v1.PersonService/GetPerson"
+	PersonService_CreatePerson_FullMethodName = "/socialassistance.v1.PersonService/CreatePerson"
+	PersonService_UpdatePerson_FullMethodName = "/socialassistance.v1.PersonService/UpdatePerson"
+	PersonService_DeletePerson_FullMethodName = "/socialassistance.v1.PersonService/DeletePerson"
+)
+
+// PersonServiceClient is the client API for PersonService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PersonServiceClient interface {
+	ListPersons(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListPersonsResponse, error)
+	GetPerson(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Person, error)
+	CreatePerson(ctx context.Context, in *PersonRequest, opts ...grpc.CallOption) (*Person, error)
+	UpdatePerson(ctx context.Context, in *PersonRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeletePerson(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type personServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPersonServiceClient(cc grpc.ClientConnInterface) PersonServiceClient {
+	return &personServiceClient{cc}
+}
+
+func (c *personServiceClient) ListPersons(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListPersonsResponse, error) {
+	out := new(ListPersonsResponse)
+	err := c.cc.Invoke(ctx, PersonService_ListPersons_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personServiceClient) GetPerson(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Person, error) {
+	out := new(Person)
+	err := c.cc.Invoke(ctx, PersonService_GetPerson_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personServiceClient) CreatePerson(ctx context.Context, in *PersonRequest, opts ...grpc.CallOption) (*Person, error) {
+	out := new(Person)
+	err := c.cc.Invoke(ctx, PersonService_CreatePerson_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personServiceClient) UpdatePerson(ctx context.Context, in *PersonRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PersonService_UpdatePerson_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personServiceClient) DeletePerson(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PersonService_DeletePerson_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PersonServiceServer is the server API for PersonService service.
+// All implementations must embed UnimplementedPersonServiceServer
+// for forward compatibility
+type PersonServiceServer interface {
+	ListPersons(context.Context, *ListRequest) (*ListPersonsResponse, error)
+	GetPerson(context.Context, *IdRequest) (*Person, error)
+	CreatePerson(context.Context, *PersonRequest) (*Person, error)
+	UpdatePerson(context.Context, *PersonRequest) (*emptypb.Empty, error)
+	DeletePerson(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPersonServiceServer()
+}
+
+// UnimplementedPersonServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPersonServiceServer struct {
+}
+
+func (UnimplementedPersonServiceServer) ListPersons(context.Context, *ListRequest) (*ListPersonsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPersons not implemented")
+}
+func (UnimplementedPersonServiceServer) GetPerson(context.Context, *IdRequest) (*Person, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPerson not implemented")
+}
+func (UnimplementedPersonServiceServer) CreatePerson(context.Context, *PersonRequest) (*Person, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePerson not implemented")
+}
+func (UnimplementedPersonServiceServer) UpdatePerson(context.Context, *PersonRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePerson not implemented")
+}
+func (UnimplementedPersonServiceServer) DeletePerson(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePerson not implemented")
+}
+func (UnimplementedPersonServiceServer) mustEmbedUnimplementedPersonServiceServer() {}
+
+// UnsafePersonServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PersonServiceServer will
+// result in compilation errors.
+type UnsafePersonServiceServer interface {
+	mustEmbedUnimplementedPersonServiceServer()
+}
+
+func RegisterPersonServiceServer(s grpc.ServiceRegistrar, srv PersonServiceServer) {
+	s.RegisterService(&PersonService_ServiceDesc, srv)
+}
+
+func _PersonService_ListPersons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonServiceServer).ListPersons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonService_ListPersons_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonServiceServer).ListPersons(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonService_GetPerson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonServiceServer).GetPerson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonService_GetPerson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonServiceServer).GetPerson(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonService_CreatePerson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PersonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonServiceServer).CreatePerson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonService_CreatePerson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonServiceServer).CreatePerson(ctx, req.(*PersonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonService_UpdatePerson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PersonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonServiceServer).UpdatePerson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonService_UpdatePerson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonServiceServer).UpdatePerson(ctx, req.(*PersonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonService_DeletePerson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonServiceServer).DeletePerson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonService_DeletePerson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonServiceServer).DeletePerson(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PersonService_ServiceDesc is the grpc.ServiceDesc for PersonService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PersonService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "socialassistance.v1.PersonService",
+	HandlerType: (*PersonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPersons",
+			Handler:    _PersonService_ListPersons_Handler,
+		},
+		{
+			MethodName: "GetPerson",
+			Handler:    _PersonService_GetPerson_Handler,
+		},
+		{
+			MethodName: "CreatePerson",
+			Handler:    _PersonService_CreatePerson_Handler,
+		},
+		{
+			MethodName: "UpdatePerson",
+			Handler:    _PersonService_UpdatePerson_Handler,
+		},
+		{
+			MethodName: "DeletePerson",
+			Handler:    _PersonService_DeletePerson_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "socialassistance/v1/socialassistance.proto",
+}
+
+const (
+	ResourceService_ListResources_FullMethodName          = "/socialassistance.v1.ResourceService/ListResources"
+	ResourceService_GetResource_FullMethodName            = "/socialassistance.v1.ResourceService/GetResource"
+	ResourceService_CreateResource_FullMethodName         = "/socialassistance.v1.ResourceService/CreateResource"
+	ResourceService_UpdateResource_FullMethodName         = "/socialassistance.v1.ResourceService/UpdateResource"
+	ResourceService_UpdateResourceQuantity_FullMethodName = "/socialassistance.v1.ResourceService/UpdateResourceQuantity"
+)
+
+// ResourceServiceClient is the client API for ResourceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ResourceServiceClient interface {
+	ListResources(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error)
+	GetResource(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Resource, error)
+	CreateResource(ctx context.Context, in *ResourceRequest, opts ...grpc.CallOption) (*Resource, error)
+	UpdateResource(ctx context.Context, in *ResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateResourceQuantity(ctx context.Context, in *UpdateResourceQuantityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type resourceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResourceServiceClient(cc grpc.ClientConnInterface) ResourceServiceClient {
+	return &resourceServiceClient{cc}
+}
+
+func (c *resourceServiceClient) ListResources(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error) {
+	out := new(ListResourcesResponse)
+	err := c.cc.Invoke(ctx, ResourceService_ListResources_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) GetResource(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Resource, error) {
+	out := new(Resource)
+	err := c.cc.Invoke(ctx, ResourceService_GetResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) CreateResource(ctx context.Context, in *ResourceRequest, opts ...grpc.CallOption) (*Resource, error) {
+	out := new(Resource)
+	err := c.cc.Invoke(ctx, ResourceService_CreateResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) UpdateResource(ctx context.Context, in *ResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ResourceService_UpdateResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) UpdateResourceQuantity(ctx context.Context, in *UpdateResourceQuantityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ResourceService_UpdateResourceQuantity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResourceServiceServer is the server API for ResourceService service.
+// All implementations must embed UnimplementedResourceServiceServer
+// for forward compatibility
+type ResourceServiceServer interface {
+	ListResources(context.Context, *ListRequest) (*ListResourcesResponse, error)
+	GetResource(context.Context, *IdRequest) (*Resource, error)
+	CreateResource(context.Context, *ResourceRequest) (*Resource, error)
+	UpdateResource(context.Context, *ResourceRequest) (*emptypb.Empty, error)
+	UpdateResourceQuantity(context.Context, *UpdateResourceQuantityRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedResourceServiceServer()
+}
+
+// UnimplementedResourceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedResourceServiceServer struct {
+}
+
+func (UnimplementedResourceServiceServer) ListResources(context.Context, *ListRequest) (*ListResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResources not implemented")
+}
+func (UnimplementedResourceServiceServer) GetResource(context.Context, *IdRequest) (*Resource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResource not implemented")
+}
+func (UnimplementedResourceServiceServer) CreateResource(context.Context, *ResourceRequest) (*Resource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateResource not implemented")
+}
+func (UnimplementedResourceServiceServer) UpdateResource(context.Context, *ResourceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateResource not implemented")
+}
+func (UnimplementedResourceServiceServer) UpdateResourceQuantity(context.Context, *UpdateResourceQuantityRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateResourceQuantity not implemented")
+}
+func (UnimplementedResourceServiceServer) mustEmbedUnimplementedResourceServiceServer() {}
+
+// UnsafeResourceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResourceServiceServer will
+// result in compilation errors.
+type UnsafeResourceServiceServer interface {
+	mustEmbedUnimplementedResourceServiceServer()
+}
+
+func RegisterResourceServiceServer(s grpc.ServiceRegistrar, srv ResourceServiceServer) {
+	s.RegisterService(&ResourceService_ServiceDesc, srv)
+}
+
+func _ResourceService_ListResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).ListResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_ListResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).ListResources(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_GetResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).GetResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_GetResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).GetResource(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_CreateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).CreateResource(ctx, req.(*ResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_UpdateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).UpdateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_UpdateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).UpdateResource(ctx, req.(*ResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_UpdateResourceQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateResourceQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).UpdateResourceQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_UpdateResourceQuantity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).UpdateResourceQuantity(ctx, req.(*UpdateResourceQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResourceService_ServiceDesc is the grpc.ServiceDesc for ResourceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResourceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "socialassistance.v1.ResourceService",
+	HandlerType: (*ResourceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListResources",
+			Handler:    _ResourceService_ListResources_Handler,
+		},
+		{
+			MethodName: "GetResource",
+			Handler:    _ResourceService_GetResource_Handler,
+		},
+		{
+			MethodName: "CreateResource",
+			Handler:    _ResourceService_CreateResource_Handler,
+		},
+		{
+			MethodName: "UpdateResource",
+			Handler:    _ResourceService_UpdateResource_Handler,
+		},
+		{
+			MethodName: "UpdateResourceQuantity",
+			Handler:    _ResourceService_UpdateResourceQuantity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "socialassistance/v1/socialassistance.proto",
+}
+
+const (
+	DonationService_DonateResource_FullMethodName = "/socialassistance.v1.DonationService/DonateResource"
+	DonationService_ReturnResource_FullMethodName = "/socialassistance.v1.DonationService/ReturnResource"
+)
+
+// DonationServiceClient is the client API for DonationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DonationServiceClient interface {
+	DonateResource(ctx context.Context, in *DonateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReturnResource(ctx context.Context, in *ReturnRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type donationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDonationServiceClient(cc grpc.ClientConnInterface) DonationServiceClient {
+	return &donationServiceClient{cc}
+}
+
+func (c *donationServiceClient) DonateResource(ctx context.Context, in *DonateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DonationService_DonateResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) ReturnResource(ctx context.Context, in *ReturnRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DonationService_ReturnResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DonationServiceServer is the server API for DonationService service.
+// All implementations must embed UnimplementedDonationServiceServer
+// for forward compatibility
+type DonationServiceServer interface {
+	DonateResource(context.Context, *DonateRequest) (*emptypb.Empty, error)
+	ReturnResource(context.Context, *ReturnRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedDonationServiceServer()
+}
+
+// UnimplementedDonationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDonationServiceServer struct {
+}
+
+func (UnimplementedDonationServiceServer) DonateResource(context.Context, *DonateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DonateResource not implemented")
+}
+func (UnimplementedDonationServiceServer) ReturnResource(context.Context, *ReturnRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReturnResource not implemented")
+}
+func (UnimplementedDonationServiceServer) mustEmbedUnimplementedDonationServiceServer() {}
+
+// UnsafeDonationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DonationServiceServer will
+// result in compilation errors.
+type UnsafeDonationServiceServer interface {
+	mustEmbedUnimplementedDonationServiceServer()
+}
+
+func RegisterDonationServiceServer(s grpc.ServiceRegistrar, srv DonationServiceServer) {
+	s.RegisterService(&DonationService_ServiceDesc, srv)
+}
+
+func _DonationService_DonateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DonateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).DonateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_DonateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).DonateResource(ctx, req.(*DonateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_ReturnResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).ReturnResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_ReturnResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).ReturnResource(ctx, req.(*ReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DonationService_ServiceDesc is the grpc.ServiceDesc for DonationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DonationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "socialassistance.v1.DonationService",
+	HandlerType: (*DonationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DonateResource",
+			Handler:    _DonationService_DonateResource_Handler,
+		},
+		{
+			MethodName: "ReturnResource",
+			Handler:    _DonationService_ReturnResource_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "socialassistance/v1/socialassistance.proto",
+}
@@ -18,6 +18,7 @@ type PersonApiImpl struct {
 	Router          *gin.RouterGroup
 	PersonService   service.PersonService
 	TraceMiddleware func(c *gin.Context)
+	Addr            string
 }
 
 func (impl *PersonApiImpl) Configure() {
@@ -32,16 +33,40 @@ func (impl *PersonApiImpl) Configure() {
 // @Tags person
 // @Accept json
 // @Produce json
-// @Success 200 {object} service.PersonResponse
+// @Param limit query integer false "page size, up to 50"
+// @Param cursor query string false "cursor of the page, taken from next_cursor or previous_cursor"
+// @Param total query boolean false "count the matching rows"
+// @Param filter[field][operator] query string false "filter by id, created_at, updated_at, family_id, name or document"
+// @Param sort query string false "sort by fields, descending when prefixed by -" example(-created_at)
+// @Param fields query string false "only return these fields" example(id,name)
+// @Param document query string false "same as filter[document], kept for the former clients"
+// @Success 200 {object} service.PersonsResponse
+// @Failure 400 {object} Problem
 // @Router /api/v1/persons [get]
 func (impl *PersonApiImpl) FindAll(c *gin.Context) {
-	res, err := impl.PersonService.FindAll(c)
+	values := c.Request.URL.Query()
+	if document := values.Get("document"); document != "" {
+		values.Add("filter[document]", document)
+	}
+
+	query, err := ParseQuery(values)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := ParsePaginationQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	res, err := impl.PersonService.FindAll(c, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, res)
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, res)
+	SetPaginationURLs(impl.Addr, c.Request.URL.Query(), &res.PaginationResponse)
+	JSONWithFields(c, http.StatusOK, res)
 }
 
 // @Summary	find person by id
@@ -49,28 +74,25 @@ func (impl *PersonApiImpl) FindAll(c *gin.Context) {
 // @Accept	json
 // @Produce	json
 // @Param	id	path		int	true	"person ID"
+// @Param	fields	query	string	false	"only return these fields"	example(id,name)
 // @Success	200	{object}	service.PersonsResponse
-// @Failure	404	{object}	HttpError
+// @Failure	404	{object}	Problem
 // @Router	/api/v1/persons/{id} [get]
 func (impl *PersonApiImpl) FindOneByID(c *gin.Context) {
 	personID, err := strconv.Atoi(c.Param("personID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid personID")
+		c.Error(&exception.InvalidParamException{Param: "personID"})
 		return
 	}
 
 	res, err := impl.PersonService.FindOneById(c, personID)
 	if err != nil {
-		if e, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, e.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
 
 		return
 	}
 
-	c.JSON(http.StatusOK, res)
+	JSONWithFields(c, http.StatusOK, res)
 }
 
 // @Summary	create a person
@@ -78,20 +100,23 @@ func (impl *PersonApiImpl) FindOneByID(c *gin.Context) {
 // @Accept	json
 // @Produce	json
 // @Param	person		body	service.PersonCreateDto	true	"Create person"
+// @Param	Idempotency-Key	header	string	false	"key to safely retry the request"
 // @Success	201	{object}	service.PersonResponse
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	409	{object}	Problem
+// @Failure	422	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/persons [post]
 func (impl *PersonApiImpl) Create(c *gin.Context) {
 	var dto service.PersonCreateDto
 	if err := c.ShouldBindJSON(&dto); err != nil {
-		NewHttpError(c, http.StatusBadRequest, err.Error())
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	res, err := impl.PersonService.Create(c, dto)
 	if err != nil {
-		NewHttpInternalServerError(c)
+		c.Error(err)
 		return
 	}
 
@@ -105,31 +130,25 @@ func (impl *PersonApiImpl) Create(c *gin.Context) {
 // @Param	id			path	int						true	"person ID"
 // @Param	person		body	service.PersonUpdateDto	true	"Update person"
 // @Success	204
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/persons/{id} [patch]
 func (impl *PersonApiImpl) Update(c *gin.Context) {
 	personID, err := strconv.Atoi(c.Param("personID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid personID")
+		c.Error(&exception.InvalidParamException{Param: "personID"})
 		return
 	}
 
 	var dto service.PersonUpdateDto
 	if err = c.ShouldBindJSON(&dto); err != nil {
-		NewHttpError(c, http.StatusBadRequest, err.Error())
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 	dto.ID = personID
 
 	if err = impl.PersonService.Update(c, dto); err != nil {
-		if e, ok := err.(*exception.EmptyModelException); ok {
-			NewHttpError(c, http.StatusBadRequest, e.Error())
-		} else if e, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, e.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -142,18 +161,18 @@ func (impl *PersonApiImpl) Update(c *gin.Context) {
 // @Produce	json
 // @Param	id	path		int	true	"person ID"
 // @Success	204
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/persons/{id} [delete]
 func (impl *PersonApiImpl) Delete(c *gin.Context) {
 	personID, err := strconv.Atoi(c.Param("personID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid personID")
+		c.Error(&exception.InvalidParamException{Param: "personID"})
 		return
 	}
 
 	if err = impl.PersonService.Delete(c, personID); err != nil {
-		NewHttpInternalServerError(c)
+		c.Error(err)
 		return
 	}
 
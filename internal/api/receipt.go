@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/receipt_api_mock.go -package mock . ReceiptApi
+type ReceiptApi interface {
+	Configure()
+}
+
+type ReceiptApiImpl struct {
+	Router          *gin.RouterGroup
+	ReceiptService  service.ReceiptService
+	TraceMiddleware func(c *gin.Context)
+}
+
+func (impl *ReceiptApiImpl) Configure() {
+	impl.Router.GET("/:donationID/receipt.pdf", impl.TraceMiddleware, impl.Donation)
+}
+
+// @Summary	printable receipt of a donation to be signed by the family
+// @Tags	donation
+// @Produce	application/pdf
+// @Param	id	path	int	true	"donation ID"
+// @Success	200	{file}		file
+// @Failure	400	{object}	Problem
+// @Failure	404	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/donations/{id}/receipt.pdf [get]
+func (impl *ReceiptApiImpl) Donation(c *gin.Context) {
+	donationID, err := strconv.Atoi(c.Param("donationID"))
+	if err != nil {
+		c.Error(&exception.InvalidParamException{Param: "donationID"})
+		return
+	}
+
+	res, err := impl.ReceiptService.Donation(c, donationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, res.Filename))
+	c.Data(http.StatusOK, "application/pdf", res.Content)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/report_api_mock.go -package mock . ReportApi
+type ReportApi interface {
+	Configure()
+}
+
+type ReportApiImpl struct {
+	Router          *gin.RouterGroup
+	ReportService   service.ReportService
+	TraceMiddleware func(c *gin.Context)
+}
+
+type DistributionReportQuery struct {
+	From    string `form:"from" example:"2000-01-01"`
+	To      string `form:"to" example:"2000-01-31"`
+	GroupBy string `form:"group_by" example:"resource"`
+}
+
+func (impl *ReportApiImpl) Configure() {
+	impl.Router.GET("/distributions", impl.TraceMiddleware, impl.Distributions)
+}
+
+// @Summary report donated quantities and families served by period
+// @Tags report
+// @Accept json
+// @Produce json
+// @Param from query string false "first day as 2006-01-02, the first day of the month by default"
+// @Param to query string false "last day as 2006-01-02, today by default"
+// @Param group_by query string false "resource, neighborhood, city or month" default(resource)
+// @Success 200 {object} service.DistributionReportResponse
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/v1/reports/distributions [get]
+func (impl *ReportApiImpl) Distributions(c *gin.Context) {
+	var query DistributionReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := impl.ReportService.Distributions(c, service.DistributionReportDto{
+		From:    query.From,
+		To:      query.To,
+		GroupBy: query.GroupBy,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
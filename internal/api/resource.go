@@ -18,6 +18,7 @@ type ResourceApiImpl struct {
 	Router          *gin.RouterGroup
 	ResourceService service.ResourceService
 	TraceMiddleware func(c *gin.Context)
+	Addr            string
 }
 
 func (impl *ResourceApiImpl) Configure() {
@@ -32,16 +33,34 @@ func (impl *ResourceApiImpl) Configure() {
 // @tags 	resource
 // @Accept 	json
 // @produce json
+// @Param 	limit query integer false "page size, up to 50"
+// @Param 	cursor query string false "cursor of the page, taken from next_cursor or previous_cursor"
+// @Param 	total query boolean false "count the matching rows"
+// @Param 	filter[field][operator] query string false "filter by id, created_at, updated_at, name, amount, measurement or quantity"
+// @Param 	sort query string false "sort by fields, descending when prefixed by -" example(-created_at)
+// @Param 	fields query string false "only return these fields" example(id,name,quantity)
 // @Success 200 {object} service.ResourcesResponse
+// @Failure 400 {object} Problem
 // @Router 	/api/v1/resources [get]
 func (impl *ResourceApiImpl) FindAll(c *gin.Context) {
-	res, err := impl.ResourceService.FindAll(c)
+	query, err := ParseQuery(c.Request.URL.Query())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, res)
+		c.Error(err)
+		return
+	}
+	if err := ParsePaginationQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	res, err := impl.ResourceService.FindAll(c, query)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, res)
+	SetPaginationURLs(impl.Addr, c.Request.URL.Query(), &res.PaginationResponse)
+	JSONWithFields(c, http.StatusOK, res)
 }
 
 // @Summary find resource by id
@@ -50,27 +69,23 @@ func (impl *ResourceApiImpl) FindAll(c *gin.Context) {
 // @produce json
 // Param 	id path			int true	"resource ID"
 // @Success 200 {object} 	service.ResourceResponse
-// Failure	404 {objetc}	HttpError
+// Failure	404 {objetc}	Problem
 // @Router /api/v1/resources [get]
 func (impl *ResourceApiImpl) FindOneByID(c *gin.Context) {
 	resourceID, err := strconv.Atoi(c.Param("resourceID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid resourceID")
+		c.Error(&exception.InvalidParamException{Param: "resourceID"})
 		return
 	}
 
 	res, err := impl.ResourceService.FindOneById(c, resourceID)
 	if err != nil {
-		if e, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, e.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
 
 		return
 	}
 
-	c.JSON(http.StatusOK, res)
+	JSONWithFields(c, http.StatusOK, res)
 }
 
 // @Summary	create a resource
@@ -78,20 +93,23 @@ func (impl *ResourceApiImpl) FindOneByID(c *gin.Context) {
 // @Accept	json
 // @Produce	json
 // @Param	resource		body	service.CreateResourceDto	true	"Create resource"
+// @Param	Idempotency-Key	header	string	false	"key to safely retry the request"
 // @Success	201	{object}	service.ResourceResponse
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	409	{object}	Problem
+// @Failure	422	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/resources [post]
 func (impl *ResourceApiImpl) Create(c *gin.Context) {
 	var dto service.CreateResourceDto
 	if err := c.ShouldBindJSON(&dto); err != nil {
-		NewHttpError(c, http.StatusBadRequest, err.Error())
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	res, err := impl.ResourceService.Create(c, dto)
 	if err != nil {
-		NewHttpInternalServerError(c)
+		c.Error(err)
 		return
 	}
 
@@ -105,31 +123,25 @@ func (impl *ResourceApiImpl) Create(c *gin.Context) {
 // @Param	id			path	int							true	"resource ID"
 // @Param	resource	body	service.UpdateResourceDto	true	"Update resource"
 // @Success	204
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/resources/{id} [patch]
 func (impl *ResourceApiImpl) Update(c *gin.Context) {
 	resourceID, err := strconv.Atoi(c.Param("resourceID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid resourceID")
+		c.Error(&exception.InvalidParamException{Param: "resourceID"})
 		return
 	}
 
 	var dto service.UpdateResourceDto
 	if err = c.ShouldBindJSON(&dto); err != nil {
-		NewHttpError(c, http.StatusBadRequest, err.Error())
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 	dto.ID = resourceID
 
 	if err := impl.ResourceService.Update(c, dto); err != nil {
-		if e, ok := err.(*exception.EmptyModelException); ok {
-			NewHttpError(c, http.StatusBadRequest, e.Error())
-		} else if e, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, e.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
 
 		return
 	}
@@ -144,28 +156,24 @@ func (impl *ResourceApiImpl) Update(c *gin.Context) {
 // @Param	id			path	int									true	"resource ID"
 // @Param	resource	body	service.UpdateResourceQuantityDto	true	"Update resource quantity"
 // @Success	204
-// @Failure	400	{object}	HttpError
-// @Failure	500	{object}	HttpError
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
 // @Router	/api/v1/resources/{id}/quantity [patch]
 func (impl *ResourceApiImpl) UpdateQuantity(c *gin.Context) {
 	resourceID, err := strconv.Atoi(c.Param("resourceID"))
 	if err != nil {
-		NewHttpError(c, http.StatusBadRequest, "invalid resourceID")
+		c.Error(&exception.InvalidParamException{Param: "resourceID"})
 		return
 	}
 
 	var dto service.UpdateResourceQuantityDto
 	if err := c.ShouldBindJSON(&dto); err != nil {
-		NewHttpError(c, http.StatusBadRequest, err.Error())
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	if err := impl.ResourceService.UpdateQuantity(c, resourceID, dto); err != nil {
-		if e, ok := err.(*exception.NotFoundException); ok {
-			NewHttpError(c, http.StatusNotFound, e.Error())
-		} else {
-			NewHttpInternalServerError(c)
-		}
+		c.Error(err)
 
 		return
 	}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/resource_stream_api_mock.go -package mock . ResourceStreamApi
+type ResourceStreamApi interface {
+	Configure()
+}
+
+type ResourceStreamApiImpl struct {
+	Router          *gin.RouterGroup
+	StockService    service.StockService
+	TraceMiddleware func(c *gin.Context)
+	Heartbeat       time.Duration
+	// Done ends the streams when the server shuts down
+	Done <-chan struct{}
+}
+
+func (impl *ResourceStreamApiImpl) Configure() {
+	impl.Router.GET("/stream", impl.TraceMiddleware, impl.Stream)
+}
+
+// @Summary	stream the quantity of the resources as they change
+// @Description	Server-Sent Events named stock with a service.StockEvent as data, and ping every few seconds.
+// @Description	Reconnecting with the Last-Event-ID header, or the last_event_id parameter, sends the missed events.
+// @Tags	resource
+// @Produce	text/event-stream
+// @Param	Last-Event-ID	header	string	false	"id of the last received event"
+// @Param	last_event_id	query	string	false	"id of the last received event"
+// @Success	200	{object}	service.StockEvent
+// @Failure	400	{object}	Problem
+// @Router	/api/v1/resources/stream [get]
+func (impl *ResourceStreamApiImpl) Stream(c *gin.Context) {
+	lastEventID := int64(0)
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id < 0 {
+			c.Error(&exception.InvalidParamException{Param: "Last-Event-ID"})
+			return
+		}
+		lastEventID = id
+	}
+
+	subscription := impl.StockService.Subscribe(c, lastEventID)
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", (3 * time.Second).Milliseconds())
+	for _, event := range subscription.Missed {
+		impl.write(c, event)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(impl.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-impl.Done:
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				// dropped for falling behind, the client reconnects with the last id it got
+				return
+			}
+			if err := impl.write(c, event); err != nil {
+				return
+			}
+		case t := <-heartbeat.C:
+			// the write fails past the write timeout of the server, so the client reconnects
+			if _, err := fmt.Fprintf(c.Writer, "event: ping\ndata: %q\n\n", t.Format("2006-01-02T15:04:05")); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func (impl *ResourceStreamApiImpl) write(c *gin.Context, event infra.BrokerEvent[service.StockEvent]) error {
+	data, _ := json.Marshal(event.Data)
+	_, err := fmt.Fprintf(c.Writer, "id: %d\nevent: stock\ndata: %s\n\n", event.ID, data)
+	return err
+}
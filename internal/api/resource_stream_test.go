@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_ResourceStreamApi_Stream(t *testing.T) {
+	cases := map[string]struct {
+		inputHeader     string
+		inputQuery      string
+		inputHeartbeat  time.Duration
+		inputTimeout    time.Duration
+		inputShutdown   bool
+		expectedCode    int
+		expectedBody    string
+		expectedContain string
+		prepareMock     func(mockStockService *mock.MockStockService)
+	}{
+		"should send missed and next events": {
+			inputHeader:    "1",
+			inputHeartbeat: time.Hour,
+			expectedCode:   http.StatusOK,
+			expectedBody: "retry: 3000\n\n" +
+				"id: 2\nevent: stock\ndata: " +
+				`{"resource_id":1,"name":"Arroz","measurement":"Kg","quantity":9,"reason":"donate","changed_at":"2000-01-01T12:03:00"}` + "\n\n" +
+				"id: 3\nevent: stock\ndata: " +
+				`{"resource_id":1,"name":"Arroz","measurement":"Kg","quantity":10,"reason":"return","changed_at":"2000-01-01T12:04:00"}` + "\n\n",
+			prepareMock: func(mockStockService *mock.MockStockService) {
+				events := make(chan infra.BrokerEvent[service.StockEvent], 1)
+				events <- infra.BrokerEvent[service.StockEvent]{ID: 3, Data: service.StockEvent{ResourceID: 1, Name: "Arroz",
+					Measurement: "Kg", Quantity: 10, Reason: "return", ChangedAt: "2000-01-01T12:04:00"}}
+				close(events)
+
+				mockStockService.EXPECT().Subscribe(gomock.Any(), int64(1)).Return(&service.StockSubscription{
+					Missed: []infra.BrokerEvent[service.StockEvent]{{ID: 2, Data: service.StockEvent{ResourceID: 1, Name: "Arroz",
+						Measurement: "Kg", Quantity: 9, Reason: "donate", ChangedAt: "2000-01-01T12:03:00"}}},
+					Events: events,
+					Close:  func() {},
+				})
+			},
+		},
+		"should send heartbeat pings": {
+			inputQuery:      "?last_event_id=5",
+			inputHeartbeat:  10 * time.Millisecond,
+			inputTimeout:    100 * time.Millisecond,
+			expectedCode:    http.StatusOK,
+			expectedContain: "event: ping\n",
+			prepareMock: func(mockStockService *mock.MockStockService) {
+				mockStockService.EXPECT().Subscribe(gomock.Any(), int64(5)).Return(&service.StockSubscription{
+					Missed: []infra.BrokerEvent[service.StockEvent]{},
+					Events: make(chan infra.BrokerEvent[service.StockEvent]),
+					Close:  func() {},
+				})
+			},
+		},
+		"should end stream on shutdown": {
+			inputHeartbeat: time.Hour,
+			inputShutdown:  true,
+			expectedCode:   http.StatusOK,
+			expectedBody:   "retry: 3000\n\n",
+			prepareMock: func(mockStockService *mock.MockStockService) {
+				mockStockService.EXPECT().Subscribe(gomock.Any(), int64(0)).Return(&service.StockSubscription{
+					Missed: []infra.BrokerEvent[service.StockEvent]{},
+					Events: make(chan infra.BrokerEvent[service.StockEvent]),
+					Close:  func() {},
+				})
+			},
+		},
+		"should throw bad request when last event id is invalid": {
+			inputHeader:  "abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid Last-Event-ID",` +
+				`"instance":"/api/v1/resources/stream","code":"invalid_param",` +
+				`"errors":[{"field":"Last-Event-ID","code":"invalid","message":"invalid Last-Event-ID"}]}`,
+			prepareMock: func(mockStockService *mock.MockStockService) {},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStockService := mock.NewMockStockService(ctrl)
+			cs.prepareMock(mockStockService)
+
+			done := make(chan struct{})
+			if cs.inputShutdown {
+				close(done)
+			}
+
+			router := gin.New()
+			router.Use((&ApiImpl{}).ErrorMiddleware)
+			resourceApi := &ResourceApiImpl{
+				Router:          router.Group("/api/v1/resources"),
+				TraceMiddleware: func(c *gin.Context) {},
+			}
+			impl := &ResourceStreamApiImpl{
+				Router:          router.Group("/api/v1/resources"),
+				StockService:    mockStockService,
+				TraceMiddleware: func(c *gin.Context) {},
+				Heartbeat:       cs.inputHeartbeat,
+				Done:            done,
+			}
+			resourceApi.Configure()
+			impl.Configure()
+
+			ctx := context.Background()
+			if cs.inputTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, cs.inputTimeout)
+				defer cancel()
+			}
+
+			// when
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/resources/stream"+cs.inputQuery, nil)
+			if cs.inputHeader != "" {
+				req.Header.Set("Last-Event-ID", cs.inputHeader)
+			}
+			router.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, cs.expectedCode, rec.Code)
+			if cs.expectedBody != "" {
+				assert.Equal(t, cs.expectedBody, rec.Body.String())
+			}
+			if cs.expectedContain != "" {
+				assert.Contains(t, rec.Body.String(), cs.expectedContain)
+				assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/search_api_mock.go -package mock . SearchApi
+type SearchApi interface {
+	Configure()
+}
+
+type SearchApiImpl struct {
+	Router          *gin.RouterGroup
+	SearchService   service.SearchService
+	TraceMiddleware func(c *gin.Context)
+}
+
+type SearchQuery struct {
+	Q     string `form:"q" example:"maria rua 25" binding:"required"`
+	Limit int    `form:"limit,default=20" example:"20" binding:"gte=1,lte=50"`
+}
+
+func (impl *SearchApiImpl) Configure() {
+	impl.Router.GET("", impl.TraceMiddleware, impl.Search)
+}
+
+// @Summary search families and persons
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "words of family names, addresses, person names or a document" example(maria rua 25)
+// @Param limit query integer false "max hits, up to 50"
+// @Success 200 {object} service.SearchResponse
+// @Failure 400 {object} Problem
+// @Router /api/v1/search [get]
+func (impl *SearchApiImpl) Search(c *gin.Context) {
+	var query SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := impl.SearchService.Search(c, query.Q, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+//go:generate mockgen -destination ../../mock/webhook_api_mock.go -package mock . WebhookApi
+type WebhookApi interface {
+	Configure()
+}
+
+type WebhookApiImpl struct {
+	Router          *gin.RouterGroup
+	WebhookService  service.WebhookService
+	TraceMiddleware func(c *gin.Context)
+}
+
+type WebhookDeliveriesQuery struct {
+	Limit int `form:"limit,default=20" example:"20" binding:"gte=1,lte=100"`
+}
+
+func (impl *WebhookApiImpl) Configure() {
+	impl.Router.GET("", impl.TraceMiddleware, impl.FindAll)
+	impl.Router.GET("/:webhookID", impl.TraceMiddleware, impl.FindOneByID)
+	impl.Router.GET("/:webhookID/deliveries", impl.TraceMiddleware, impl.FindDeliveries)
+	impl.Router.POST("", impl.TraceMiddleware, impl.Create)
+	impl.Router.PATCH("/:webhookID", impl.TraceMiddleware, impl.Update)
+	impl.Router.DELETE("/:webhookID", impl.TraceMiddleware, impl.Delete)
+}
+
+// @Summary	find all webhooks
+// @Tags	webhook
+// @Accept	json
+// @Produce	json
+// @Success	200	{object}	service.WebhooksResponse
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/webhooks [get]
+func (impl *WebhookApiImpl) FindAll(c *gin.Context) {
+	res, err := impl.WebhookService.FindAll(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary	find webhook by id
+// @Tags	webhook
+// @Accept	json
+// @Produce	json
+// @Param	id	path		int	true	"webhook ID"
+// @Success	200	{object}	service.WebhookResponse
+// @Failure	400	{object}	Problem
+// @Failure	404	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/webhooks/{id} [get]
+func (impl *WebhookApiImpl) FindOneByID(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("webhookID"))
+	if err != nil {
+		c.Error(&exception.InvalidParamException{Param: "webhookID"})
+		return
+	}
+
+	res, err := impl.WebhookService.FindOneById(c, webhookID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary	list the last deliveries of a webhook with each attempt
+// @Tags	webhook
+// @Accept	json
+// @Produce	json
+// @Param	id		path		int		true	"webhook ID"
+// @Param	limit	query		integer	false	"number of deliveries, up to 100"
+// @Success	200		{object}	service.WebhookDeliveriesResponse
+// @Failure	400		{object}	Problem
+// @Failure	404		{object}	Problem
+// @Failure	500		{object}	Problem
+// @Router	/api/v1/webhooks/{id}/deliveries [get]
+func (impl *WebhookApiImpl) FindDeliveries(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("webhookID"))
+	if err != nil {
+		c.Error(&exception.InvalidParamException{Param: "webhookID"})
+		return
+	}
+
+	var query WebhookDeliveriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := impl.WebhookService.FindDeliveries(c, webhookID, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary	subscribe a webhook to events
+// @Tags	webhook
+// @Accept	json
+// @Produce	json
+// @Param	webhook			body	service.WebhookCreateDto	true	"Create webhook"
+// @Param	Idempotency-Key	header	string	false	"key to safely retry the request"
+// @Success	201	{object}	service.WebhookResponse
+// @Failure	400	{object}	Problem
+// @Failure	409	{object}	Problem
+// @Failure	422	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/webhooks [post]
+func (impl *WebhookApiImpl) Create(c *gin.Context) {
+	var dto service.WebhookCreateDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := impl.WebhookService.Create(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// @Summary	update a webhook
+// @Tags	webhook
+// @Accept	json
+// @Produce	json
+// @Param	id		path	int							true	"webhook ID"
+// @Param	webhook	body	service.WebhookUpdateDto	true	"Update webhook"
+// @Success	204
+// @Failure	400	{object}	Problem
+// @Failure	404	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/webhooks/{id} [patch]
+func (impl *WebhookApiImpl) Update(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("webhookID"))
+	if err != nil {
+		c.Error(&exception.InvalidParamException{Param: "webhookID"})
+		return
+	}
+
+	var dto service.WebhookUpdateDto
+	if err = c.ShouldBindJSON(&dto); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	dto.ID = webhookID
+
+	if err = impl.WebhookService.Update(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary	delete a webhook
+// @Tags	webhook
+// @Accept	json
+// @Produce	json
+// @Param	id	path		int	true	"webhook ID"
+// @Success	204
+// @Failure	400	{object}	Problem
+// @Failure	500	{object}	Problem
+// @Router	/api/v1/webhooks/{id} [delete]
+func (impl *WebhookApiImpl) Delete(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("webhookID"))
+	if err != nil {
+		c.Error(&exception.InvalidParamException{Param: "webhookID"})
+		return
+	}
+
+	if err = impl.WebhookService.Delete(c, webhookID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,49 +1,458 @@
 package configuration
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
+// EnvPrefix prefixes the environment variables overriding the keys, as SOCIALASSISTANCE_MYSQL_HOST for mysql.host
+const EnvPrefix = "SOCIALASSISTANCE"
+
+const (
+	ProfileDev  = "dev"
+	ProfileTest = "test"
+	ProfileProd = "prod"
+)
+
 type HttpConfig struct {
+	Host                string `mapstructure:"host"`
+	Port                int    `mapstructure:"port" validate:"required,min=1,max=65535"`
+	ReadTimeoutMs       int64  `mapstructure:"read_timeout_ms" validate:"gte=0"`
+	ReadHeaderTimeoutMs int64  `mapstructure:"read_header_timeout_ms" validate:"gte=0"`
+	WriteTimeoutMs      int64  `mapstructure:"write_timeout_ms" validate:"gte=0"`
+	IdleTimeoutMs       int64  `mapstructure:"idle_timeout_ms" validate:"gte=0"`
+	MaxHeaderBytes      int    `mapstructure:"max_header_bytes" validate:"gte=0"`
+	MaxBodyBytes        int64  `mapstructure:"max_body_bytes" validate:"gte=0"`
+	// ShutdownTimeoutMs is how long the requests in flight have to finish on SIGTERM
+	ShutdownTimeoutMs int64 `mapstructure:"shutdown_timeout_ms" validate:"gt=0"`
+}
+
+type GrpcConfig struct {
 	Host string `mapstructure:"host"`
-	Port int    `mapstructure:"port"`
+	Port int    `mapstructure:"port" validate:"required,min=1,max=65535"`
 }
 
 type MySQLConfig struct {
-	Host              string `mapstructure:"host"`
-	Port              int    `mapstructure:"port"`
-	Database          string `mapstructure:"database"`
-	Username          string `mapstructure:"username"`
-	Password          string `mapstructure:"password"`
-	ConnMaxLifetimeMs int64  `mapstructure:"conn_max_lifetime_ms"`
-	MaxOpenConns      int    `mapstructure:"max_open_conns"`
-	MaxIdleConns      int    `mapstructure:"max_idle_conns"`
+	Host              string `mapstructure:"host" validate:"required"`
+	Port              int    `mapstructure:"port" validate:"required,min=1,max=65535"`
+	Database          string `mapstructure:"database" validate:"required"`
+	Username          string `mapstructure:"username" validate:"required"`
+	Password          string `mapstructure:"password" secret:"true" env:"MYSQL_PASSWORD"`
+	ConnMaxLifetimeMs int64  `mapstructure:"conn_max_lifetime_ms" validate:"gte=0"`
+	MaxOpenConns      int    `mapstructure:"max_open_conns" validate:"gte=2"`
+	MaxIdleConns      int    `mapstructure:"max_idle_conns" validate:"gte=0,ltefield=MaxOpenConns"`
+}
+
+type CryptoConfig struct {
+	ActiveKeyID string `mapstructure:"active_key_id" validate:"required"`
+	// Keys maps the key ids to the keys, from the environment as "id:base64key,id:base64key"
+	Keys          map[string]string `mapstructure:"keys" validate:"required,min=1" secret:"true" env:"CRYPTO_KEYS"`
+	BlindIndexKey string            `mapstructure:"blind_index_key" validate:"required" secret:"true" env:"CRYPTO_BLIND_INDEX_KEY"`
+}
+
+type IdempotencyConfig struct {
+	TTLMs   int64 `mapstructure:"ttl_ms" validate:"gt=0"`
+	LeaseMs int64 `mapstructure:"lease_ms" validate:"gt=0"`
+}
+
+type ExportConfig struct {
+	DateFormat string `mapstructure:"date_format" validate:"required"`
+	// Columns renames the header of the exported fields by entity, as columns.families.name
+	Columns map[string]map[string]string `mapstructure:"columns"`
+}
+
+type ReceiptConfig struct {
+	// Organization is printed on the header and numbers the receipts
+	Organization string `mapstructure:"organization"`
+	Document     string `mapstructure:"document"`
+	Address      string `mapstructure:"address"`
+}
+
+type DashboardConfig struct {
+	CacheTTLMs int64 `mapstructure:"cache_ttl_ms" validate:"gte=0"`
+}
+
+type WebhookConfig struct {
+	TimeoutMs   int64 `mapstructure:"timeout_ms" validate:"gt=0"`
+	BatchSize   int   `mapstructure:"batch_size" validate:"gte=1"`
+	MaxAttempts int   `mapstructure:"max_attempts" validate:"gte=1"`
+	BackoffMs   int64 `mapstructure:"backoff_ms" validate:"gte=0"`
+	// LowStockQuantity is the resource quantity that publishes resource.low_stock
+	LowStockQuantity float64 `mapstructure:"low_stock_quantity"`
+}
+
+type StreamConfig struct {
+	HeartbeatMs int64 `mapstructure:"heartbeat_ms" validate:"gt=0"`
+	// History is how many events are kept to resume a stream from its Last-Event-ID
+	History int `mapstructure:"history" validate:"gte=0"`
+}
+
+type NotificationConfig struct {
+	TimeoutMs   int64 `mapstructure:"timeout_ms" validate:"gt=0"`
+	BatchSize   int   `mapstructure:"batch_size" validate:"gte=1"`
+	MaxAttempts int   `mapstructure:"max_attempts" validate:"gte=1"`
+	BackoffMs   int64 `mapstructure:"backoff_ms" validate:"gte=0"`
+	// ReminderAfterMs is how long after basket_ready the families that didn't pick up are reminded
+	ReminderAfterMs int64 `mapstructure:"reminder_after_ms" validate:"gt=0"`
+	// Sink sends every channel to the log or to File instead of the providers, for development
+	Sink     string         `mapstructure:"sink" validate:"omitempty,oneof=log file"`
+	File     string         `mapstructure:"file" validate:"required_if=Sink file"`
+	SMTP     SMTPConfig     `mapstructure:"smtp"`
+	SMS      SMSConfig      `mapstructure:"sms"`
+	WhatsApp WhatsAppConfig `mapstructure:"whatsapp"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password" secret:"true" env:"SMTP_PASSWORD"`
+	From     string `mapstructure:"from"`
+}
+
+type SMSConfig struct {
+	URL   string `mapstructure:"url"`
+	From  string `mapstructure:"from"`
+	Token string `mapstructure:"token" secret:"true" env:"SMS_TOKEN"`
+}
+
+type WhatsAppConfig struct {
+	URL           string `mapstructure:"url"`
+	PhoneNumberID string `mapstructure:"phone_number_id"`
+	Token         string `mapstructure:"token" secret:"true" env:"WHATSAPP_TOKEN"`
+}
+
+type SchedulerConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Location of the schedules, as America/Sao_Paulo, the local time when empty
+	Location        string `mapstructure:"location"`
+	TimeoutMs       int64  `mapstructure:"timeout_ms" validate:"gt=0"`
+	RunsRetentionMs int64  `mapstructure:"runs_retention_ms" validate:"gt=0"`
+	// Jobs maps each job to its cron expression, the jobs left out only run when triggered
+	Jobs map[string]string `mapstructure:"jobs"`
+}
+
+type MetricsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// StockTimeoutMs bounds the query of the stock on each scrape
+	StockTimeoutMs int64 `mapstructure:"stock_timeout_ms" validate:"gte=0"`
+}
+
+type TracingConfig struct {
+	ServiceName string `mapstructure:"service_name" validate:"required"`
+	// Exporter sends the spans to otlp, prints them on stdout or, when empty, drops them
+	Exporter string `mapstructure:"exporter" validate:"omitempty,oneof=otlp stdout"`
+	// Endpoint of the OTLP collector over gRPC, OTEL_EXPORTER_OTLP_ENDPOINT when empty
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio" validate:"gte=0,lte=1"`
+}
+
+type LogConfig struct {
+	// Level is one of trace, debug, info, warn, error, fatal or panic
+	Level string `mapstructure:"level" validate:"oneof=trace debug info warn warning error fatal panic"`
+	// Format is json or text
+	Format string `mapstructure:"format" validate:"oneof=json text"`
+	// Redact lists the fields with personal data masked in the logs
+	Redact []string `mapstructure:"redact"`
+}
+
+type HealthConfig struct {
+	TimeoutMs int64 `mapstructure:"timeout_ms" validate:"gt=0"`
+	// MigrationsDir holds the migrations the database must be at, the check is skipped without it
+	MigrationsDir string `mapstructure:"migrations_dir"`
+	// DiskPath is the directory of the files written by the API, as the notifications file
+	DiskPath         string `mapstructure:"disk_path"`
+	DiskMinFreeBytes uint64 `mapstructure:"disk_min_free_bytes"`
+	// QueueMaxDelayMs is how long a webhook or notification may be overdue
+	QueueMaxDelayMs int64 `mapstructure:"queue_max_delay_ms" validate:"gt=0"`
 }
 
 type Config struct {
-	Http  HttpConfig  `mapstructure:"http"`
-	MySQL MySQLConfig `mapstructure:"mysql"`
+	// Profile is dev, test or prod, whose config.<profile>.yml overrides config.yml
+	Profile      string             `mapstructure:"profile" validate:"oneof=dev test prod"`
+	Http         HttpConfig         `mapstructure:"http"`
+	Grpc         GrpcConfig         `mapstructure:"grpc"`
+	MySQL        MySQLConfig        `mapstructure:"mysql"`
+	Crypto       CryptoConfig       `mapstructure:"crypto"`
+	Idempotency  IdempotencyConfig  `mapstructure:"idempotency"`
+	Export       ExportConfig       `mapstructure:"export"`
+	Receipt      ReceiptConfig      `mapstructure:"receipt"`
+	Dashboard    DashboardConfig    `mapstructure:"dashboard"`
+	Webhook      WebhookConfig      `mapstructure:"webhook"`
+	Stream       StreamConfig       `mapstructure:"stream"`
+	Notification NotificationConfig `mapstructure:"notification"`
+	Scheduler    SchedulerConfig    `mapstructure:"scheduler"`
+	Metrics      MetricsConfig      `mapstructure:"metrics"`
+	Tracing      TracingConfig      `mapstructure:"tracing"`
+	Log          LogConfig          `mapstructure:"log"`
+	Health       HealthConfig       `mapstructure:"health"`
 }
 
+// LoadConfig reads config.yml from path and merges config.<profile>.yml over it, the profile being
+// SOCIALASSISTANCE_PROFILE or dev. Each key is overridden by its environment variable, or by the file
+// named in the variable suffixed with _FILE, as Docker and Kubernetes secrets. The .env file of path,
+// when there is one, sets the variables not set yet. The config is validated, the error listing
+// every invalid key.
 func LoadConfig(path string) (Config, error) {
 	var cfg Config
 
-	if err := godotenv.Load(path + "/.env"); err != nil {
+	if err := godotenv.Load(filepath.Join(path, ".env")); err != nil && !errors.Is(err, os.ErrNotExist) {
 		return cfg, err
 	}
 
-	viper.AddConfigPath(path)
-	if err := viper.ReadInConfig(); err != nil {
+	v := viper.New()
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	v.AddConfigPath(path)
+	v.SetConfigName("config")
+	if err := v.ReadInConfig(); err != nil {
 		return cfg, err
 	}
-	if err := viper.Unmarshal(&cfg); err != nil {
+
+	profile := os.Getenv(EnvPrefix + "_PROFILE")
+	if profile == "" {
+		profile = ProfileDev
+	}
+	v.Set("profile", profile)
+	if _, err := os.Stat(filepath.Join(path, "config."+profile+".yml")); err == nil {
+		v.SetConfigName("config." + profile)
+		if err := v.MergeInConfig(); err != nil {
+			return cfg, err
+		}
+	}
+
+	for _, field := range configFields(reflect.TypeOf(cfg), "") {
+		if err := bindEnv(v, field); err != nil {
+			return cfg, err
+		}
+	}
+
+	hook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		stringToMapHook,
+	))
+	if err := v.Unmarshal(&cfg, hook); err != nil {
 		return cfg, err
 	}
 
-	cfg.MySQL.Password = os.Getenv("MYSQL_PASSWORD")
+	return cfg, Validate(cfg)
+}
+
+// configField is a key of the config with the environment variables it is read from
+type configField struct {
+	Key    string
+	Envs   []string
+	Secret bool
+}
+
+// configFields lists the keys of the structs in t, down to their values
+func configFields(t reflect.Type, prefix string) []configField {
+	fields := []configField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		key := prefix + name
+		if field.Type.Kind() == reflect.Struct {
+			fields = append(fields, configFields(field.Type, key+".")...)
+			continue
+		}
+
+		envs := []string{EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))}
+		if env := field.Tag.Get("env"); env != "" {
+			envs = append(envs, env)
+		}
+		fields = append(fields, configField{Key: key, Envs: envs, Secret: field.Tag.Get("secret") == "true"})
+	}
+
+	return fields
+}
+
+// bindEnv reads the key from its variables, or from the file of their _FILE variable. The variables
+// are only bound when set, as binding a map would hide the variables of its keys.
+func bindEnv(v *viper.Viper, field configField) error {
+	for _, env := range field.Envs {
+		if os.Getenv(env) != "" {
+			return v.BindEnv(field.Key, env)
+		}
+	}
+
+	for _, env := range field.Envs {
+		file := os.Getenv(env + "_FILE")
+		if file == "" {
+			continue
+		}
+
+		value, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("cannot read %s_FILE: %w", env, err)
+		}
+		v.Set(field.Key, strings.TrimSpace(string(value)))
+		return nil
+	}
+
+	return nil
+}
+
+// stringToMapHook reads the maps set by the environment, in the "key:value,key:value" format, or
+// in JSON for the nested ones, as export.columns
+func stringToMapHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	switch to {
+	case reflect.TypeOf(map[string]string{}):
+		return parseMap(data.(string))
+	case reflect.TypeOf(map[string]map[string]string{}):
+		entries := map[string]map[string]string{}
+		if err := json.Unmarshal([]byte(data.(string)), &entries); err != nil {
+			return nil, fmt.Errorf(`invalid map %q, expected JSON as {"key":{"key":"value"}}`, data)
+		}
+		return entries, nil
+	}
+
+	return data, nil
+}
+
+// parseMap reads entries in the "key:value,key:value" format
+func parseMap(value string) (map[string]string, error) {
+	entries := map[string]string{}
+
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || key == "" || val == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected key:value", pair)
+		}
+		entries[key] = val
+	}
+
+	return entries, nil
+}
+
+var camelCaseRegex = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// Validate checks the config, the error listing every invalid key
+func Validate(cfg Config) error {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("mapstructure")
+	})
+
+	problems := []string{}
+	if err := validate.Struct(cfg); err != nil {
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			return err
+		}
+		for _, e := range errs {
+			key := strings.TrimPrefix(e.Namespace(), "Config.")
+			problems = append(problems, fmt.Sprintf("%s %s", key, validationMessage(e)))
+		}
+	}
+
+	if _, ok := cfg.Crypto.Keys[cfg.Crypto.ActiveKeyID]; len(cfg.Crypto.Keys) > 0 && !ok {
+		problems = append(problems, fmt.Sprintf("crypto.active_key_id %q is not in crypto.keys", cfg.Crypto.ActiveKeyID))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func validationMessage(e validator.FieldError) string {
+	switch e.Tag() {
+	case "required":
+		return "is required"
+	case "required_if":
+		return fmt.Sprintf("is required when %s", strings.ToLower(camelCaseRegex.ReplaceAllString(e.Param(), "${1}_${2}")))
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s", e.Param())
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s", e.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", e.Param())
+	case "ltefield":
+		return fmt.Sprintf("must be at most %s", strings.ToLower(camelCaseRegex.ReplaceAllString(e.Param(), "${1}_${2}")))
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", strings.ReplaceAll(e.Param(), " ", ", "))
+	}
+
+	return fmt.Sprintf("is invalid (%s)", e.Tag())
+}
+
+// Print writes each key of the config with its value, the secrets redacted
+func Print(w io.Writer, cfg Config) {
+	value := reflect.ValueOf(cfg)
+	for _, field := range configFields(value.Type(), "") {
+		fieldValue := lookup(value, field.Key)
+		if field.Secret {
+			if !fieldValue.IsZero() {
+				fmt.Fprintf(w, "%s: [REDACTED]\n", field.Key)
+			} else {
+				fmt.Fprintf(w, "%s: \n", field.Key)
+			}
+			continue
+		}
+
+		switch fieldValue.Kind() {
+		case reflect.Map:
+			keys := fieldValue.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+			if len(keys) == 0 {
+				fmt.Fprintf(w, "%s: {}\n", field.Key)
+			}
+			for _, key := range keys {
+				fmt.Fprintf(w, "%s.%s: %v\n", field.Key, key, fieldValue.MapIndex(key))
+			}
+		case reflect.Slice:
+			items := []string{}
+			for i := 0; i < fieldValue.Len(); i++ {
+				items = append(items, fmt.Sprint(fieldValue.Index(i)))
+			}
+			fmt.Fprintf(w, "%s: %s\n", field.Key, strings.Join(items, ","))
+		default:
+			fmt.Fprintf(w, "%s: %v\n", field.Key, fieldValue)
+		}
+	}
+}
+
+// lookup finds the value of the key, as mysql.host, in the config
+func lookup(value reflect.Value, key string) reflect.Value {
+	for _, name := range strings.Split(key, ".") {
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).Tag.Get("mapstructure") == name {
+				value = value.Field(i)
+				break
+			}
+		}
+	}
 
-	return cfg, nil
+	return value
 }
@@ -0,0 +1,169 @@
+package configuration_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/configuration"
+)
+
+const (
+	CRYPTO_KEYS            = "v1:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	CRYPTO_BLIND_INDEX_KEY = "YmxpbmRpbmRleGtleWJsaW5kaW5kZXhrZXlibGluZGk="
+)
+
+func Test_LoadConfig(t *testing.T) {
+	cases := map[string]struct {
+		inputEnv       map[string]string
+		inputProfile   string
+		expectedErr    string
+		expectedConfig func(t *testing.T, cfg configuration.Config)
+	}{
+		"should load config.yml": {
+			inputEnv: map[string]string{"CRYPTO_KEYS": CRYPTO_KEYS, "CRYPTO_BLIND_INDEX_KEY": CRYPTO_BLIND_INDEX_KEY},
+			expectedConfig: func(t *testing.T, cfg configuration.Config) {
+				assert.Equal(t, configuration.ProfileDev, cfg.Profile)
+				assert.Equal(t, "localhost", cfg.MySQL.Host)
+				assert.Equal(t, map[string]string{"v1": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="}, cfg.Crypto.Keys)
+			},
+		},
+		"should override keys by prefixed environment variables": {
+			inputEnv: map[string]string{
+				"CRYPTO_KEYS":                                    CRYPTO_KEYS,
+				"CRYPTO_BLIND_INDEX_KEY":                         CRYPTO_BLIND_INDEX_KEY,
+				"SOCIALASSISTANCE_MYSQL_HOST":                    "db",
+				"SOCIALASSISTANCE_MYSQL_MAX_OPEN_CONNS":          "20",
+				"SOCIALASSISTANCE_SCHEDULER_JOBS_PURGE_JOB_RUNS": "@daily",
+				"SOCIALASSISTANCE_LOG_REDACT":                    "name,document",
+			},
+			expectedConfig: func(t *testing.T, cfg configuration.Config) {
+				assert.Equal(t, "db", cfg.MySQL.Host)
+				assert.Equal(t, 20, cfg.MySQL.MaxOpenConns)
+				assert.Equal(t, "@daily", cfg.Scheduler.Jobs["purge_job_runs"])
+				assert.Equal(t, []string{"name", "document"}, cfg.Log.Redact)
+			},
+		},
+		"should override nested map by JSON environment variable": {
+			inputEnv: map[string]string{
+				"CRYPTO_KEYS":                     CRYPTO_KEYS,
+				"CRYPTO_BLIND_INDEX_KEY":          CRYPTO_BLIND_INDEX_KEY,
+				"SOCIALASSISTANCE_EXPORT_COLUMNS": `{"families":{"zipcode":"cep","name":"nome"}}`,
+			},
+			expectedConfig: func(t *testing.T, cfg configuration.Config) {
+				assert.Equal(t, map[string]map[string]string{"families": {"zipcode": "cep", "name": "nome"}}, cfg.Export.Columns)
+			},
+		},
+		"should throw error when nested map is not JSON": {
+			inputEnv: map[string]string{
+				"CRYPTO_KEYS":                     CRYPTO_KEYS,
+				"CRYPTO_BLIND_INDEX_KEY":          CRYPTO_BLIND_INDEX_KEY,
+				"SOCIALASSISTANCE_EXPORT_COLUMNS": "families:zipcode:cep",
+			},
+			expectedErr: "1 error(s) decoding:\n\n* error decoding 'export.columns': " +
+				`invalid map "families:zipcode:cep", expected JSON as {"key":{"key":"value"}}`,
+		},
+		"should merge config of profile": {
+			inputEnv:     map[string]string{"CRYPTO_KEYS": CRYPTO_KEYS, "CRYPTO_BLIND_INDEX_KEY": CRYPTO_BLIND_INDEX_KEY},
+			inputProfile: configuration.ProfileProd,
+			expectedConfig: func(t *testing.T, cfg configuration.Config) {
+				assert.Equal(t, configuration.ProfileProd, cfg.Profile)
+				assert.Equal(t, "0.0.0.0", cfg.MySQL.Host)
+				assert.Equal(t, "socialassistance", cfg.MySQL.Database)
+			},
+		},
+		"should read secrets from files": {
+			inputEnv: map[string]string{
+				"CRYPTO_KEYS_FILE":                        "crypto_keys",
+				"SOCIALASSISTANCE_CRYPTO_BLIND_INDEX_KEY": CRYPTO_BLIND_INDEX_KEY,
+				"MYSQL_PASSWORD_FILE":                     "mysql_password",
+			},
+			expectedConfig: func(t *testing.T, cfg configuration.Config) {
+				assert.Equal(t, "secret", cfg.MySQL.Password)
+				assert.Equal(t, "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=", cfg.Crypto.Keys["v1"])
+			},
+		},
+		"should throw error listing invalid keys": {
+			inputEnv: map[string]string{
+				"CRYPTO_KEYS":                           CRYPTO_KEYS,
+				"SOCIALASSISTANCE_MYSQL_MAX_OPEN_CONNS": "1",
+				"SOCIALASSISTANCE_LOG_FORMAT":           "xml",
+			},
+			expectedErr: "invalid config: mysql.max_open_conns must be at least 2; " +
+				"mysql.max_idle_conns must be at most max_open_conns; crypto.blind_index_key is required; " +
+				"log.format must be one of json, text",
+		},
+		"should throw error when profile is invalid": {
+			inputEnv:     map[string]string{"CRYPTO_KEYS": CRYPTO_KEYS, "CRYPTO_BLIND_INDEX_KEY": CRYPTO_BLIND_INDEX_KEY},
+			inputProfile: "staging",
+			expectedErr:  "invalid config: profile must be one of dev, test, prod",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			dir := t.TempDir()
+			write(t, dir, "config.yml", "mysql:\n  host: 'localhost'\n  port: 3306\n  database: 'socialassistance'\n"+
+				"  username: 'socialassistanceapi'\n  max_open_conns: 10\n  max_idle_conns: 5\n"+
+				"http:\n  port: 8080\n  shutdown_timeout_ms: 1000\ngrpc:\n  port: 9090\n"+
+				"crypto:\n  active_key_id: 'v1'\nidempotency:\n  ttl_ms: 1000\n  lease_ms: 1000\nexport:\n  date_format: '2006-01-02'\n"+
+				"webhook:\n  timeout_ms: 1000\n  batch_size: 1\n  max_attempts: 1\n"+
+				"notification:\n  timeout_ms: 1000\n  batch_size: 1\n  max_attempts: 1\n  reminder_after_ms: 1000\n  sink: 'log'\n"+
+				"stream:\n  heartbeat_ms: 1000\nscheduler:\n  timeout_ms: 1000\n  runs_retention_ms: 1000\n"+
+				"  jobs:\n    purge_job_runs: '30 3 * * *'\n"+
+				"tracing:\n  service_name: 'socialassistanceapi'\nlog:\n  level: 'info'\n  format: 'json'\n"+
+				"health:\n  timeout_ms: 1000\n  queue_max_delay_ms: 1000\n")
+			write(t, dir, "config.prod.yml", "mysql:\n  host: '0.0.0.0'\n")
+			write(t, dir, "crypto_keys", CRYPTO_KEYS+"\n")
+			write(t, dir, "mysql_password", "secret\n")
+
+			for key, value := range cs.inputEnv {
+				if strings.HasSuffix(key, "_FILE") {
+					value = filepath.Join(dir, value)
+				}
+				t.Setenv(key, value)
+			}
+			t.Setenv("SOCIALASSISTANCE_PROFILE", cs.inputProfile)
+
+			// when
+			cfg, err := configuration.LoadConfig(dir)
+
+			// then
+			if cs.expectedErr != "" {
+				assert.EqualError(t, err, cs.expectedErr)
+				return
+			}
+			assert.Nil(t, err)
+			cs.expectedConfig(t, cfg)
+		})
+	}
+}
+
+func Test_Print(t *testing.T) {
+	// given
+	cfg := configuration.Config{
+		MySQL:     configuration.MySQLConfig{Host: "db", Password: "secret"},
+		Crypto:    configuration.CryptoConfig{Keys: map[string]string{"v1": "key"}},
+		Scheduler: configuration.SchedulerConfig{Jobs: map[string]string{"purge_job_runs": "30 3 * * *"}},
+	}
+
+	// when
+	var out bytes.Buffer
+	configuration.Print(&out, cfg)
+
+	// then
+	assert.Contains(t, out.String(), "mysql.host: db\n")
+	assert.Contains(t, out.String(), "mysql.password: [REDACTED]\n")
+	assert.Contains(t, out.String(), "crypto.keys: [REDACTED]\n")
+	assert.Contains(t, out.String(), "crypto.blind_index_key: \n")
+	assert.Contains(t, out.String(), "scheduler.jobs.purge_job_runs: 30 3 * * *\n")
+	assert.NotContains(t, out.String(), "secret")
+	assert.NotContains(t, out.String(), "key\n")
+}
+
+func write(t *testing.T, dir, name, content string) {
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
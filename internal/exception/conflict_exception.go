@@ -0,0 +1,19 @@
+package exception
+
+type ConflictException struct {
+	// Code tells this error apart from others of the type, conflict by default
+	Code string
+	Err  error
+}
+
+func (e *ConflictException) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConflictException) ErrorCode() string {
+	return codeOr(e.Code, "conflict")
+}
+
+func (e *ConflictException) Unwrap() error {
+	return e.Err
+}
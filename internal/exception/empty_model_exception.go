@@ -1,9 +1,19 @@
 package exception
 
 type EmptyModelException struct {
-	Err error
+	// Code tells this error apart from others of the type, empty_model by default
+	Code string
+	Err  error
 }
 
 func (e *EmptyModelException) Error() string {
 	return e.Err.Error()
 }
+
+func (e *EmptyModelException) ErrorCode() string {
+	return codeOr(e.Code, "empty_model")
+}
+
+func (e *EmptyModelException) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,16 @@
+package exception
+
+// Coded is implemented by the exceptions, whose code, as resource.not_found, stays the same while
+// the message may change, so the clients can rely on it
+type Coded interface {
+	error
+	ErrorCode() string
+}
+
+func codeOr(code, def string) string {
+	if code == "" {
+		return def
+	}
+
+	return code
+}
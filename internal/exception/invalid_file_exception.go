@@ -0,0 +1,19 @@
+package exception
+
+type InvalidFileException struct {
+	// Code tells this error apart from others of the type, invalid_file by default
+	Code string
+	Err  error
+}
+
+func (e *InvalidFileException) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidFileException) ErrorCode() string {
+	return codeOr(e.Code, "invalid_file")
+}
+
+func (e *InvalidFileException) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,16 @@
+package exception
+
+import "fmt"
+
+// InvalidParamException is a path parameter or header of the request that could not be parsed
+type InvalidParamException struct {
+	Param string
+}
+
+func (e *InvalidParamException) Error() string {
+	return fmt.Sprintf("invalid %s", e.Param)
+}
+
+func (e *InvalidParamException) ErrorCode() string {
+	return "invalid_param"
+}
@@ -0,0 +1,19 @@
+package exception
+
+type InvalidQueryException struct {
+	// Code tells this error apart from others of the type, invalid_query by default
+	Code string
+	Err  error
+}
+
+func (e *InvalidQueryException) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidQueryException) ErrorCode() string {
+	return codeOr(e.Code, "invalid_query")
+}
+
+func (e *InvalidQueryException) Unwrap() error {
+	return e.Err
+}
@@ -1,9 +1,19 @@
 package exception
 
 type NegativeException struct {
-	Err error
+	// Code tells this error apart from others of the type, negative_quantity by default
+	Code string
+	Err  error
 }
 
 func (e *NegativeException) Error() string {
 	return e.Err.Error()
 }
+
+func (e *NegativeException) ErrorCode() string {
+	return codeOr(e.Code, "negative_quantity")
+}
+
+func (e *NegativeException) Unwrap() error {
+	return e.Err
+}
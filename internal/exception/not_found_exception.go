@@ -1,9 +1,19 @@
 package exception
 
 type NotFoundException struct {
-	Err error
+	// Code tells this error apart from others of the type, not_found by default
+	Code string
+	Err  error
 }
 
 func (e *NotFoundException) Error() string {
 	return e.Err.Error()
 }
+
+func (e *NotFoundException) ErrorCode() string {
+	return codeOr(e.Code, "not_found")
+}
+
+func (e *NotFoundException) Unwrap() error {
+	return e.Err
+}
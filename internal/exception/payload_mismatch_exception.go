@@ -0,0 +1,19 @@
+package exception
+
+type PayloadMismatchException struct {
+	// Code tells this error apart from others of the type, payload_mismatch by default
+	Code string
+	Err  error
+}
+
+func (e *PayloadMismatchException) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PayloadMismatchException) ErrorCode() string {
+	return codeOr(e.Code, "payload_mismatch")
+}
+
+func (e *PayloadMismatchException) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,19 @@
+package exception
+
+type PayloadTooLargeException struct {
+	// Code tells this error apart from others of the type, payload_too_large by default
+	Code string
+	Err  error
+}
+
+func (e *PayloadTooLargeException) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PayloadTooLargeException) ErrorCode() string {
+	return codeOr(e.Code, "payload_too_large")
+}
+
+func (e *PayloadTooLargeException) Unwrap() error {
+	return e.Err
+}
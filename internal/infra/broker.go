@@ -0,0 +1,85 @@
+package infra
+
+import "sync"
+
+// brokerBuffer is how many events a subscriber may fall behind before it is dropped
+const brokerBuffer = 64
+
+type BrokerEvent[T any] struct {
+	ID   int64
+	Data T
+}
+
+// Broker fans out events to the subscribers of this process. It keeps the last events so a
+// subscriber that reconnects receives the ones it missed. Subscribers too slow to keep up are
+// dropped, closing their channel, instead of blocking the publisher.
+type Broker[T any] struct {
+	mu          sync.Mutex
+	lastID      int64
+	history     []BrokerEvent[T]
+	size        int
+	subscribers map[chan BrokerEvent[T]]struct{}
+}
+
+func NewBroker[T any](history int) *Broker[T] {
+	return &Broker[T]{size: history, subscribers: map[chan BrokerEvent[T]]struct{}{}}
+}
+
+func (impl *Broker[T]) Publish(data T) BrokerEvent[T] {
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+
+	impl.lastID++
+	event := BrokerEvent[T]{ID: impl.lastID, Data: data}
+
+	impl.history = append(impl.history, event)
+	if len(impl.history) > impl.size {
+		impl.history = impl.history[len(impl.history)-impl.size:]
+	}
+
+	for ch := range impl.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(impl.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return event
+}
+
+// Subscribe returns the kept events after lastID, when it is not 0, and a channel with the next
+// ones. An id greater than the last published one comes from before a restart, so every kept
+// event is returned. cancel must be called when the subscriber leaves.
+func (impl *Broker[T]) Subscribe(lastID int64) (<-chan BrokerEvent[T], []BrokerEvent[T], func()) {
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+
+	missed := []BrokerEvent[T]{}
+	if lastID > 0 {
+		if lastID > impl.lastID {
+			lastID = 0
+		}
+		for _, event := range impl.history {
+			if event.ID > lastID {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	ch := make(chan BrokerEvent[T], brokerBuffer)
+	impl.subscribers[ch] = struct{}{}
+
+	cancel := func() {
+		impl.mu.Lock()
+		defer impl.mu.Unlock()
+
+		if _, ok := impl.subscribers[ch]; ok {
+			delete(impl.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, missed, cancel
+}
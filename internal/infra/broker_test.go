@@ -0,0 +1,101 @@
+package infra_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+)
+
+func Test_Broker_Subscribe(t *testing.T) {
+	cases := map[string]struct {
+		inputLastID    int64
+		expectedMissed []infra.BrokerEvent[string]
+	}{
+		"should not replay events without last id": {
+			inputLastID:    0,
+			expectedMissed: []infra.BrokerEvent[string]{},
+		},
+		"should replay kept events after last id": {
+			inputLastID:    2,
+			expectedMissed: []infra.BrokerEvent[string]{{ID: 3, Data: "c"}},
+		},
+		"should replay only kept events when last id is too old": {
+			inputLastID:    1,
+			expectedMissed: []infra.BrokerEvent[string]{{ID: 2, Data: "b"}, {ID: 3, Data: "c"}},
+		},
+		"should replay every kept event when last id comes from before a restart": {
+			inputLastID:    10,
+			expectedMissed: []infra.BrokerEvent[string]{{ID: 2, Data: "b"}, {ID: 3, Data: "c"}},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			broker := infra.NewBroker[string](2)
+			broker.Publish("a")
+			broker.Publish("b")
+			broker.Publish("c")
+
+			// when
+			events, missed, cancel := broker.Subscribe(cs.inputLastID)
+			defer cancel()
+			broker.Publish("d")
+
+			// then
+			assert.Equal(t, cs.expectedMissed, missed)
+			assert.Equal(t, infra.BrokerEvent[string]{ID: 4, Data: "d"}, <-events)
+		})
+	}
+}
+
+func Test_Broker_Publish(t *testing.T) {
+	t.Run("should fan out to every subscriber", func(t *testing.T) {
+		// given
+		broker := infra.NewBroker[int](10)
+		first, _, cancelFirst := broker.Subscribe(0)
+		defer cancelFirst()
+		second, _, cancelSecond := broker.Subscribe(0)
+		defer cancelSecond()
+
+		// when
+		broker.Publish(1)
+
+		// then
+		assert.Equal(t, 1, (<-first).Data)
+		assert.Equal(t, 1, (<-second).Data)
+	})
+
+	t.Run("should drop subscriber that does not keep up", func(t *testing.T) {
+		// given
+		broker := infra.NewBroker[int](10)
+		events, _, cancel := broker.Subscribe(0)
+		defer cancel()
+
+		// when
+		for i := 0; i < 100; i++ {
+			broker.Publish(i)
+		}
+
+		// then
+		received := 0
+		for range events {
+			received++
+		}
+		assert.Less(t, received, 100)
+	})
+
+	t.Run("should stop sending after cancel", func(t *testing.T) {
+		// given
+		broker := infra.NewBroker[int](10)
+		events, _, cancel := broker.Subscribe(0)
+
+		// when
+		cancel()
+		broker.Publish(1)
+
+		// then
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,248 @@
+package infra
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// encrypted values are stored as "enc:v1:<key id>:<wrapped data key>:<ciphertext>",
+// anything without the prefix is treated as legacy plaintext
+const cipherPrefix = "enc:v1:"
+
+// Cipher encrypts column values with AES-GCM envelope encryption: every value
+// gets its own random data key, which is wrapped by the active key encryption
+// key. Values wrapped by older keys stay readable, so keys can be rotated.
+type Cipher struct {
+	keys          map[string]cipher.AEAD
+	activeKeyID   string
+	blindIndexKey []byte
+}
+
+// CipherConfigure returns an error when a key is missing or invalid, the personal data not being
+// stored without encryption
+func CipherConfigure(keys map[string]string, activeKeyID, blindIndexKey string) (*Cipher, error) {
+	impl := &Cipher{keys: map[string]cipher.AEAD{}, activeKeyID: activeKeyID}
+
+	for id, key := range keys {
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid encryption key id %q", id)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %s: %w", id, err)
+		}
+		impl.keys[id] = aead
+	}
+
+	if _, ok := impl.keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active encryption key %q not found", activeKeyID)
+	}
+
+	indexKey, err := base64.StdEncoding.DecodeString(blindIndexKey)
+	if err != nil || len(indexKey) < 32 {
+		return nil, fmt.Errorf("blind index key must be at least 32 bytes encoded in base64")
+	}
+	impl.blindIndexKey = indexKey
+
+	return impl, nil
+}
+
+func (impl *Cipher) Encrypt(plaintext string) (string, error) {
+	if impl == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	wrappedKey, err := seal(impl.keys[impl.activeKeyID], dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	dataAEAD, err := aeadFromKey(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(dataAEAD, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return cipherPrefix + impl.activeKeyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (impl *Cipher) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, cipherPrefix) {
+		return value, nil
+	}
+	if impl == nil {
+		return "", fmt.Errorf("encrypted value found but no encryption keys are configured")
+	}
+
+	_, dataKey, ciphertext, err := impl.unwrap(value)
+	if err != nil {
+		return "", err
+	}
+
+	dataAEAD, err := aeadFromKey(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dataAEAD, ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Rewrap wraps the data key of value with the active key. It returns false
+// when value is already wrapped by the active key. Plaintext values are encrypted.
+func (impl *Cipher) Rewrap(value string) (string, bool, error) {
+	if impl == nil || value == "" {
+		return value, false, nil
+	}
+	if !strings.HasPrefix(value, cipherPrefix) {
+		encrypted, err := impl.Encrypt(value)
+		return encrypted, err == nil, err
+	}
+
+	keyID, dataKey, ciphertext, err := impl.unwrap(value)
+	if err != nil {
+		return "", false, err
+	}
+	if keyID == impl.activeKeyID {
+		return value, false, nil
+	}
+
+	wrappedKey, err := seal(impl.keys[impl.activeKeyID], dataKey)
+	if err != nil {
+		return "", false, err
+	}
+
+	return cipherPrefix + impl.activeKeyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), true, nil
+}
+
+// BlindIndex returns a keyed hash of value used for equality search on encrypted columns
+func (impl *Cipher) BlindIndex(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if impl == nil {
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])
+	}
+
+	mac := hmac.New(sha256.New, impl.blindIndexKey)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (impl *Cipher) EncryptFields(fields ...*string) error {
+	for _, field := range fields {
+		value, err := impl.Encrypt(*field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+
+	return nil
+}
+
+func (impl *Cipher) DecryptFields(fields ...*string) error {
+	for _, field := range fields {
+		value, err := impl.Decrypt(*field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+
+	return nil
+}
+
+func (impl *Cipher) unwrap(value string) (string, []byte, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(value, cipherPrefix), ":")
+	if len(parts) != 3 {
+		return "", nil, nil, fmt.Errorf("malformed encrypted value")
+	}
+
+	keyAEAD, ok := impl.keys[parts[0]]
+	if !ok {
+		return "", nil, nil, fmt.Errorf("encryption key %q not found", parts[0])
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, err
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	dataKey, err := open(keyAEAD, wrappedKey)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return parts[0], dataKey, ciphertext, nil
+}
+
+func newAEAD(key string) (cipher.AEAD, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(raw))
+	}
+
+	return aeadFromKey(raw)
+}
+
+func aeadFromKey(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("malformed encrypted value")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
@@ -0,0 +1,163 @@
+package infra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+)
+
+const (
+	KEY_V1     = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	KEY_V2     = "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="
+	BLIND_KEY  = "YmxpbmRpbmRleGtleWJsaW5kaW5kZXhrZXlibGluZGk="
+	BLIND_KEY2 = "YW5vdGhlcmJsaW5kaW5kZXhrZXlhbm90aGVyYmxpbmQ="
+)
+
+func Test_CipherConfigure(t *testing.T) {
+	cases := map[string]struct {
+		inputKeys          map[string]string
+		inputActiveKeyID   string
+		inputBlindIndexKey string
+		expectedErr        string
+	}{
+		"should configure cipher": {
+			inputKeys:          map[string]string{"v1": KEY_V1},
+			inputActiveKeyID:   "v1",
+			inputBlindIndexKey: BLIND_KEY,
+		},
+		"should throw error when keys are missing": {
+			inputActiveKeyID:   "v1",
+			inputBlindIndexKey: BLIND_KEY,
+			expectedErr:        `active encryption key "v1" not found`,
+		},
+		"should throw error when key is invalid": {
+			inputKeys:          map[string]string{"v1": "abc"},
+			inputActiveKeyID:   "v1",
+			inputBlindIndexKey: BLIND_KEY,
+			expectedErr:        "invalid encryption key v1",
+		},
+		"should throw error when blind index key is missing": {
+			inputKeys:        map[string]string{"v1": KEY_V1},
+			inputActiveKeyID: "v1",
+			expectedErr:      "blind index key must be at least 32 bytes encoded in base64",
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			impl, err := infra.CipherConfigure(cs.inputKeys, cs.inputActiveKeyID, cs.inputBlindIndexKey)
+
+			// then
+			if cs.expectedErr == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, impl)
+			} else {
+				assert.ErrorContains(t, err, cs.expectedErr)
+				assert.Nil(t, impl)
+			}
+		})
+	}
+}
+
+func Test_Cipher_EncryptDecrypt(t *testing.T) {
+	cases := map[string]struct {
+		inputValue string
+	}{
+		"should encrypt and decrypt text":    {inputValue: "R. Vinte e Cinco de Março"},
+		"should keep empty text unencrypted": {inputValue: ""},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			impl, _ := infra.CipherConfigure(map[string]string{"v1": KEY_V1}, "v1", BLIND_KEY)
+
+			// when
+			encrypted, err := impl.Encrypt(cs.inputValue)
+			assert.Nil(t, err)
+			decrypted, err := impl.Decrypt(encrypted)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, cs.inputValue, decrypted)
+			if cs.inputValue != "" {
+				assert.NotContains(t, encrypted, cs.inputValue)
+				assert.True(t, strings.HasPrefix(encrypted, "enc:v1:v1:"))
+			}
+		})
+	}
+}
+
+func Test_Cipher_Decrypt(t *testing.T) {
+	cases := map[string]struct {
+		inputValue  string
+		expectedRes string
+		expectedErr bool
+	}{
+		"should return plaintext when value is not encrypted": {
+			inputValue:  "Sauro",
+			expectedRes: "Sauro",
+		},
+		"should throw error when value is malformed": {
+			inputValue:  "enc:v1:v1:abc",
+			expectedErr: true,
+		},
+		"should throw error when key is unknown": {
+			inputValue:  "enc:v1:v9:abc:def",
+			expectedErr: true,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			impl, _ := infra.CipherConfigure(map[string]string{"v1": KEY_V1}, "v1", BLIND_KEY)
+
+			// when
+			res, err := impl.Decrypt(cs.inputValue)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err != nil)
+			assert.Equal(t, cs.expectedRes, res)
+		})
+	}
+}
+
+func Test_Cipher_Rewrap(t *testing.T) {
+	t.Run("should rewrap value with active key", func(t *testing.T) {
+		// given
+		old, _ := infra.CipherConfigure(map[string]string{"v1": KEY_V1}, "v1", BLIND_KEY)
+		impl, _ := infra.CipherConfigure(map[string]string{"v1": KEY_V1, "v2": KEY_V2}, "v2", BLIND_KEY)
+
+		encrypted, _ := old.Encrypt("Sauro")
+
+		// when
+		rewrapped, changed, err := impl.Rewrap(encrypted)
+		again, changedAgain, _ := impl.Rewrap(rewrapped)
+		decrypted, _ := impl.Decrypt(rewrapped)
+
+		// then
+		assert.Nil(t, err)
+		assert.True(t, changed)
+		assert.True(t, strings.HasPrefix(rewrapped, "enc:v1:v2:"))
+		assert.False(t, changedAgain)
+		assert.Equal(t, rewrapped, again)
+		assert.Equal(t, "Sauro", decrypted)
+	})
+}
+
+func Test_Cipher_BlindIndex(t *testing.T) {
+	t.Run("should return deterministic keyed index", func(t *testing.T) {
+		// given
+		impl, _ := infra.CipherConfigure(map[string]string{"v1": KEY_V1}, "v1", BLIND_KEY)
+		other, _ := infra.CipherConfigure(map[string]string{"v1": KEY_V1}, "v1", BLIND_KEY2)
+
+		// when
+		index := impl.BlindIndex("12345678900")
+
+		// then
+		assert.Len(t, index, 64)
+		assert.Equal(t, index, impl.BlindIndex(" 12345678900 "))
+		assert.NotEqual(t, index, other.BlindIndex("12345678900"))
+	})
+}
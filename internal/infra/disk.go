@@ -0,0 +1,13 @@
+package infra
+
+import "syscall"
+
+// FreeDiskSpace returns the bytes available to the API in the file system of path
+func FreeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,67 @@
+package infra_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+)
+
+func Test_PublicHttpClient(t *testing.T) {
+	t.Run("should refuse loopback address", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		client := infra.PublicHttpClient(time.Second)
+
+		// when
+		res, err := client.Get(server.URL)
+
+		// then
+		assert.Nil(t, res)
+		assert.ErrorContains(t, err, "address 127.0.0.1 is not public")
+	})
+
+	t.Run("should not follow redirects", func(t *testing.T) {
+		// given
+		client := infra.PublicHttpClient(time.Second)
+
+		// when
+		err := client.CheckRedirect(&http.Request{}, []*http.Request{{}})
+
+		// then
+		assert.Equal(t, http.ErrUseLastResponse, err)
+	})
+}
+
+func Test_IsPublicIP(t *testing.T) {
+	cases := map[string]struct {
+		inputIP  string
+		expected bool
+	}{
+		"should accept public ipv4":          {inputIP: "8.8.8.8", expected: true},
+		"should accept public ipv6":          {inputIP: "2001:4860:4860::8888", expected: true},
+		"should refuse loopback":             {inputIP: "127.0.0.1"},
+		"should refuse ipv6 loopback":        {inputIP: "::1"},
+		"should refuse private":              {inputIP: "10.0.0.1"},
+		"should refuse private ipv6":         {inputIP: "fd00::1"},
+		"should refuse link-local metadata":  {inputIP: "169.254.169.254"},
+		"should refuse link-local ipv6":      {inputIP: "fe80::1"},
+		"should refuse unspecified":          {inputIP: "0.0.0.0"},
+		"should refuse ipv4 mapped loopback": {inputIP: "::ffff:127.0.0.1"},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			result := infra.IsPublicIP(net.ParseIP(cs.inputIP))
+
+			// then
+			assert.Equal(t, cs.expected, result)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+type loggerKey struct{}
+
+// LoggerConfigure sets the level and the format, json or text, of the logs. The entries get the
+// trace of the span in their context and the values of the redacted fields are masked.
+func LoggerConfigure(level, format string, redacted []string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case LogFormatJSON, "":
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	case LogFormatText:
+		logrus.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	default:
+		return fmt.Errorf("invalid log format %q", format)
+	}
+
+	logrus.SetLevel(lvl)
+	logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+	logrus.AddHook(TraceHook{})
+	logrus.AddHook(NewRedactHook(redacted...))
+
+	return nil
+}
+
+// WithLogFields returns a copy of ctx whose logger adds fields to the entries,
+// as the route of the request
+func WithLogFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return context.WithValue(ctx, loggerKey{}, Logger(ctx).WithFields(fields))
+}
+
+// Logger is the logger of ctx, with the fields of the request and the trace of its span
+func Logger(ctx context.Context) *logrus.Entry {
+	entry, ok := ctx.Value(loggerKey{}).(*logrus.Entry)
+	if !ok {
+		entry = logrus.NewEntry(logrus.StandardLogger())
+	}
+
+	return entry.WithContext(ctx)
+}
+
+// RedactHook masks the values of the fields with personal data, whatever their case
+type RedactHook struct {
+	fields map[string]bool
+}
+
+func NewRedactHook(fields ...string) *RedactHook {
+	hook := &RedactHook{fields: map[string]bool{}}
+	for _, field := range fields {
+		hook.fields[strings.ToLower(field)] = true
+	}
+
+	return hook
+}
+
+func (hook *RedactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (hook *RedactHook) Fire(entry *logrus.Entry) error {
+	for key := range entry.Data {
+		if hook.fields[strings.ToLower(key)] {
+			entry.Data[key] = "[REDACTED]"
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package infra_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+)
+
+func Test_LoggerConfigure(t *testing.T) {
+	cases := map[string]struct {
+		inputLevel  string
+		inputFormat string
+		expectedErr bool
+	}{
+		"should configure json logs": {inputLevel: "info", inputFormat: infra.LogFormatJSON},
+		"should configure text logs": {inputLevel: "debug", inputFormat: infra.LogFormatText},
+		"should throw error when level is invalid": {
+			inputLevel:  "verbose",
+			inputFormat: infra.LogFormatJSON,
+			expectedErr: true,
+		},
+		"should throw error when format is invalid": {
+			inputLevel:  "info",
+			inputFormat: "xml",
+			expectedErr: true,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			err := infra.LoggerConfigure(cs.inputLevel, cs.inputFormat, nil)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err != nil)
+		})
+	}
+}
+
+func Test_Logger(t *testing.T) {
+	cases := map[string]struct {
+		inputFields    logrus.Fields
+		expectedFields map[string]interface{}
+	}{
+		"should log with fields of context": {
+			inputFields:    logrus.Fields{"route": "/api/v1/families/:familyID"},
+			expectedFields: map[string]interface{}{"route": "/api/v1/families/:familyID", "path": "test"},
+		},
+		"should redact personal data": {
+			inputFields:    logrus.Fields{"route": "/api/v1/persons", "Document": "12345678900"},
+			expectedFields: map[string]interface{}{"route": "/api/v1/persons", "Document": "[REDACTED]", "path": "test"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			assert.Nil(t, infra.LoggerConfigure("info", infra.LogFormatJSON, []string{"document"}))
+			defer logrus.SetOutput(logrus.StandardLogger().Out)
+
+			var out bytes.Buffer
+			logrus.SetOutput(&out)
+
+			ctx := infra.WithLogFields(context.Background(), cs.inputFields)
+
+			// when
+			infra.Logger(ctx).WithFields(logrus.Fields{"path": "test"}).Info("message")
+
+			// then
+			var entry map[string]interface{}
+			assert.Nil(t, json.Unmarshal(out.Bytes(), &entry))
+			for key, value := range cs.expectedFields {
+				assert.Equal(t, value, entry[key])
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package infra
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const metricsNamespace = "socialassistance"
+
+var (
+	HttpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HttpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the HTTP requests by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	RepositoryQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "repository",
+		Name:      "query_duration_seconds",
+		Help:      "Duration of the repository methods querying MySQL.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"repository", "method"})
+
+	DonationsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "donations_total",
+		Help:      "Donations of resources to families.",
+	})
+)
+
+// NewMetricsRegistry registers the Go runtime, process, MySQL pool and application metrics,
+// plus the given collectors
+func NewMetricsRegistry(db *sql.DB, cs ...prometheus.Collector) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, "mysql"),
+		HttpRequestsTotal,
+		HttpRequestDuration,
+		RepositoryQueryDuration,
+		DonationsTotal,
+	)
+	registry.MustRegister(cs...)
+
+	return registry
+}
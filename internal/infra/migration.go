@@ -0,0 +1,85 @@
+package infra
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang-migrate/migrate/v4"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_.+\.up\.sql$`)
+
+// LatestMigration returns the version of the last migration in dir, named as 000001_create_x.up.sql
+func LatestMigration(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	latest := 0
+	for _, entry := range entries {
+		match := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		if version, _ := strconv.Atoi(match[1]); version > latest {
+			latest = version
+		}
+	}
+
+	return latest, nil
+}
+
+// Migrate applies the migrations of dir not applied yet or, with down, reverts the last one. The
+// versions are kept in the schema_migrations table, as by the migrate CLI, and the version the
+// database is left at is returned.
+func Migrate(dir, host string, port int, database, username, password string, down bool) (uint, error) {
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = fmt.Sprintf("%s:%d", host, port)
+	cfg.DBName = database
+	cfg.User = username
+	cfg.Passwd = password
+	cfg.MultiStatements = true
+
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	driver, err := migratemysql.WithInstance(db, &migratemysql.Config{})
+	if err != nil {
+		return 0, err
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://"+dir, "mysql", driver)
+	if err != nil {
+		return 0, err
+	}
+
+	if down {
+		err = m.Steps(-1)
+	} else {
+		err = m.Up()
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return 0, err
+	}
+	if dirty {
+		return version, fmt.Errorf("migration %d failed and must be fixed by hand", version)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,42 @@
+package infra_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+)
+
+func Test_LatestMigration(t *testing.T) {
+	cases := map[string]struct {
+		inputFiles      []string
+		expectedVersion int
+	}{
+		"should return version of last up migration": {
+			inputFiles: []string{"000001_create_persons.up.sql", "000001_create_persons.down.sql",
+				"000012_create_webhooks.up.sql", "000002_create_families.up.sql", "README.md"},
+			expectedVersion: 12,
+		},
+		"should return zero without migrations": {
+			expectedVersion: 0,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			dir := t.TempDir()
+			for _, file := range cs.inputFiles {
+				assert.Nil(t, os.WriteFile(filepath.Join(dir, file), []byte{}, 0o644))
+			}
+
+			// when
+			version, err := infra.LatestMigration(dir)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, cs.expectedVersion, version)
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/go-sql-driver/mysql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
 type MySQL struct {
@@ -16,7 +18,14 @@ func MySQLConfigure(host string, port int, database, username, password string,
 	connMaxLifetime time.Duration, maxOpenConns, maxIdleConns int) MySQL {
 	url := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", username, password, host, port, database)
 
-	db, err := sql.Open("mysql", url)
+	// each statement is traced as a span of the request or job running it
+	db, err := otelsql.Open("mysql", url, otelsql.WithAttributes(semconv.DBSystemMySQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			OmitConnPrepare:      true,
+			OmitRows:             true,
+		}))
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,169 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Message is a rendered notification addressed to an e-mail or a phone number
+type Message struct {
+	Channel string `json:"channel"`
+	To      string `json:"to"`
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body"`
+}
+
+//go:generate mockgen -destination ../../mock/sender_mock.go -package mock . Sender
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// SMTPSender sends e-mails in plain text through a SMTP server, authenticating when Username is set
+type SMTPSender struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (impl *SMTPSender) Send(ctx context.Context, message Message) error {
+	var auth smtp.Auth
+	if impl.Username != "" {
+		auth = smtp.PlainAuth("", impl.Username, impl.Password, impl.Host)
+	}
+
+	headers := []string{
+		"From: " + impl.From,
+		"To: " + message.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(message.Body, "\n", "\r\n")
+
+	addr := net.JoinHostPort(impl.Host, strconv.Itoa(impl.Port))
+
+	return smtp.SendMail(addr, auth, impl.From, []string{message.To}, []byte(body))
+}
+
+// SMSSender posts {"from", "to", "text"} to the SMS gateway with a bearer token
+type SMSSender struct {
+	URL    string
+	Token  string
+	From   string
+	Client *http.Client
+}
+
+func (impl *SMSSender) Send(ctx context.Context, message Message) error {
+	return postJSON(ctx, impl.Client, impl.URL, impl.Token, map[string]string{
+		"from": impl.From,
+		"to":   message.To,
+		"text": message.Body,
+	})
+}
+
+// WhatsAppSender sends text messages through the WhatsApp Business Cloud API
+type WhatsAppSender struct {
+	URL           string
+	PhoneNumberID string
+	Token         string
+	Client        *http.Client
+}
+
+func (impl *WhatsAppSender) Send(ctx context.Context, message Message) error {
+	url := fmt.Sprintf("%s/%s/messages", strings.TrimSuffix(impl.URL, "/"), impl.PhoneNumberID)
+
+	return postJSON(ctx, impl.Client, url, impl.Token, map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"recipient_type":    "individual",
+		"to":                strings.TrimPrefix(message.To, "+"),
+		"type":              "text",
+		"text":              map[string]interface{}{"preview_url": false, "body": message.Body},
+	})
+}
+
+// LogSender logs the messages instead of sending them, for development
+type LogSender struct{}
+
+func (impl *LogSender) Send(ctx context.Context, message Message) error {
+	Logger(ctx).WithFields(logrus.Fields{
+		"path":    "internal.infra.notification.log_sender",
+		"channel": message.Channel,
+		"to":      message.To,
+		"subject": message.Subject,
+	}).Info(message.Body)
+
+	return nil
+}
+
+// FileSender appends the messages as JSON lines to Path, for development
+type FileSender struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (impl *FileSender) Send(ctx context.Context, message Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+
+	file, err := os.OpenFile(impl.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+
+	return err
+}
+
+func postJSON(ctx context.Context, client *http.Client, url, token string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		response, _ := io.ReadAll(io.LimitReader(res.Body, 1000))
+		return fmt.Errorf("%s responded %d: %s", url, res.StatusCode, strings.TrimSpace(string(response)))
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package infra_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+)
+
+func Test_Sender_Send(t *testing.T) {
+	cases := map[string]struct {
+		inputStatus   int
+		newSender     func(url string) infra.Sender
+		expectedPath  string
+		expectedBody  string
+		expectedError bool
+	}{
+		"should post sms to the gateway": {
+			inputStatus: http.StatusOK,
+			newSender: func(url string) infra.Sender {
+				return &infra.SMSSender{URL: url + "/sms", Token: "token", From: "ONG", Client: http.DefaultClient}
+			},
+			expectedPath: "/sms",
+			expectedBody: `{"from":"ONG","text":"Olá","to":"+5521999999999"}`,
+		},
+		"should post whatsapp text to the phone number": {
+			inputStatus: http.StatusOK,
+			newSender: func(url string) infra.Sender {
+				return &infra.WhatsAppSender{URL: url, PhoneNumberID: "123", Token: "token", Client: http.DefaultClient}
+			},
+			expectedPath: "/123/messages",
+			expectedBody: `{"messaging_product":"whatsapp","recipient_type":"individual",` +
+				`"text":{"body":"Olá","preview_url":false},"to":"5521999999999","type":"text"}`,
+		},
+		"should throw error when the gateway refuses the message": {
+			inputStatus: http.StatusBadRequest,
+			newSender: func(url string) infra.Sender {
+				return &infra.SMSSender{URL: url + "/sms", Token: "token", Client: http.DefaultClient}
+			},
+			expectedPath:  "/sms",
+			expectedError: true,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			var path, authorization, body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				path, authorization, body = r.URL.Path, r.Header.Get("Authorization"), string(b)
+				w.WriteHeader(cs.inputStatus)
+			}))
+			defer server.Close()
+
+			// when
+			err := cs.newSender(server.URL).Send(context.Background(), infra.Message{
+				Channel: "sms",
+				To:      "+5521999999999",
+				Body:    "Olá",
+			})
+
+			// then
+			assert.Equal(t, cs.expectedError, err != nil)
+			assert.Equal(t, cs.expectedPath, path)
+			assert.Equal(t, "Bearer token", authorization)
+			if cs.expectedBody != "" {
+				assert.JSONEq(t, cs.expectedBody, body)
+			}
+		})
+	}
+}
+
+func Test_FileSender_Send(t *testing.T) {
+	t.Run("should append messages as json lines", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "notifications.jsonl")
+		sender := &infra.FileSender{Path: path}
+
+		// when
+		err1 := sender.Send(context.Background(), infra.Message{Channel: "email", To: "a@b.com", Subject: "Oi", Body: "1"})
+		err2 := sender.Send(context.Background(), infra.Message{Channel: "sms", To: "+55", Body: "2"})
+
+		// then
+		assert.Nil(t, err1)
+		assert.Nil(t, err2)
+
+		content, err := os.ReadFile(path)
+		assert.Nil(t, err)
+
+		messages := []infra.Message{}
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		for decoder.More() {
+			var message infra.Message
+			assert.Nil(t, decoder.Decode(&message))
+			messages = append(messages, message)
+		}
+		assert.Equal(t, []infra.Message{
+			{Channel: "email", To: "a@b.com", Subject: "Oi", Body: "1"},
+			{Channel: "sms", To: "+55", Body: "2"},
+		}, messages)
+	})
+}
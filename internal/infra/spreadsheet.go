@@ -0,0 +1,149 @@
+package infra
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	SpreadsheetCSV  = "csv"
+	SpreadsheetXLSX = "xlsx"
+)
+
+// ReadSpreadsheet returns the rows of a CSV file, separated by commas or semicolons,
+// or of the first sheet of a XLSX file
+func ReadSpreadsheet(format string, r io.Reader) ([][]string, error) {
+	switch format {
+	case SpreadsheetCSV:
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		header, _, _ := bytes.Cut(content, []byte("\n"))
+		if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+			reader.Comma = ';'
+		}
+
+		return reader.ReadAll()
+
+	case SpreadsheetXLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return file.GetRows(file.GetSheetName(0))
+	}
+
+	return nil, fmt.Errorf("unsupported spreadsheet format %s", format)
+}
+
+// SpreadsheetWriter writes rows to a spreadsheet as they come, without holding them in memory
+type SpreadsheetWriter interface {
+	Write(row []interface{}) error
+	Close() error
+}
+
+// NewSpreadsheetWriter returns a CSV or XLSX writer to w, which must be closed to flush the last rows
+func NewSpreadsheetWriter(format string, w io.Writer) (SpreadsheetWriter, error) {
+	switch format {
+	case SpreadsheetCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+
+	case SpreadsheetXLSX:
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter(file.GetSheetName(0))
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return &xlsxWriter{file: file, stream: stream, w: w}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported spreadsheet format %s", format)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	rows   int
+}
+
+func (w *csvWriter) Write(row []interface{}) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = escapeFormula(v)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+
+	if err := w.writer.Write(record); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%1000 == 0 {
+		w.writer.Flush()
+		return w.writer.Error()
+	}
+
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// escapeFormula quotes the text that spreadsheet programs would run as a formula when opening
+// the CSV, as "=HYPERLINK(...)". The numbers are not text, so negative quantities are kept.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	w      io.Writer
+	rows   int
+}
+
+func (w *xlsxWriter) Write(row []interface{}) error {
+	w.rows++
+	cell, err := excelize.CoordinatesToCellName(1, w.rows)
+	if err != nil {
+		return err
+	}
+
+	return w.stream.SetRow(cell, row)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+
+	_, err := w.file.WriteTo(w.w)
+	return err
+}
@@ -0,0 +1,116 @@
+package infra_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/xuri/excelize/v2"
+)
+
+func Test_ReadSpreadsheet(t *testing.T) {
+	xlsx := excelize.NewFile()
+	xlsx.SetSheetRow("Sheet1", "A1", &[]string{"name", "zipcode"})
+	xlsx.SetSheetRow("Sheet1", "A2", &[]string{"Silva", "01035000"})
+	xlsxContent, _ := xlsx.WriteToBuffer()
+
+	cases := map[string]struct {
+		inputFormat  string
+		inputContent []byte
+		expectedRows [][]string
+		expectedErr  bool
+	}{
+		"should read csv separated by commas": {
+			inputFormat:  infra.SpreadsheetCSV,
+			inputContent: []byte("name,zipcode\nSilva,01035000\n"),
+			expectedRows: [][]string{{"name", "zipcode"}, {"Silva", "01035000"}},
+		},
+		"should read csv separated by semicolons with byte order mark": {
+			inputFormat:  infra.SpreadsheetCSV,
+			inputContent: []byte("\xef\xbb\xbfname;street\nSilva;Rua São João, 25\n"),
+			expectedRows: [][]string{{"name", "street"}, {"Silva", "Rua São João, 25"}},
+		},
+		"should read first sheet of xlsx": {
+			inputFormat:  infra.SpreadsheetXLSX,
+			inputContent: xlsxContent.Bytes(),
+			expectedRows: [][]string{{"name", "zipcode"}, {"Silva", "01035000"}},
+		},
+		"should throw error when xlsx is invalid": {
+			inputFormat:  infra.SpreadsheetXLSX,
+			inputContent: []byte("name,zipcode"),
+			expectedErr:  true,
+		},
+		"should throw error when format is unsupported": {
+			inputFormat:  "ods",
+			inputContent: []byte(strings.Repeat("a", 10)),
+			expectedErr:  true,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			rows, err := infra.ReadSpreadsheet(cs.inputFormat, bytes.NewReader(cs.inputContent))
+
+			// then
+			assert.Equal(t, cs.expectedErr, err != nil)
+			if !cs.expectedErr {
+				assert.Equal(t, cs.expectedRows, rows)
+			}
+		})
+	}
+}
+
+func Test_NewSpreadsheetWriter(t *testing.T) {
+	cases := map[string]struct {
+		inputFormat  string
+		inputRows    [][]interface{}
+		expectedRows [][]string
+		expectedErr  bool
+	}{
+		"should write csv": {
+			inputFormat:  infra.SpreadsheetCSV,
+			inputRows:    [][]interface{}{{"name", "quantity"}, {"Arroz, tipo 1", 1.5}, {nil, 2}},
+			expectedRows: [][]string{{"name", "quantity"}, {"Arroz, tipo 1", "1.5"}, {"", "2"}},
+		},
+		"should escape csv formulas": {
+			inputFormat:  infra.SpreadsheetCSV,
+			inputRows:    [][]interface{}{{"=1+1", "+55", "-2", "@SUM(A1)", "a=b"}, {-2.5, "", "Arroz", "\tx", nil}},
+			expectedRows: [][]string{{"'=1+1", "'+55", "'-2", "'@SUM(A1)", "a=b"}, {"-2.5", "", "Arroz", "'\tx", ""}},
+		},
+		"should write xlsx": {
+			inputFormat:  infra.SpreadsheetXLSX,
+			inputRows:    [][]interface{}{{"name", "quantity"}, {"Arroz", 1.5}},
+			expectedRows: [][]string{{"name", "quantity"}, {"Arroz", "1.5"}},
+		},
+		"should throw error when format is unsupported": {
+			inputFormat: "ods",
+			expectedErr: true,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			var buf bytes.Buffer
+
+			// when
+			writer, err := infra.NewSpreadsheetWriter(cs.inputFormat, &buf)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err != nil)
+			if cs.expectedErr {
+				return
+			}
+
+			for _, row := range cs.inputRows {
+				assert.NoError(t, writer.Write(row))
+			}
+			assert.NoError(t, writer.Close())
+
+			rows, err := infra.ReadSpreadsheet(cs.inputFormat, &buf)
+			assert.NoError(t, err)
+			assert.Equal(t, cs.expectedRows, rows)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package infra
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Tokenize splits value into unique lowercase words without accents,
+// so "Rua São João, 25" becomes [rua sao joao 25]
+func Tokenize(value string) []string {
+	normalized, _, err := transform.String(
+		transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), value)
+	if err != nil {
+		normalized = value
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(normalized), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := []string{}
+	seen := map[string]bool{}
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		tokens = append(tokens, word)
+	}
+
+	return tokens
+}
+
+// BlindTokens returns the blind index of every word of value, used to search words in encrypted columns
+func (impl *Cipher) BlindTokens(value string) []string {
+	tokens := Tokenize(value)
+	for i, token := range tokens {
+		tokens[i] = impl.BlindIndex(token)
+	}
+
+	return tokens
+}
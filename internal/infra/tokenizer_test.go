@@ -0,0 +1,37 @@
+package infra_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+)
+
+func Test_Tokenize(t *testing.T) {
+	cases := map[string]struct {
+		inputValue     string
+		expectedTokens []string
+	}{
+		"should split words without accents": {
+			inputValue:     "Rua São João, 25",
+			expectedTokens: []string{"rua", "sao", "joao", "25"},
+		},
+		"should remove repeated words": {
+			inputValue:     "Maria maria MARIA da Silva",
+			expectedTokens: []string{"maria", "da", "silva"},
+		},
+		"should return empty list": {
+			inputValue:     " - ",
+			expectedTokens: []string{},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			tokens := infra.Tokenize(cs.inputValue)
+
+			// then
+			assert.Equal(t, cs.expectedTokens, tokens)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/viniosilva/socialassistanceapi"
+
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+// TracingConfigure sets the global tracer provider and the W3C traceparent propagation.
+// The spans are sent to an OTLP collector at endpoint, printed on stdout or, without exporter,
+// only identify the logs. The function returned flushes the spans left on shutdown.
+func TracingConfigure(ctx context.Context, serviceName, exporter, endpoint string, insecure bool,
+	sampleRatio float64) (func(context.Context) error, error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	}
+
+	switch exporter {
+	case TracingExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{}
+		if endpoint != "" {
+			clientOpts = append(clientOpts, otlptracegrpc.WithEndpoint(endpoint))
+		}
+		if insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	case TracingExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	case "":
+	default:
+		return nil, fmt.Errorf("invalid tracing exporter %q", exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{},
+		propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span child of the one in ctx, which must be ended by the caller
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// TraceHook adds the trace_id and span_id of the span in the context of the entry,
+// as logged by logrus.WithContext(ctx), and marks the span failed on errors
+type TraceHook struct{}
+
+func (hook TraceHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (hook TraceHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	span := trace.SpanFromContext(entry.Context)
+	spanContext := span.SpanContext()
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	entry.Data["trace_id"] = spanContext.TraceID().String()
+	entry.Data["span_id"] = spanContext.SpanID().String()
+	if entry.Level <= logrus.ErrorLevel {
+		span.SetStatus(codes.Error, entry.Message)
+	}
+
+	return nil
+}
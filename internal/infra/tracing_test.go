@@ -0,0 +1,102 @@
+package infra_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_TracingConfigure(t *testing.T) {
+	cases := map[string]struct {
+		inputExporter string
+		expectedErr   bool
+	}{
+		"should configure without exporter": {inputExporter: ""},
+		"should configure stdout exporter":  {inputExporter: infra.TracingExporterStdout},
+		"should configure otlp exporter":    {inputExporter: infra.TracingExporterOTLP},
+		"should throw error when exporter is invalid": {
+			inputExporter: "zipkin",
+			expectedErr:   true,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+
+			// when
+			shutdown, err := infra.TracingConfigure(ctx, "socialassistanceapi", cs.inputExporter, "localhost:4317",
+				true, 1)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err != nil)
+			if !cs.expectedErr {
+				assert.Nil(t, shutdown(ctx))
+			}
+		})
+	}
+}
+
+func Test_TraceHook_Fire(t *testing.T) {
+	cases := map[string]struct {
+		inputLevel     logrus.Level
+		inputSpan      bool
+		expectedTrace  bool
+		expectedStatus string
+	}{
+		"should add trace of the span": {
+			inputLevel:     logrus.InfoLevel,
+			inputSpan:      true,
+			expectedTrace:  true,
+			expectedStatus: "Unset",
+		},
+		"should mark span failed on error": {
+			inputLevel:     logrus.ErrorLevel,
+			inputSpan:      true,
+			expectedTrace:  true,
+			expectedStatus: "Error",
+		},
+		"should not add trace without span": {
+			inputLevel: logrus.InfoLevel,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			ctx := context.Background()
+			if cs.inputSpan {
+				ctx, _ = provider.Tracer("test").Start(ctx, "span")
+			}
+
+			var out bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&out)
+			logger.SetFormatter(&logrus.JSONFormatter{})
+			logger.AddHook(infra.TraceHook{})
+
+			// when
+			logger.WithContext(ctx).Log(cs.inputLevel, "message")
+
+			// then
+			var entry map[string]interface{}
+			assert.Nil(t, json.Unmarshal(out.Bytes(), &entry))
+			assert.Equal(t, cs.expectedTrace, entry["trace_id"] != nil)
+			assert.Equal(t, cs.expectedTrace, entry["span_id"] != nil)
+			if cs.inputSpan {
+				span := recorder.Started()[0]
+				assert.Equal(t, entry["trace_id"], span.SpanContext().TraceID().String())
+				assert.Equal(t, cs.expectedStatus, span.Status().Code.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+type DashboardDistributions struct {
+	Donations int
+	Families  int
+	Quantity  float64
+}
+
+type DashboardResource struct {
+	ResourceID int
+	Name       string
+	Quantity   float64
+	Total      DistributionTotal
+}
+
+type Dashboard struct {
+	ActiveFamilies int
+	// PersonsServed are the active persons of the families that received donations this month
+	PersonsServed int
+	// Stock is the quantity in stock converted to each measurement
+	Stock     []DistributionTotal
+	ThisMonth DashboardDistributions
+	LastMonth DashboardDistributions
+	// TopResources are the most donated resources this month
+	TopResources []DashboardResource
+}
+
+// DashboardDay has what was created in a day
+type DashboardDay struct {
+	Date      time.Time
+	Families  int
+	Persons   int
+	Resources int
+	Donations int
+	Quantity  float64
+}
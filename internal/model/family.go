@@ -16,4 +16,7 @@ type Family struct {
 	Number       string
 	Complement   string
 	Zipcode      string
+	// Persons and Donations are only loaded when included
+	Persons   []Person
+	Donations []ResourceToFamily
 }
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type IdempotencyKey struct {
+	Key         string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	RequestHash string
+	Status      int
+	ContentType string
+	Body        []byte
+}
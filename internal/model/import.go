@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+type ImportStatus string
+
+const (
+	// ImportStatusValidated is a dry run without errors, nothing was saved
+	ImportStatusValidated ImportStatus = "validated"
+	// ImportStatusInvalid has row errors, nothing was saved
+	ImportStatusInvalid   ImportStatus = "invalid"
+	ImportStatusCompleted ImportStatus = "completed"
+	// ImportStatusFailed could not be saved, nothing was saved
+	ImportStatusFailed ImportStatus = "failed"
+)
+
+type ImportRowError struct {
+	Row     int
+	Field   string
+	Message string
+}
+
+type Import struct {
+	ID        int
+	CreatedAt time.Time
+	Filename  string
+	DryRun    bool
+	Status    ImportStatus
+	Rows      int
+	Families  int
+	Persons   int
+	Errors    []ImportRowError
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+const (
+	JobRunRunning   = "running"
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+)
+
+const (
+	JobTriggeredBySchedule = "schedule"
+	JobTriggeredByManual   = "manual"
+)
+
+// JobRun is one execution of a scheduled job by the replica holding its lease
+type JobRun struct {
+	ID          int
+	Job         string
+	TriggeredBy string
+	Owner       string
+	Status      string
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+	DurationMs  int64
+	Error       string
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+const (
+	NotificationChannelEmail    = "email"
+	NotificationChannelSMS      = "sms"
+	NotificationChannelWhatsApp = "whatsapp"
+)
+
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// PersonContact holds how a person is reached and the channels the person opted in to
+type PersonContact struct {
+	PersonID  int
+	UpdatedAt time.Time
+	Email     string
+	Phone     string
+	Channels  []string
+}
+
+// Notification is queued until its sender accepts it or it runs out of attempts
+type Notification struct {
+	ID            int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	PersonID      int
+	Channel       string
+	Template      string
+	Recipient     string
+	Subject       string
+	Body          string
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	Error         string
+	SentAt        *time.Time
+}
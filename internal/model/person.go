@@ -9,4 +9,5 @@ type Person struct {
 	DeletedAt *time.Time
 	FamilyID  int
 	Name      string
+	Document  string
 }
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+type FilterOperator string
+
+const (
+	FilterOperatorEq       FilterOperator = "eq"
+	FilterOperatorNe       FilterOperator = "ne"
+	FilterOperatorContains FilterOperator = "contains"
+	FilterOperatorIn       FilterOperator = "in"
+	FilterOperatorGt       FilterOperator = "gt"
+	FilterOperatorGte      FilterOperator = "gte"
+	FilterOperatorLt       FilterOperator = "lt"
+	FilterOperatorLte      FilterOperator = "lte"
+)
+
+type Filter struct {
+	Field    string
+	Operator FilterOperator
+	Value    string
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Cursor points to the row where a page starts, by the values of its sort fields
+type Cursor struct {
+	Sort     string        `json:"s"`
+	Values   []interface{} `json:"v"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(b, &cursor); err != nil || len(cursor.Values) == 0 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+type Query struct {
+	Filters []Filter
+	Sorts   []Sort
+	Limit   int
+	Cursor  *Cursor
+	// Total counts the rows matching the filters, which costs an extra query
+	Total bool
+	// Include lists the related resources loaded with the rows
+	Include []string
+}
+
+type Pagination struct {
+	PreviousCursor string
+	NextCursor     string
+	Total          *int
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type Receipt struct {
+	ID           int
+	CreatedAt    time.Time
+	Organization string
+	// Number is sequential by organization and kept when the receipt is printed again
+	Number     int
+	DonationID int
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+type DistributionGroup string
+
+const (
+	DistributionGroupResource     DistributionGroup = "resource"
+	DistributionGroupNeighborhood DistributionGroup = "neighborhood"
+	DistributionGroupCity         DistributionGroup = "city"
+	DistributionGroupMonth        DistributionGroup = "month"
+)
+
+type DistributionReport struct {
+	// Group is the resource name, neighborhood, city or month as 2006-01
+	Group      string
+	ResourceID int
+	Quantity   float64
+	Donations  int
+	Families   int
+	Totals     []DistributionTotal
+}
+
+// DistributionTotal is the quantity converted to the resource measurement, as the kilograms of rice
+type DistributionTotal struct {
+	Measurement string
+	Amount      float64
+}
+
+type DistributionPeriod struct {
+	From time.Time
+	To   time.Time
+}
@@ -0,0 +1,14 @@
+package model
+
+const (
+	SearchHitFamily = "family"
+	SearchHitPerson = "person"
+)
+
+// SearchHit is a family or person matching a search, scored by the number of searched words it matches
+type SearchHit struct {
+	Type   string
+	Score  int
+	Family *Family
+	Person *Person
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+const (
+	WebhookEventFamilyCreated   = "family.created"
+	WebhookEventDonationCreated = "donation.created"
+	WebhookEventResourceLow     = "resource.low_stock"
+	// WebhookEventReportDistributions is the distributions report of the last month
+	WebhookEventReportDistributions = "report.distributions"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type WebhookSubscription struct {
+	ID        int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	URL       string
+	Events    []string
+	Secret    string
+	Active    bool
+}
+
+// WebhookDelivery queues an event to a subscription until it is accepted or runs out of attempts
+type WebhookDelivery struct {
+	ID             int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SubscriptionID int
+	Event          string
+	Payload        string
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	// URL and Secret are loaded from the subscription when the delivery is claimed to be sent
+	URL    string
+	Secret string
+	// History is only loaded when listing the deliveries
+	History []WebhookAttempt
+}
+
+type WebhookAttempt struct {
+	ID         int
+	CreatedAt  time.Time
+	DeliveryID int
+	StatusCode int
+	Error      string
+	DurationMs int64
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/dashboard_repository_mock.go -package mock . DashboardRepository
+type DashboardRepository interface {
+	Summary(ctx context.Context, month time.Time, top int) (*model.Dashboard, error)
+	Days(ctx context.Context, from, to time.Time) ([]model.DashboardDay, error)
+}
+
+type DashboardRepositoryImpl struct {
+	DB infra.MySQL
+}
+
+// Summary computes the dashboard of the month starting at month, compared to the month before
+func (impl *DashboardRepositoryImpl) Summary(ctx context.Context, month time.Time, top int) (*model.Dashboard, error) {
+	defer observe(ctx, "dashboard", "summary")()
+
+	lastMonth := month.AddDate(0, -1, 0).Format("2006-01-02T15:04:05")
+	nextMonth := month.AddDate(0, 1, 0).Format("2006-01-02T15:04:05")
+	thisMonth := month.Format("2006-01-02T15:04:05")
+
+	data := &model.Dashboard{Stock: []model.DistributionTotal{}, TopResources: []model.DashboardResource{}}
+
+	err := impl.DB.DB.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(id) FROM families WHERE deleted_at IS NULL),
+			(SELECT COUNT(p.id)
+				FROM persons p
+				WHERE p.deleted_at IS NULL AND p.family_id IN (
+					SELECT family_id FROM resources_to_families WHERE created_at >= ? AND created_at < ?
+				))
+	`, thisMonth, nextMonth).Scan(&data.ActiveFamilies, &data.PersonsServed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, period := range []struct {
+		from, to string
+		res      *model.DashboardDistributions
+	}{
+		{thisMonth, nextMonth, &data.ThisMonth},
+		{lastMonth, thisMonth, &data.LastMonth},
+	} {
+		err := impl.DB.DB.QueryRowContext(ctx, `
+			SELECT COUNT(id), COUNT(DISTINCT family_id), COALESCE(SUM(quantity), 0)
+			FROM resources_to_families
+			WHERE created_at >= ? AND created_at < ?
+		`, period.from, period.to).Scan(&period.res.Donations, &period.res.Families, &period.res.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stock, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT measurement, SUM(quantity * amount)
+		FROM resources
+		GROUP BY measurement
+		ORDER BY measurement
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer stock.Close()
+
+	for stock.Next() {
+		var total model.DistributionTotal
+		if err := stock.Scan(&total.Measurement, &total.Amount); err != nil {
+			return nil, err
+		}
+		data.Stock = append(data.Stock, total)
+	}
+	if err := stock.Err(); err != nil {
+		return nil, err
+	}
+
+	resources, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT r.id, r.name, r.measurement, SUM(rf.quantity), SUM(rf.quantity * r.amount)
+		FROM resources_to_families rf
+		JOIN resources r ON r.id = rf.resource_id
+		WHERE rf.created_at >= ? AND rf.created_at < ?
+		GROUP BY r.id, r.name, r.measurement
+		ORDER BY SUM(rf.quantity) DESC, r.id
+		LIMIT ?
+	`, thisMonth, nextMonth, top)
+	if err != nil {
+		return nil, err
+	}
+	defer resources.Close()
+
+	for resources.Next() {
+		var resource model.DashboardResource
+		if err := resources.Scan(&resource.ResourceID, &resource.Name, &resource.Total.Measurement,
+			&resource.Quantity, &resource.Total.Amount); err != nil {
+			return nil, err
+		}
+		data.TopResources = append(data.TopResources, resource)
+	}
+
+	return data, resources.Err()
+}
+
+// Days counts what was created each day from from until before to in a single pass over the
+// created_at indexes. Days without anything are left out.
+func (impl *DashboardRepositoryImpl) Days(ctx context.Context, from, to time.Time) ([]model.DashboardDay, error) {
+	defer observe(ctx, "dashboard", "days")()
+
+	args := []interface{}{}
+	for i := 0; i < 4; i++ {
+		args = append(args, from.Format("2006-01-02T15:04:05"), to.Format("2006-01-02T15:04:05"))
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT day, SUM(families), SUM(persons), SUM(resources), SUM(donations), SUM(quantity)
+		FROM (
+			SELECT DATE(created_at) AS day, COUNT(id) AS families, 0 AS persons, 0 AS resources, 0 AS donations, 0 AS quantity
+			FROM families WHERE created_at >= ? AND created_at < ? GROUP BY day
+			UNION ALL
+			SELECT DATE(created_at), 0, COUNT(id), 0, 0, 0
+			FROM persons WHERE created_at >= ? AND created_at < ? GROUP BY DATE(created_at)
+			UNION ALL
+			SELECT DATE(created_at), 0, 0, COUNT(id), 0, 0
+			FROM resources WHERE created_at >= ? AND created_at < ? GROUP BY DATE(created_at)
+			UNION ALL
+			SELECT DATE(created_at), 0, 0, 0, COUNT(id), SUM(quantity)
+			FROM resources_to_families WHERE created_at >= ? AND created_at < ? GROUP BY DATE(created_at)
+		) AS days
+		GROUP BY day
+		ORDER BY day
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	data := []model.DashboardDay{}
+	for res.Next() {
+		var day model.DashboardDay
+		var date string
+
+		if err := res.Scan(&date, &day.Families, &day.Persons, &day.Resources, &day.Donations, &day.Quantity); err != nil {
+			return nil, err
+		}
+
+		t, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, err
+		}
+		day.Date = t
+
+		data = append(data, day)
+	}
+
+	return data, res.Err()
+}
@@ -4,17 +4,30 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/viniosilva/socialassistanceapi/internal/exception"
 	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
 )
 
 //go:generate mockgen -destination ../../mock/donate_resource_repository_mock.go -package mock . DonateResourceRepository
 type DonateResourceRepository interface {
 	Donate(ctx context.Context, resourceID, familyID int, quantity float64) error
 	Return(ctx context.Context, resourceID int) error
+	FindOneById(ctx context.Context, donationID int) (*model.ResourceToFamily, error)
+	FindAllByFamilyIDs(ctx context.Context, familyIDs []int) ([]model.ResourceToFamily, error)
+	FindEach(ctx context.Context, query model.Query, fn func(data model.ResourceToFamily) error) error
+}
+
+var donationQueryFields = map[string]queryField{
+	"id":          {Column: "id", Type: queryFieldNumber},
+	"created_at":  {Column: "created_at", Type: queryFieldDate},
+	"resource_id": {Column: "resource_id", Type: queryFieldNumber},
+	"family_id":   {Column: "family_id", Type: queryFieldNumber},
+	"quantity":    {Column: "quantity", Type: queryFieldNumber},
 }
 
 type DonateResourceRepositoryImpl struct {
@@ -22,6 +35,8 @@ type DonateResourceRepositoryImpl struct {
 }
 
 func (impl *DonateResourceRepositoryImpl) Donate(ctx context.Context, resourceID, familyID int, quantity float64) error {
+	defer observe(ctx, "donate_resource", "donate")()
+
 	tx, err := impl.DB.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
 		return err
@@ -52,13 +67,13 @@ func (impl *DonateResourceRepositoryImpl) Donate(ctx context.Context, resourceID
 		if err := tx.Rollback(); err != nil {
 			return err
 		}
-		return &exception.NotFoundException{Err: fmt.Errorf("resource %d not found", resourceID)}
+		return &exception.NotFoundException{Code: "resource.not_found", Err: fmt.Errorf("resource %d not found", resourceID)}
 	}
 	if dbQuantity-quantity < 0 {
 		if err := tx.Rollback(); err != nil {
 			return err
 		}
-		return &exception.NegativeException{Err: fmt.Errorf("resource %d quantity is %.1f", resourceID, dbQuantity)}
+		return &exception.NegativeException{Code: "resource.insufficient_stock", Err: fmt.Errorf("resource %d quantity is %.1f", resourceID, dbQuantity)}
 	}
 
 	_, err = tx.ExecContext(ctx, `
@@ -71,7 +86,7 @@ func (impl *DonateResourceRepositoryImpl) Donate(ctx context.Context, resourceID
 		}
 
 		if e, ok := err.(*mysql.MySQLError); ok && e.Number == 1452 {
-			return &exception.NotFoundException{Err: fmt.Errorf("family %d not found", familyID)}
+			return &exception.NotFoundException{Code: "family.not_found", Err: fmt.Errorf("family %d not found", familyID)}
 		}
 		return err
 	}
@@ -97,6 +112,8 @@ func (impl *DonateResourceRepositoryImpl) Donate(ctx context.Context, resourceID
 }
 
 func (impl *DonateResourceRepositoryImpl) Return(ctx context.Context, resourceID int) error {
+	defer observe(ctx, "donate_resource", "return")()
+
 	tx, err := impl.DB.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
 		return err
@@ -127,7 +144,7 @@ func (impl *DonateResourceRepositoryImpl) Return(ctx context.Context, resourceID
 		if err := tx.Rollback(); err != nil {
 			return err
 		}
-		return &exception.NotFoundException{Err: fmt.Errorf("resource %d not found", resourceID)}
+		return &exception.NotFoundException{Code: "resource.not_found", Err: fmt.Errorf("resource %d not found", resourceID)}
 	}
 
 	_, err = tx.ExecContext(ctx, "DELETE FROM resources_to_families WHERE resource_id = ?", resourceID)
@@ -157,3 +174,132 @@ func (impl *DonateResourceRepositoryImpl) Return(ctx context.Context, resourceID
 
 	return nil
 }
+
+func (impl *DonateResourceRepositoryImpl) FindOneById(ctx context.Context, donationID int) (*model.ResourceToFamily, error) {
+	defer observe(ctx, "donate_resource", "find_one_by_id")()
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT id,
+			created_at,
+			resource_id,
+			family_id,
+			quantity
+		FROM resources_to_families
+		WHERE id = ?
+		LIMIT 1
+	`, donationID)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var data *model.ResourceToFamily
+	for res.Next() {
+		data, err = impl.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if data == nil {
+		return nil, &exception.NotFoundException{Code: "donation.not_found", Err: fmt.Errorf("donation %d not found", donationID)}
+	}
+
+	return data, nil
+}
+
+func (impl *DonateResourceRepositoryImpl) FindAllByFamilyIDs(ctx context.Context, familyIDs []int) ([]model.ResourceToFamily, error) {
+	defer observe(ctx, "donate_resource", "find_all_by_family_ids")()
+
+	data := []model.ResourceToFamily{}
+	if len(familyIDs) == 0 {
+		return data, nil
+	}
+
+	args := []interface{}{}
+	for _, id := range familyIDs {
+		args = append(args, id)
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id,
+			created_at,
+			resource_id,
+			family_id,
+			quantity
+		FROM resources_to_families
+		WHERE family_id IN (%s)
+		ORDER BY id
+	`, placeholders(len(args))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		donation, err := impl.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, *donation)
+	}
+
+	return data, nil
+}
+
+// FindEach calls fn with each donation matching query as it is read, without loading all rows in memory
+func (impl *DonateResourceRepositoryImpl) FindEach(ctx context.Context, query model.Query, fn func(data model.ResourceToFamily) error) error {
+	defer observe(ctx, "donate_resource", "find_each")()
+
+	q, err := buildQuery(query, "resources_to_families", donationQueryFields, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id,
+			created_at,
+			resource_id,
+			family_id,
+			quantity
+		FROM resources_to_families
+		WHERE %s
+		ORDER BY %s
+		%s
+	`, q.WhereClause(), q.OrderByClause(), q.LimitClause()), q.Args...)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		donation, err := impl.Scan(res)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(*donation); err != nil {
+			return err
+		}
+	}
+
+	return res.Err()
+}
+
+func (impl *DonateResourceRepositoryImpl) Scan(res *sql.Rows) (*model.ResourceToFamily, error) {
+	var data = &model.ResourceToFamily{}
+	var createdAt string
+
+	if err := res.Scan(&data.ID, &createdAt, &data.ResourceID, &data.FamilyID, &data.Quantity); err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(createdAt, " ", "T", 1))
+	if err != nil {
+		return nil, err
+	}
+	data.CreatedAt = t
+
+	return data, nil
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/encryption_repository_mock.go -package mock . EncryptionRepository
+type EncryptionRepository interface {
+	RotateKeys(ctx context.Context) (int, error)
+}
+
+type EncryptionRepositoryImpl struct {
+	DB     infra.MySQL
+	Cipher *infra.Cipher
+}
+
+// RotateKeys rewraps every encrypted column with the active key and recomputes
+// the blind indexes and search tokens, returning the number of rewrapped rows
+func (impl *EncryptionRepositoryImpl) RotateKeys(ctx context.Context) (int, error) {
+	defer observe(ctx, "encryption", "rotate_keys")()
+
+	families, err := impl.rotateFamilies(ctx)
+	if err != nil {
+		return families, err
+	}
+
+	persons, err := impl.rotatePersons(ctx)
+	if err != nil {
+		return families + persons, err
+	}
+
+	total := families + persons
+	for _, table := range []struct {
+		name, key string
+		columns   []string
+	}{
+		{name: "webhook_subscriptions", key: "id", columns: []string{"secret"}},
+		{name: "person_contacts", key: "person_id", columns: []string{"email", "phone"}},
+		{name: "notifications", key: "id", columns: []string{"recipient", "body"}},
+	} {
+		rows, err := impl.rotateColumns(ctx, table.name, table.key, table.columns...)
+		total += rows
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func (impl *EncryptionRepositoryImpl) rotateFamilies(ctx context.Context) (int, error) {
+	type row struct {
+		id                               int
+		name, street, number, complement string
+		city, neighborhood, zipcode      string
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT id, name, street, number, complement, city, neighborhood, zipcode
+		FROM families
+	`)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := []row{}
+	for res.Next() {
+		var r row
+		if err := res.Scan(&r.id, &r.name, &r.street, &r.number, &r.complement,
+			&r.city, &r.neighborhood, &r.zipcode); err != nil {
+			res.Close()
+			return 0, err
+		}
+		rows = append(rows, r)
+	}
+	res.Close()
+
+	families := &FamilyRepositoryImpl{DB: impl.DB, Cipher: impl.Cipher}
+
+	total := 0
+	for _, r := range rows {
+		plaintext := model.Family{ID: r.id, Name: r.name, Street: r.street, Number: r.number,
+			Complement: r.complement, City: r.city, Neighborhood: r.neighborhood, Zipcode: r.zipcode}
+		if err := impl.Cipher.DecryptFields(&plaintext.Name, &plaintext.Street,
+			&plaintext.Number, &plaintext.Complement); err != nil {
+			return total, err
+		}
+		if err := families.IndexSearchTokens(ctx, impl.DB.DB, plaintext); err != nil {
+			return total, err
+		}
+
+		changed, err := impl.rewrap(&r.name, &r.street, &r.number, &r.complement)
+		if err != nil {
+			return total, err
+		}
+		if !changed {
+			continue
+		}
+
+		if _, err := impl.DB.DB.ExecContext(ctx, `
+			UPDATE families
+			SET name = ?, street = ?, number = ?, complement = ?
+			WHERE id = ?
+		`, r.name, r.street, r.number, r.complement, r.id); err != nil {
+			return total, err
+		}
+		total++
+	}
+
+	return total, nil
+}
+
+func (impl *EncryptionRepositoryImpl) rotatePersons(ctx context.Context) (int, error) {
+	type row struct {
+		id             int
+		name, document string
+		documentIndex  *string
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, "SELECT id, name, document, document_bidx FROM persons")
+	if err != nil {
+		return 0, err
+	}
+
+	rows := []row{}
+	for res.Next() {
+		var r row
+		if err := res.Scan(&r.id, &r.name, &r.document, &r.documentIndex); err != nil {
+			res.Close()
+			return 0, err
+		}
+		rows = append(rows, r)
+	}
+	res.Close()
+
+	persons := &PersonRepositoryImpl{DB: impl.DB, Cipher: impl.Cipher}
+
+	total := 0
+	for _, r := range rows {
+		changed, err := impl.rewrap(&r.name, &r.document)
+		if err != nil {
+			return total, err
+		}
+
+		plaintext := model.Person{ID: r.id, Name: r.name, Document: r.document}
+		if err := impl.Cipher.DecryptFields(&plaintext.Name, &plaintext.Document); err != nil {
+			return total, err
+		}
+		if err := persons.IndexSearchTokens(ctx, impl.DB.DB, plaintext); err != nil {
+			return total, err
+		}
+
+		var documentIndex *string
+		if plaintext.Document != "" {
+			index := persons.DocumentIndex(plaintext.Document)
+			documentIndex = &index
+		}
+		if documentIndex != nil && (r.documentIndex == nil || *r.documentIndex != *documentIndex) {
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+
+		if _, err := impl.DB.DB.ExecContext(ctx, `
+			UPDATE persons
+			SET name = ?, document = ?, document_bidx = ?
+			WHERE id = ?
+		`, r.name, r.document, documentIndex, r.id); err != nil {
+			return total, err
+		}
+		total++
+	}
+
+	return total, nil
+}
+
+// rotateColumns rewraps the encrypted columns of a table without blind indexes or search tokens
+func (impl *EncryptionRepositoryImpl) rotateColumns(ctx context.Context, table, key string, columns ...string) (int, error) {
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf("SELECT %s, %s FROM %s", key, strings.Join(columns, ", "), table))
+	if err != nil {
+		return 0, err
+	}
+
+	rows := [][]string{}
+	for res.Next() {
+		r := make([]string, len(columns)+1)
+		dest := make([]interface{}, len(r))
+		for i := range r {
+			dest[i] = &r[i]
+		}
+		if err := res.Scan(dest...); err != nil {
+			res.Close()
+			return 0, err
+		}
+		rows = append(rows, r)
+	}
+	res.Close()
+
+	sets := []string{}
+	for _, column := range columns {
+		sets = append(sets, column+" = ?")
+	}
+	update := fmt.Sprintf("UPDATE %s SET %s WHERE %s = ?", table, strings.Join(sets, ", "), key)
+
+	total := 0
+	for _, r := range rows {
+		fields := []*string{}
+		for i := range columns {
+			fields = append(fields, &r[i+1])
+		}
+
+		changed, err := impl.rewrap(fields...)
+		if err != nil {
+			return total, err
+		}
+		if !changed {
+			continue
+		}
+
+		args := []interface{}{}
+		for _, field := range fields {
+			args = append(args, *field)
+		}
+		if _, err := impl.DB.DB.ExecContext(ctx, update, append(args, r[0])...); err != nil {
+			return total, err
+		}
+		total++
+	}
+
+	return total, nil
+}
+
+func (impl *EncryptionRepositoryImpl) rewrap(fields ...*string) (bool, error) {
+	changed := false
+
+	for _, field := range fields {
+		value, ok, err := impl.Cipher.Rewrap(*field)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			*field = value
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
@@ -14,22 +14,85 @@ import (
 
 //go:generate mockgen -destination ../../mock/family_repository_mock.go -package mock . FamilyRepository
 type FamilyRepository interface {
-	FindAll(ctx context.Context, limit, offset int) ([]model.Family, error)
+	FindAll(ctx context.Context, query model.Query) ([]model.Family, model.Pagination, error)
+	FindEach(ctx context.Context, query model.Query, fn func(data model.Family) error) error
 	FindOneById(ctx context.Context, familyID int) (*model.Family, error)
 	Create(ctx context.Context, data model.Family) (*model.Family, error)
 	Update(ctx context.Context, data model.Family) error
 	Delete(ctx context.Context, data int) error
-	Count(ctx context.Context) (int, error)
+	Count(ctx context.Context, query model.Query) (int, error)
+}
+
+var familyQueryFields = map[string]queryField{
+	"id":           {Column: "id", Type: queryFieldNumber},
+	"created_at":   {Column: "created_at", Type: queryFieldDate},
+	"updated_at":   {Column: "updated_at", Type: queryFieldDate},
+	"name":         {Column: "name", Type: queryFieldEncrypted},
+	"country":      {Column: "country", Type: queryFieldText},
+	"state":        {Column: "state", Type: queryFieldText},
+	"city":         {Column: "city", Type: queryFieldText},
+	"neighborhood": {Column: "neighborhood", Type: queryFieldText},
+	"street":       {Column: "street", Type: queryFieldEncrypted},
+	"number":       {Column: "number", Type: queryFieldEncrypted},
+	"complement":   {Column: "complement", Type: queryFieldEncrypted},
+	"zipcode":      {Column: "zipcode", Type: queryFieldText},
+}
+
+func familyCursorValue(data model.Family, field string) interface{} {
+	switch field {
+	case "created_at":
+		return data.CreatedAt.Format("2006-01-02T15:04:05")
+	case "updated_at":
+		return data.UpdatedAt.Format("2006-01-02T15:04:05")
+	case "country":
+		return data.Country
+	case "state":
+		return data.State
+	case "city":
+		return data.City
+	case "neighborhood":
+		return data.Neighborhood
+	case "zipcode":
+		return data.Zipcode
+	}
+
+	return data.ID
 }
 
 type FamilyRepositoryImpl struct {
-	DB infra.MySQL
+	DB     infra.MySQL
+	Cipher *infra.Cipher
 }
 
-func (impl *FamilyRepositoryImpl) FindAll(ctx context.Context, limit, offset int) ([]model.Family, error) {
+func (impl *FamilyRepositoryImpl) FindAll(ctx context.Context, query model.Query) ([]model.Family, model.Pagination, error) {
+	defer observe(ctx, "family", "find_all")()
+
 	data := []model.Family{}
 
-	res, err := impl.DB.DB.Query(`
+	err := impl.FindEach(ctx, query, func(d model.Family) error {
+		data = append(data, d)
+		return nil
+	})
+	if err != nil {
+		return nil, model.Pagination{}, err
+	}
+
+	data, pagination := paginate(query, data, familyCursorValue)
+
+	return data, pagination, nil
+}
+
+// FindEach calls fn with each row matching query as it is read, without loading all rows in memory
+func (impl *FamilyRepositoryImpl) FindEach(ctx context.Context, query model.Query, fn func(d model.Family) error) error {
+	defer observe(ctx, "family", "find_each")()
+
+	q, err := buildQuery(query, "families", familyQueryFields, impl.Cipher)
+	if err != nil {
+		return err
+	}
+	q.Where = append([]string{"deleted_at IS NULL"}, q.Where...)
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
 		SELECT id,
 			created_at,
 			updated_at,
@@ -43,27 +106,32 @@ func (impl *FamilyRepositoryImpl) FindAll(ctx context.Context, limit, offset int
 			complement,
 			zipcode
 		FROM families
-		WHERE deleted_at IS NULL
-		LIMIT ?
-		OFFSET ?
-	`, limit, offset)
+		WHERE %s
+		ORDER BY %s
+		%s
+	`, q.WhereClause(), q.OrderByClause(), q.LimitClause()), q.Args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer res.Close()
 
 	for res.Next() {
 		d, err := impl.Scan(res)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		data = append(data, *d)
+		if err := fn(*d); err != nil {
+			return err
+		}
 	}
 
-	return data, nil
+	return res.Err()
 }
 
 func (impl *FamilyRepositoryImpl) FindOneById(ctx context.Context, familyID int) (*model.Family, error) {
+	defer observe(ctx, "family", "find_one_by_id")()
+
 	res, err := impl.DB.DB.QueryContext(ctx, `
 		SELECT id,
 			created_at,
@@ -94,21 +162,51 @@ func (impl *FamilyRepositoryImpl) FindOneById(ctx context.Context, familyID int)
 	}
 
 	if data == nil {
-		return nil, &exception.NotFoundException{Err: fmt.Errorf("family %d not found", familyID)}
+		return nil, &exception.NotFoundException{Code: "family.not_found", Err: fmt.Errorf("family %d not found", familyID)}
 	}
 
 	return data, nil
 }
 
 func (impl *FamilyRepositoryImpl) Create(ctx context.Context, data model.Family) (*model.Family, error) {
+	defer observe(ctx, "family", "create")()
+
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := impl.Insert(ctx, tx, data)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Insert creates the family and its search tokens with db, so it can be part of a bigger transaction
+func (impl *FamilyRepositoryImpl) Insert(ctx context.Context, db execer, data model.Family) (*model.Family, error) {
+	defer observe(ctx, "family", "insert")()
+
+	encrypted := data
+	if err := impl.Cipher.EncryptFields(&encrypted.Name, &encrypted.Street,
+		&encrypted.Number, &encrypted.Complement); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	nowMysql := now.Format("2006-01-02T15:04:05")
-	res, err := impl.DB.DB.ExecContext(ctx, `
+	res, err := db.ExecContext(ctx, `
 		INSERT INTO families (created_at, updated_at, name, country,
 			state, city, neighborhood, street, number, complement, zipcode)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, nowMysql, nowMysql, data.Name, data.Country, data.State, data.City,
-		data.Neighborhood, data.Street, data.Number, data.Complement, data.Zipcode)
+	`, nowMysql, nowMysql, encrypted.Name, encrypted.Country, encrypted.State, encrypted.City,
+		encrypted.Neighborhood, encrypted.Street, encrypted.Number, encrypted.Complement, encrypted.Zipcode)
 	if err != nil {
 		return nil, err
 	}
@@ -121,10 +219,21 @@ func (impl *FamilyRepositoryImpl) Create(ctx context.Context, data model.Family)
 	data.CreatedAt = now
 	data.UpdatedAt = now
 
+	if err := impl.IndexSearchTokens(ctx, db, data); err != nil {
+		return nil, err
+	}
+
 	return &data, nil
 }
 
 func (impl *FamilyRepositoryImpl) Update(ctx context.Context, data model.Family) error {
+	defer observe(ctx, "family", "update")()
+
+	plaintext := data
+	if err := impl.Cipher.EncryptFields(&data.Name, &data.Street, &data.Number, &data.Complement); err != nil {
+		return err
+	}
+
 	fields, values := impl.DB.BuildUpdateData(map[string]interface{}{
 		"name":         data.Name,
 		"country":      data.Country,
@@ -137,7 +246,7 @@ func (impl *FamilyRepositoryImpl) Update(ctx context.Context, data model.Family)
 		"zipcode":      data.Zipcode,
 	})
 	if len(fields) == 0 {
-		return &exception.EmptyModelException{Err: fmt.Errorf("empty family model")}
+		return &exception.EmptyModelException{Code: "family.empty_update", Err: fmt.Errorf("empty family model")}
 	}
 
 	query := fmt.Sprintf(`
@@ -151,23 +260,38 @@ func (impl *FamilyRepositoryImpl) Update(ctx context.Context, data model.Family)
 	values = append([]interface{}{now.Format("2006-01-02T15:04:05")}, values...)
 	values = append(values, data.ID)
 
-	res, err := impl.DB.DB.ExecContext(ctx, query, values...)
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	res, err := tx.ExecContext(ctx, query, values...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	rows, err := res.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 	if rows == 0 {
-		return &exception.NotFoundException{Err: fmt.Errorf("family %d not found", data.ID)}
+		tx.Rollback()
+		return &exception.NotFoundException{Code: "family.not_found", Err: fmt.Errorf("family %d not found", data.ID)}
 	}
 
-	return nil
+	if err := impl.IndexSearchTokens(ctx, tx, plaintext); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (impl *FamilyRepositoryImpl) Delete(ctx context.Context, familyID int) error {
+	defer observe(ctx, "family", "delete")()
+
 	_, err := impl.DB.DB.ExecContext(ctx, `
 		UPDATE families
 		SET deleted_at = NOW()
@@ -177,16 +301,27 @@ func (impl *FamilyRepositoryImpl) Delete(ctx context.Context, familyID int) erro
 	return err
 }
 
-func (impl *FamilyRepositoryImpl) Count(ctx context.Context) (int, error) {
+func (impl *FamilyRepositoryImpl) Count(ctx context.Context, query model.Query) (int, error) {
+	defer observe(ctx, "family", "count")()
+
 	total := 0
 
-	res, err := impl.DB.DB.QueryContext(ctx, `
+	query.Cursor = nil
+	q, err := buildQuery(query, "families", familyQueryFields, impl.Cipher)
+	if err != nil {
+		return total, err
+	}
+	q.Where = append([]string{"deleted_at IS NULL"}, q.Where...)
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
 		SELECT count(id) as total
 		FROM families
-	`)
+		WHERE %s
+	`, q.WhereClause()), q.Args...)
 	if err != nil {
 		return total, err
 	}
+	defer res.Close()
 
 	for res.Next() {
 		if err = res.Scan(&total); err != nil {
@@ -197,6 +332,30 @@ func (impl *FamilyRepositoryImpl) Count(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// IndexSearchTokens indexes the words of the non empty name and address fields of data
+func (impl *FamilyRepositoryImpl) IndexSearchTokens(ctx context.Context, db execer, data model.Family) error {
+	defer observe(ctx, "family", "index_search_tokens")()
+
+	for field, value := range map[string]string{
+		"name":         data.Name,
+		"city":         data.City,
+		"neighborhood": data.Neighborhood,
+		"street":       data.Street,
+		"number":       data.Number,
+		"complement":   data.Complement,
+		"zipcode":      data.Zipcode,
+	} {
+		if value == "" {
+			continue
+		}
+		if err := indexSearchTokens(ctx, db, impl.Cipher, "families", data.ID, field, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (impl *FamilyRepositoryImpl) Scan(res *sql.Rows) (*model.Family, error) {
 	var data = &model.Family{}
 	var createdAt, updatedAt string
@@ -207,6 +366,10 @@ func (impl *FamilyRepositoryImpl) Scan(res *sql.Rows) (*model.Family, error) {
 		return nil, err
 	}
 
+	if err := impl.Cipher.DecryptFields(&data.Name, &data.Street, &data.Number, &data.Complement); err != nil {
+		return nil, err
+	}
+
 	t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(createdAt, " ", "T", 1))
 	if err != nil {
 		return nil, err
@@ -2,13 +2,24 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/viniosilva/socialassistanceapi/internal/infra"
 )
 
+// healthQueues maps the outbound queues to their tables, whose pending rows wait to be sent
+var healthQueues = map[string]string{
+	"webhooks":      "webhook_deliveries",
+	"notifications": "notifications",
+}
+
 //go:generate mockgen -destination ../../mock/health_repository_mock.go -package mock . HealthRepository
 type HealthRepository interface {
 	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (int, bool, error)
+	CountOverdue(ctx context.Context, queue string, before time.Time) (int, error)
 }
 
 type HealthRepositoryImpl struct {
@@ -16,5 +27,49 @@ type HealthRepositoryImpl struct {
 }
 
 func (impl *HealthRepositoryImpl) Ping(ctx context.Context) error {
+	defer observe(ctx, "health", "ping")()
+
 	return impl.DB.DB.PingContext(ctx)
 }
+
+// MigrationVersion returns the version the database was migrated to and whether that migration
+// failed halfway, leaving it dirty
+func (impl *HealthRepositoryImpl) MigrationVersion(ctx context.Context) (int, bool, error) {
+	defer observe(ctx, "health", "migration_version")()
+
+	var version int
+	var dirty bool
+	err := impl.DB.DB.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).
+		Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
+
+// CountOverdue counts the pending messages of the queue, webhooks or notifications, that were due before
+func (impl *HealthRepositoryImpl) CountOverdue(ctx context.Context, queue string, before time.Time) (int, error) {
+	defer observe(ctx, "health", "count_overdue")()
+
+	table, ok := healthQueues[queue]
+	if !ok {
+		return 0, fmt.Errorf("queue %s not found", queue)
+	}
+
+	var total int
+	err := impl.DB.DB.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM %s
+		WHERE status = 'pending'
+			AND next_attempt_at <= ?
+	`, table), before.Format("2006-01-02T15:04:05")).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
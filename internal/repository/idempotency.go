@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/idempotency_repository_mock.go -package mock . IdempotencyRepository
+type IdempotencyRepository interface {
+	FindOneByKey(ctx context.Context, key string) (*model.IdempotencyKey, error)
+	Create(ctx context.Context, data model.IdempotencyKey) error
+	Update(ctx context.Context, data model.IdempotencyKey) error
+	Delete(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type IdempotencyRepositoryImpl struct {
+	DB infra.MySQL
+}
+
+func (impl *IdempotencyRepositoryImpl) FindOneByKey(ctx context.Context, key string) (*model.IdempotencyKey, error) {
+	defer observe(ctx, "idempotency", "find_one_by_key")()
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT idempotency_key,
+			created_at,
+			expires_at,
+			request_hash,
+			status,
+			content_type,
+			body
+		FROM idempotency_keys
+		WHERE idempotency_key = ?
+			AND expires_at > ?
+		LIMIT 1
+	`, key, time.Now().Format("2006-01-02T15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var data *model.IdempotencyKey
+	for res.Next() {
+		data, err = impl.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if data == nil {
+		return nil, &exception.NotFoundException{Code: "idempotency_key.not_found", Err: fmt.Errorf("idempotency key %s not found", key)}
+	}
+
+	return data, nil
+}
+
+func (impl *IdempotencyRepositoryImpl) Create(ctx context.Context, data model.IdempotencyKey) error {
+	defer observe(ctx, "idempotency", "create")()
+
+	nowMysql := data.CreatedAt.Format("2006-01-02T15:04:05")
+
+	// an expired key can be reused
+	if _, err := impl.DB.DB.ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key = ?
+			AND expires_at <= ?
+	`, data.Key, nowMysql); err != nil {
+		return err
+	}
+
+	_, err := impl.DB.DB.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (idempotency_key, created_at, expires_at, request_hash)
+		VALUES (?, ?, ?, ?)
+	`, data.Key, nowMysql, data.ExpiresAt.Format("2006-01-02T15:04:05"), data.RequestHash)
+	if err != nil {
+		if e, ok := err.(*mysql.MySQLError); ok && e.Number == 1062 {
+			return &exception.ConflictException{Code: "idempotency_key.exists", Err: fmt.Errorf("idempotency key %s already exists", data.Key)}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (impl *IdempotencyRepositoryImpl) Update(ctx context.Context, data model.IdempotencyKey) error {
+	defer observe(ctx, "idempotency", "update")()
+
+	res, err := impl.DB.DB.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET expires_at = ?,
+			status = ?,
+			content_type = ?,
+			body = ?
+		WHERE idempotency_key = ?
+	`, data.ExpiresAt.Format("2006-01-02T15:04:05"), data.Status, data.ContentType, data.Body, data.Key)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &exception.NotFoundException{Code: "idempotency_key.not_found", Err: fmt.Errorf("idempotency key %s not found", data.Key)}
+	}
+
+	return nil
+}
+
+func (impl *IdempotencyRepositoryImpl) Delete(ctx context.Context, key string) error {
+	defer observe(ctx, "idempotency", "delete")()
+
+	_, err := impl.DB.DB.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE idempotency_key = ?", key)
+
+	return err
+}
+
+// DeleteExpired purges the keys expired by now, which are no longer replayed
+func (impl *IdempotencyRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	defer observe(ctx, "idempotency", "delete_expired")()
+
+	res, err := impl.DB.DB.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= ?",
+		now.Format("2006-01-02T15:04:05"))
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+
+	return int(rows), err
+}
+
+func (impl *IdempotencyRepositoryImpl) Scan(res *sql.Rows) (*model.IdempotencyKey, error) {
+	var data = &model.IdempotencyKey{}
+	var createdAt, expiresAt string
+
+	if err := res.Scan(&data.Key, &createdAt, &expiresAt, &data.RequestHash, &data.Status,
+		&data.ContentType, &data.Body); err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(createdAt, " ", "T", 1))
+	if err != nil {
+		return nil, err
+	}
+	data.CreatedAt = t
+
+	t, err = time.Parse("2006-01-02T15:04:05", strings.Replace(expiresAt, " ", "T", 1))
+	if err != nil {
+		return nil, err
+	}
+	data.ExpiresAt = t
+
+	return data, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/import_repository_mock.go -package mock . ImportRepository
+type ImportRepository interface {
+	Create(ctx context.Context, data model.Import, families []model.Family) (*model.Import, error)
+	FindOneById(ctx context.Context, importID int) (*model.Import, error)
+}
+
+type ImportRepositoryImpl struct {
+	DB     infra.MySQL
+	Cipher *infra.Cipher
+}
+
+// Create saves the import together with its families and their persons, all or nothing, setting
+// the ids of the families
+func (impl *ImportRepositoryImpl) Create(ctx context.Context, data model.Import, families []model.Family) (*model.Import, error) {
+	defer observe(ctx, "import", "create")()
+
+	errors, err := json.Marshal(data.Errors)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	familyRepository := &FamilyRepositoryImpl{DB: impl.DB, Cipher: impl.Cipher}
+	personRepository := &PersonRepositoryImpl{DB: impl.DB, Cipher: impl.Cipher}
+
+	for i, family := range families {
+		f, err := familyRepository.Insert(ctx, tx, family)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		families[i] = *f
+
+		for _, person := range family.Persons {
+			person.FamilyID = f.ID
+			if _, err := personRepository.Insert(ctx, tx, person); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+	}
+
+	now := time.Now()
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO imports (created_at, filename, dry_run, status, total_rows, families, persons, errors)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, now.Format("2006-01-02T15:04:05"), data.Filename, data.DryRun, data.Status, data.Rows,
+		data.Families, data.Persons, string(errors))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	data.ID = int(id)
+	data.CreatedAt = now
+
+	return &data, nil
+}
+
+func (impl *ImportRepositoryImpl) FindOneById(ctx context.Context, importID int) (*model.Import, error) {
+	defer observe(ctx, "import", "find_one_by_id")()
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT id,
+			created_at,
+			filename,
+			dry_run,
+			status,
+			total_rows,
+			families,
+			persons,
+			errors
+		FROM imports
+		WHERE id = ?
+		LIMIT 1
+	`, importID)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var data *model.Import
+	for res.Next() {
+		data, err = impl.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if data == nil {
+		return nil, &exception.NotFoundException{Code: "import.not_found", Err: fmt.Errorf("import %d not found", importID)}
+	}
+
+	return data, nil
+}
+
+func (impl *ImportRepositoryImpl) Scan(res *sql.Rows) (*model.Import, error) {
+	var data = &model.Import{}
+	var createdAt, errors string
+
+	if err := res.Scan(&data.ID, &createdAt, &data.Filename, &data.DryRun, &data.Status, &data.Rows,
+		&data.Families, &data.Persons, &errors); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(errors), &data.Errors); err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(createdAt, " ", "T", 1))
+	if err != nil {
+		return nil, err
+	}
+	data.CreatedAt = t
+
+	return data, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/job_repository_mock.go -package mock . JobRepository
+type JobRepository interface {
+	Acquire(ctx context.Context, job, owner string, lease time.Duration) (bool, error)
+	Release(ctx context.Context, job, owner string) error
+	CreateRun(ctx context.Context, data model.JobRun) (*model.JobRun, error)
+	FinishRun(ctx context.Context, data model.JobRun) error
+	FindRuns(ctx context.Context, job string, limit int) ([]model.JobRun, error)
+	DeleteRuns(ctx context.Context, before time.Time) (int, error)
+}
+
+type JobRepositoryImpl struct {
+	DB infra.MySQL
+}
+
+// Acquire takes the lease of the job for owner when it is free or expired, so a single replica
+// runs the job at a time. The lease of a replica that died expires by itself.
+func (impl *JobRepositoryImpl) Acquire(ctx context.Context, job, owner string, lease time.Duration) (bool, error) {
+	defer observe(ctx, "job", "acquire")()
+
+	now := time.Now()
+
+	if _, err := impl.DB.DB.ExecContext(ctx, `
+		INSERT IGNORE INTO job_leases (job, owner, locked_until)
+		VALUES (?, '', ?)
+	`, job, now.Add(-time.Second).Format("2006-01-02T15:04:05")); err != nil {
+		return false, err
+	}
+
+	res, err := impl.DB.DB.ExecContext(ctx, `
+		UPDATE job_leases
+		SET owner = ?, locked_until = ?
+		WHERE job = ?
+			AND locked_until <= ?
+	`, owner, now.Add(lease).Format("2006-01-02T15:04:05"), job, now.Format("2006-01-02T15:04:05"))
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (impl *JobRepositoryImpl) Release(ctx context.Context, job, owner string) error {
+	defer observe(ctx, "job", "release")()
+
+	_, err := impl.DB.DB.ExecContext(ctx, `
+		UPDATE job_leases
+		SET locked_until = ?
+		WHERE job = ?
+			AND owner = ?
+	`, time.Now().Format("2006-01-02T15:04:05"), job, owner)
+
+	return err
+}
+
+func (impl *JobRepositoryImpl) CreateRun(ctx context.Context, data model.JobRun) (*model.JobRun, error) {
+	defer observe(ctx, "job", "create_run")()
+
+	res, err := impl.DB.DB.ExecContext(ctx, `
+		INSERT INTO job_runs (job, triggered_by, owner, status, started_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, data.Job, data.TriggeredBy, data.Owner, data.Status, data.StartedAt.Format("2006-01-02T15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	data.ID = int(id)
+
+	return &data, nil
+}
+
+// FinishRun saves the status, duration and error of the run
+func (impl *JobRepositoryImpl) FinishRun(ctx context.Context, data model.JobRun) error {
+	defer observe(ctx, "job", "finish_run")()
+
+	var finishedAt *string
+	if data.FinishedAt != nil {
+		value := data.FinishedAt.Format("2006-01-02T15:04:05")
+		finishedAt = &value
+	}
+
+	_, err := impl.DB.DB.ExecContext(ctx, `
+		UPDATE job_runs
+		SET status = ?, finished_at = ?, duration_ms = ?, error = ?
+		WHERE id = ?
+	`, data.Status, finishedAt, data.DurationMs, data.Error, data.ID)
+
+	return err
+}
+
+// FindRuns returns the last runs of the job, the most recent first
+func (impl *JobRepositoryImpl) FindRuns(ctx context.Context, job string, limit int) ([]model.JobRun, error) {
+	defer observe(ctx, "job", "find_runs")()
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT id,
+			job,
+			triggered_by,
+			owner,
+			status,
+			started_at,
+			finished_at,
+			duration_ms,
+			error
+		FROM job_runs
+		WHERE job = ?
+		ORDER BY id DESC
+		LIMIT ?
+	`, job, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	data := []model.JobRun{}
+	for res.Next() {
+		run, err := impl.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, *run)
+	}
+
+	return data, res.Err()
+}
+
+// DeleteRuns purges the history of runs started before the given time
+func (impl *JobRepositoryImpl) DeleteRuns(ctx context.Context, before time.Time) (int, error) {
+	defer observe(ctx, "job", "delete_runs")()
+
+	res, err := impl.DB.DB.ExecContext(ctx, `
+		DELETE FROM job_runs
+		WHERE started_at < ?
+	`, before.Format("2006-01-02T15:04:05"))
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+
+	return int(rows), err
+}
+
+func (impl *JobRepositoryImpl) Scan(res *sql.Rows) (*model.JobRun, error) {
+	var data = &model.JobRun{}
+	var startedAt string
+	var finishedAt *string
+
+	if err := res.Scan(&data.ID, &data.Job, &data.TriggeredBy, &data.Owner, &data.Status, &startedAt,
+		&finishedAt, &data.DurationMs, &data.Error); err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(startedAt, " ", "T", 1))
+	if err != nil {
+		return nil, err
+	}
+	data.StartedAt = t
+
+	if finishedAt != nil {
+		t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(*finishedAt, " ", "T", 1))
+		if err != nil {
+			return nil, err
+		}
+		data.FinishedAt = &t
+	}
+
+	return data, nil
+}
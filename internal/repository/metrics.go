@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+)
+
+// observe measures and logs the repository method until the returned func is called, as in
+// defer observe(ctx, "family", "find_all")()
+func observe(ctx context.Context, repository, method string) func() {
+	start := time.Now()
+
+	return func() {
+		duration := time.Since(start)
+		infra.RepositoryQueryDuration.WithLabelValues(repository, method).Observe(duration.Seconds())
+		infra.Logger(ctx).WithFields(logrus.Fields{
+			"path":        "internal.repository." + repository + "." + method,
+			"duration_ms": duration.Milliseconds(),
+		}).Debug()
+	}
+}
@@ -0,0 +1,444 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/notification_repository_mock.go -package mock . NotificationRepository
+type NotificationRepository interface {
+	FindContacts(ctx context.Context, personIDs []int) ([]model.PersonContact, error)
+	SaveContact(ctx context.Context, data model.PersonContact) error
+	FindAll(ctx context.Context, query model.Query) ([]model.Notification, model.Pagination, error)
+	Count(ctx context.Context, query model.Query) (int, error)
+	Create(ctx context.Context, data []model.Notification) ([]model.Notification, error)
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]model.Notification, error)
+	Save(ctx context.Context, data model.Notification) error
+	FindPersonsToRemind(ctx context.Context, template, reminder string, sentBefore time.Time) ([]int, error)
+}
+
+var notificationQueryFields = map[string]queryField{
+	"id":         {Column: "id", Type: queryFieldNumber},
+	"created_at": {Column: "created_at", Type: queryFieldDate},
+	"person_id":  {Column: "person_id", Type: queryFieldNumber},
+	"channel":    {Column: "channel", Type: queryFieldText},
+	"template":   {Column: "template", Type: queryFieldText},
+	"status":     {Column: "status", Type: queryFieldText},
+	"attempts":   {Column: "attempts", Type: queryFieldNumber},
+}
+
+func notificationCursorValue(notification model.Notification, field string) interface{} {
+	switch field {
+	case "created_at":
+		return notification.CreatedAt.Format("2006-01-02T15:04:05")
+	case "person_id":
+		return notification.PersonID
+	case "channel":
+		return notification.Channel
+	case "template":
+		return notification.Template
+	case "status":
+		return notification.Status
+	case "attempts":
+		return notification.Attempts
+	}
+
+	return notification.ID
+}
+
+type NotificationRepositoryImpl struct {
+	DB     infra.MySQL
+	Cipher *infra.Cipher
+}
+
+func (impl *NotificationRepositoryImpl) FindContacts(ctx context.Context, personIDs []int) ([]model.PersonContact, error) {
+	defer observe(ctx, "notification", "find_contacts")()
+
+	data := []model.PersonContact{}
+	if len(personIDs) == 0 {
+		return data, nil
+	}
+
+	args := []interface{}{}
+	for _, id := range personIDs {
+		args = append(args, id)
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT person_id,
+			updated_at,
+			email,
+			phone,
+			channels
+		FROM person_contacts
+		WHERE person_id IN (%s)
+		ORDER BY person_id
+	`, placeholders(len(args))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var contact model.PersonContact
+		var updatedAt, channels string
+
+		if err := res.Scan(&contact.PersonID, &updatedAt, &contact.Email, &contact.Phone, &channels); err != nil {
+			return nil, err
+		}
+		if err := impl.Cipher.DecryptFields(&contact.Email, &contact.Phone); err != nil {
+			return nil, err
+		}
+
+		contact.Channels = []string{}
+		if channels != "" {
+			contact.Channels = strings.Split(channels, ",")
+		}
+
+		t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(updatedAt, " ", "T", 1))
+		if err != nil {
+			return nil, err
+		}
+		contact.UpdatedAt = t
+
+		data = append(data, contact)
+	}
+
+	return data, res.Err()
+}
+
+// SaveContact creates or replaces the contact of the person
+func (impl *NotificationRepositoryImpl) SaveContact(ctx context.Context, data model.PersonContact) error {
+	defer observe(ctx, "notification", "save_contact")()
+
+	if err := impl.Cipher.EncryptFields(&data.Email, &data.Phone); err != nil {
+		return err
+	}
+
+	_, err := impl.DB.DB.ExecContext(ctx, `
+		INSERT INTO person_contacts (person_id, updated_at, email, phone, channels)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE updated_at = VALUES(updated_at),
+			email = VALUES(email),
+			phone = VALUES(phone),
+			channels = VALUES(channels)
+	`, data.PersonID, time.Now().Format("2006-01-02T15:04:05"), data.Email, data.Phone, strings.Join(data.Channels, ","))
+	if e, ok := err.(*mysql.MySQLError); ok && e.Number == 1452 {
+		return &exception.NotFoundException{Code: "person.not_found", Err: fmt.Errorf("person %d not found", data.PersonID)}
+	}
+
+	return err
+}
+
+func (impl *NotificationRepositoryImpl) FindAll(ctx context.Context, query model.Query) ([]model.Notification, model.Pagination, error) {
+	defer observe(ctx, "notification", "find_all")()
+
+	q, err := buildQuery(query, "notifications", notificationQueryFields, nil)
+	if err != nil {
+		return nil, model.Pagination{}, err
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id,
+			created_at,
+			updated_at,
+			person_id,
+			channel,
+			template,
+			recipient,
+			subject,
+			body,
+			status,
+			attempts,
+			next_attempt_at,
+			error,
+			sent_at
+		FROM notifications
+		WHERE %s
+		ORDER BY %s
+		%s
+	`, q.WhereClause(), q.OrderByClause(), q.LimitClause()), q.Args...)
+	if err != nil {
+		return nil, model.Pagination{}, err
+	}
+	defer res.Close()
+
+	data := []model.Notification{}
+	for res.Next() {
+		notification, err := impl.Scan(res)
+		if err != nil {
+			return nil, model.Pagination{}, err
+		}
+		data = append(data, *notification)
+	}
+	if err := res.Err(); err != nil {
+		return nil, model.Pagination{}, err
+	}
+
+	data, pagination := paginate(query, data, notificationCursorValue)
+
+	return data, pagination, nil
+}
+
+func (impl *NotificationRepositoryImpl) Count(ctx context.Context, query model.Query) (int, error) {
+	defer observe(ctx, "notification", "count")()
+
+	total := 0
+
+	query.Cursor = nil
+	q, err := buildQuery(query, "notifications", notificationQueryFields, nil)
+	if err != nil {
+		return total, err
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT count(id) as total
+		FROM notifications
+		WHERE %s
+	`, q.WhereClause()), q.Args...)
+	if err != nil {
+		return total, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		if err = res.Scan(&total); err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// Create queues the notifications, all or nothing
+func (impl *NotificationRepositoryImpl) Create(ctx context.Context, data []model.Notification) ([]model.Notification, error) {
+	defer observe(ctx, "notification", "create")()
+
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	nowMysql := now.Format("2006-01-02T15:04:05")
+
+	res := []model.Notification{}
+	for _, notification := range data {
+		recipient, body := notification.Recipient, notification.Body
+		if err := impl.Cipher.EncryptFields(&recipient, &body); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		r, err := tx.ExecContext(ctx, `
+			INSERT INTO notifications (created_at, updated_at, person_id, channel, template, recipient, subject, body,
+				status, attempts, next_attempt_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?)
+		`, nowMysql, nowMysql, notification.PersonID, notification.Channel, notification.Template, recipient,
+			notification.Subject, body, model.NotificationPending, nowMysql)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		id, err := r.LastInsertId()
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		notification.ID = int(id)
+		notification.CreatedAt = now
+		notification.UpdatedAt = now
+		notification.Status = model.NotificationPending
+		notification.NextAttemptAt = now
+		res = append(res, notification)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Claim takes up to limit pending notifications due now and postpones them by lease,
+// so other instances skip them while they are sent
+func (impl *NotificationRepositoryImpl) Claim(ctx context.Context, limit int, lease time.Duration) ([]model.Notification, error) {
+	defer observe(ctx, "notification", "claim")()
+
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	res, err := tx.QueryContext(ctx, `
+		SELECT id,
+			created_at,
+			updated_at,
+			person_id,
+			channel,
+			template,
+			recipient,
+			subject,
+			body,
+			status,
+			attempts,
+			next_attempt_at,
+			error,
+			sent_at
+		FROM notifications
+		WHERE status = ?
+			AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`, model.NotificationPending, now.Format("2006-01-02T15:04:05"), limit)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	data := []model.Notification{}
+	ids := []interface{}{}
+	for res.Next() {
+		notification, err := impl.Scan(res)
+		if err != nil {
+			res.Close()
+			tx.Rollback()
+			return nil, err
+		}
+		data = append(data, *notification)
+		ids = append(ids, notification.ID)
+	}
+	res.Close()
+
+	if len(ids) > 0 {
+		args := append([]interface{}{now.Add(lease).Format("2006-01-02T15:04:05")}, ids...)
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			UPDATE notifications
+			SET next_attempt_at = ?
+			WHERE id IN (%s)
+		`, placeholders(len(ids))), args...); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Save updates the status, attempts, next attempt, error and sent time of the notification
+func (impl *NotificationRepositoryImpl) Save(ctx context.Context, data model.Notification) error {
+	defer observe(ctx, "notification", "save")()
+
+	var sentAt *string
+	if data.SentAt != nil {
+		value := data.SentAt.Format("2006-01-02T15:04:05")
+		sentAt = &value
+	}
+
+	_, err := impl.DB.DB.ExecContext(ctx, `
+		UPDATE notifications
+		SET updated_at = ?, status = ?, attempts = ?, next_attempt_at = ?, error = ?, sent_at = ?
+		WHERE id = ?
+	`, time.Now().Format("2006-01-02T15:04:05"), data.Status, data.Attempts,
+		data.NextAttemptAt.Format("2006-01-02T15:04:05"), data.Error, sentAt, data.ID)
+
+	return err
+}
+
+// FindPersonsToRemind returns the persons sent the template before sentBefore whose family got no
+// donation since, and who were not sent the reminder since either
+func (impl *NotificationRepositoryImpl) FindPersonsToRemind(ctx context.Context, template, reminder string,
+	sentBefore time.Time) ([]int, error) {
+	defer observe(ctx, "notification", "find_persons_to_remind")()
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT DISTINCT n.person_id
+		FROM notifications n
+			JOIN persons p ON p.id = n.person_id
+		WHERE n.template = ?
+			AND n.status = ?
+			AND n.sent_at <= ?
+			AND p.deleted_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1
+				FROM resources_to_families d
+				WHERE d.family_id = p.family_id
+					AND d.created_at >= n.sent_at
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM notifications r
+				WHERE r.person_id = n.person_id
+					AND r.template = ?
+					AND r.created_at >= n.sent_at
+			)
+		ORDER BY n.person_id
+	`, template, model.NotificationSent, sentBefore.Format("2006-01-02T15:04:05"), reminder)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	data := []int{}
+	for res.Next() {
+		var personID int
+		if err := res.Scan(&personID); err != nil {
+			return nil, err
+		}
+		data = append(data, personID)
+	}
+
+	return data, res.Err()
+}
+
+func (impl *NotificationRepositoryImpl) Scan(res *sql.Rows) (*model.Notification, error) {
+	var data = &model.Notification{}
+	var createdAt, updatedAt, nextAttemptAt string
+	var sentAt *string
+
+	if err := res.Scan(&data.ID, &createdAt, &updatedAt, &data.PersonID, &data.Channel, &data.Template,
+		&data.Recipient, &data.Subject, &data.Body, &data.Status, &data.Attempts, &nextAttemptAt,
+		&data.Error, &sentAt); err != nil {
+		return nil, err
+	}
+
+	if err := impl.Cipher.DecryptFields(&data.Recipient, &data.Body); err != nil {
+		return nil, err
+	}
+
+	values := []string{createdAt, updatedAt, nextAttemptAt}
+	for i, t := range []*time.Time{&data.CreatedAt, &data.UpdatedAt, &data.NextAttemptAt} {
+		parsed, err := time.Parse("2006-01-02T15:04:05", strings.Replace(values[i], " ", "T", 1))
+		if err != nil {
+			return nil, err
+		}
+		*t = parsed
+	}
+
+	if sentAt != nil {
+		t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(*sentAt, " ", "T", 1))
+		if err != nil {
+			return nil, err
+		}
+		data.SentAt = &t
+	}
+
+	return data, nil
+}
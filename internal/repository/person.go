@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/viniosilva/socialassistanceapi/internal/exception"
 	"github.com/viniosilva/socialassistanceapi/internal/infra"
@@ -14,7 +15,10 @@ import (
 
 //go:generate mockgen -destination ../../mock/person_repository_mock.go -package mock . PersonRepository
 type PersonRepository interface {
-	FindAll(ctx context.Context) ([]model.Person, error)
+	FindAll(ctx context.Context, query model.Query) ([]model.Person, model.Pagination, error)
+	FindEach(ctx context.Context, query model.Query, fn func(data model.Person) error) error
+	Count(ctx context.Context, query model.Query) (int, error)
+	FindAllByFamilyIDs(ctx context.Context, familyIDs []int) ([]model.Person, error)
 	FindOneById(ctx context.Context, personID int) (*model.Person, error)
 	Create(ctx context.Context, data model.Person) (*model.Person, error)
 	Update(ctx context.Context, data model.Person) error
@@ -22,24 +26,128 @@ type PersonRepository interface {
 }
 
 type PersonRepositoryImpl struct {
-	DB infra.MySQL
+	DB     infra.MySQL
+	Cipher *infra.Cipher
 }
 
-func (impl *PersonRepositoryImpl) FindAll(ctx context.Context) ([]model.Person, error) {
+func (impl *PersonRepositoryImpl) FindAll(ctx context.Context, query model.Query) ([]model.Person, model.Pagination, error) {
+	defer observe(ctx, "person", "find_all")()
+
+	data := []model.Person{}
+
+	err := impl.FindEach(ctx, query, func(person model.Person) error {
+		data = append(data, person)
+		return nil
+	})
+	if err != nil {
+		return nil, model.Pagination{}, err
+	}
+
+	data, pagination := paginate(query, data, personCursorValue)
+
+	return data, pagination, nil
+}
+
+// FindEach calls fn with each row matching query as it is read, without loading all rows in memory
+func (impl *PersonRepositoryImpl) FindEach(ctx context.Context, query model.Query, fn func(person model.Person) error) error {
+	defer observe(ctx, "person", "find_each")()
+
+	q, err := buildQuery(query, "persons", impl.queryFields(), impl.Cipher)
+	if err != nil {
+		return err
+	}
+	q.Where = append([]string{"deleted_at IS NULL"}, q.Where...)
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id,
+			created_at,
+			updated_at,
+			family_id,
+			name,
+			document
+		FROM persons
+		WHERE %s
+		ORDER BY %s
+		%s
+	`, q.WhereClause(), q.OrderByClause(), q.LimitClause()), q.Args...)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		person, err := impl.Scan(res)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(*person); err != nil {
+			return err
+		}
+	}
+
+	return res.Err()
+}
+
+func (impl *PersonRepositoryImpl) Count(ctx context.Context, query model.Query) (int, error) {
+	defer observe(ctx, "person", "count")()
+
+	total := 0
+
+	query.Cursor = nil
+	q, err := buildQuery(query, "persons", impl.queryFields(), impl.Cipher)
+	if err != nil {
+		return total, err
+	}
+	q.Where = append([]string{"deleted_at IS NULL"}, q.Where...)
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT count(id) as total
+		FROM persons
+		WHERE %s
+	`, q.WhereClause()), q.Args...)
+	if err != nil {
+		return total, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		if err = res.Scan(&total); err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func (impl *PersonRepositoryImpl) FindAllByFamilyIDs(ctx context.Context, familyIDs []int) ([]model.Person, error) {
+	defer observe(ctx, "person", "find_all_by_family_ids")()
+
 	data := []model.Person{}
+	if len(familyIDs) == 0 {
+		return data, nil
+	}
+
+	args := []interface{}{}
+	for _, id := range familyIDs {
+		args = append(args, id)
+	}
 
-	res, err := impl.DB.DB.Query(`
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
 		SELECT id,
 			created_at,
 			updated_at,
 			family_id,
-			name
+			name,
+			document
 		FROM persons
-		WHERE deleted_at IS NULL
-	`)
+		WHERE deleted_at IS NULL AND family_id IN (%s)
+		ORDER BY id
+	`, placeholders(len(args))), args...)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Close()
 
 	for res.Next() {
 		person, err := impl.Scan(res)
@@ -54,12 +162,15 @@ func (impl *PersonRepositoryImpl) FindAll(ctx context.Context) ([]model.Person,
 }
 
 func (impl *PersonRepositoryImpl) FindOneById(ctx context.Context, personID int) (*model.Person, error) {
+	defer observe(ctx, "person", "find_one_by_id")()
+
 	res, err := impl.DB.DB.QueryContext(ctx, `
 		SELECT id,
 			created_at,
 			updated_at,
 			family_id,
-			name
+			name,
+			document
 		FROM persons
 		WHERE id = ?
 		LIMIT 1
@@ -77,19 +188,53 @@ func (impl *PersonRepositoryImpl) FindOneById(ctx context.Context, personID int)
 	}
 
 	if person == nil {
-		return nil, &exception.NotFoundException{Err: fmt.Errorf("person %d not found", personID)}
+		return nil, &exception.NotFoundException{Code: "person.not_found", Err: fmt.Errorf("person %d not found", personID)}
 	}
 
 	return person, nil
 }
 
 func (impl *PersonRepositoryImpl) Create(ctx context.Context, data model.Person) (*model.Person, error) {
+	defer observe(ctx, "person", "create")()
+
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := impl.Insert(ctx, tx, data)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Insert creates the person and its search tokens with db, so it can be part of a bigger transaction
+func (impl *PersonRepositoryImpl) Insert(ctx context.Context, db execer, data model.Person) (*model.Person, error) {
+	defer observe(ctx, "person", "insert")()
+
+	encrypted := data
+	if err := impl.Cipher.EncryptFields(&encrypted.Name, &encrypted.Document); err != nil {
+		return nil, err
+	}
+
+	var documentIndex interface{}
+	if data.Document != "" {
+		documentIndex = impl.DocumentIndex(data.Document)
+	}
+
 	now := time.Now()
 	nowMysql := now.Format("2006-01-02T15:04:05")
-	res, err := impl.DB.DB.ExecContext(ctx, `
-		INSERT INTO persons (created_at, updated_at, family_id, name)
-		VALUES (?, ?, ?, ?)
-	`, nowMysql, nowMysql, data.FamilyID, data.Name)
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO persons (created_at, updated_at, family_id, name, document, document_bidx)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, nowMysql, nowMysql, data.FamilyID, encrypted.Name, encrypted.Document, documentIndex)
 	if err != nil {
 		return nil, err
 	}
@@ -102,19 +247,38 @@ func (impl *PersonRepositoryImpl) Create(ctx context.Context, data model.Person)
 	data.CreatedAt = now
 	data.UpdatedAt = now
 
+	if err := impl.IndexSearchTokens(ctx, db, data); err != nil {
+		return nil, err
+	}
+
 	return &data, nil
 }
 
 func (impl *PersonRepositoryImpl) Update(ctx context.Context, data model.Person) error {
+	defer observe(ctx, "person", "update")()
+
+	plaintext := data
+
+	documentIndex := ""
+	if data.Document != "" {
+		documentIndex = impl.DocumentIndex(data.Document)
+	}
+
+	if err := impl.Cipher.EncryptFields(&data.Name, &data.Document); err != nil {
+		return err
+	}
+
 	fields, values := impl.DB.BuildUpdateData(map[string]interface{}{
-		"name": data.Name,
+		"name":          data.Name,
+		"document":      data.Document,
+		"document_bidx": documentIndex,
 	})
 	if len(fields) == 0 {
-		return &exception.EmptyModelException{Err: fmt.Errorf("empty person model")}
+		return &exception.EmptyModelException{Code: "person.empty_update", Err: fmt.Errorf("empty person model")}
 	}
 
 	if data.FamilyID > 0 {
-		fields = append(fields, "family_id")
+		fields = append(fields, "family_id = ?")
 		values = append(values, data.FamilyID)
 	}
 
@@ -128,24 +292,39 @@ func (impl *PersonRepositoryImpl) Update(ctx context.Context, data model.Person)
 	values = append([]interface{}{now.Format("2006-01-02T15:04:05")}, values...)
 	values = append(values, data.ID)
 
-	res, err := impl.DB.DB.ExecContext(ctx, query, values...)
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	res, err := tx.ExecContext(ctx, query, values...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	rows, err := res.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
 	if rows == 0 {
-		return &exception.NotFoundException{Err: fmt.Errorf("person %d not found", data.ID)}
+		tx.Rollback()
+		return &exception.NotFoundException{Code: "person.not_found", Err: fmt.Errorf("person %d not found", data.ID)}
+	}
+
+	if err := impl.IndexSearchTokens(ctx, tx, plaintext); err != nil {
+		tx.Rollback()
+		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (impl *PersonRepositoryImpl) Delete(ctx context.Context, personID int) error {
+	defer observe(ctx, "person", "delete")()
+
 	_, err := impl.DB.DB.ExecContext(ctx, `
 		UPDATE persons
 		SET deleted_at = NOW()
@@ -155,11 +334,50 @@ func (impl *PersonRepositoryImpl) Delete(ctx context.Context, personID int) erro
 	return err
 }
 
+// IndexSearchTokens indexes the words of the person name when it is not empty
+func (impl *PersonRepositoryImpl) IndexSearchTokens(ctx context.Context, db execer, data model.Person) error {
+	defer observe(ctx, "person", "index_search_tokens")()
+
+	if data.Name == "" {
+		return nil
+	}
+
+	return indexSearchTokens(ctx, db, impl.Cipher, "persons", data.ID, "name", data.Name)
+}
+
+func (impl *PersonRepositoryImpl) queryFields() map[string]queryField {
+	return map[string]queryField{
+		"id":         {Column: "id", Type: queryFieldNumber},
+		"created_at": {Column: "created_at", Type: queryFieldDate},
+		"updated_at": {Column: "updated_at", Type: queryFieldDate},
+		"family_id":  {Column: "family_id", Type: queryFieldNumber},
+		"name":       {Column: "name", Type: queryFieldEncrypted},
+		"document":   {Column: "document_bidx", Type: queryFieldBlindIndex, BlindIndex: impl.DocumentIndex},
+	}
+}
+
+func personCursorValue(person model.Person, field string) interface{} {
+	switch field {
+	case "created_at":
+		return person.CreatedAt.Format("2006-01-02T15:04:05")
+	case "updated_at":
+		return person.UpdatedAt.Format("2006-01-02T15:04:05")
+	case "family_id":
+		return person.FamilyID
+	}
+
+	return person.ID
+}
+
 func (impl *PersonRepositoryImpl) Scan(res *sql.Rows) (*model.Person, error) {
 	var person = &model.Person{}
 	var createdAt, updatedAt string
 
-	if err := res.Scan(&person.ID, &createdAt, &updatedAt, &person.FamilyID, &person.Name); err != nil {
+	if err := res.Scan(&person.ID, &createdAt, &updatedAt, &person.FamilyID, &person.Name, &person.Document); err != nil {
+		return nil, err
+	}
+
+	if err := impl.Cipher.DecryptFields(&person.Name, &person.Document); err != nil {
 		return nil, err
 	}
 
@@ -177,3 +395,15 @@ func (impl *PersonRepositoryImpl) Scan(res *sql.Rows) (*model.Person, error) {
 
 	return person, nil
 }
+
+// DocumentIndex returns the blind index of a document, ignoring punctuation such as in "123.456.789-00"
+func (impl *PersonRepositoryImpl) DocumentIndex(document string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, document)
+
+	return impl.Cipher.BlindIndex(digits)
+}
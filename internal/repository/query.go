@@ -0,0 +1,373 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+type queryFieldType int
+
+const (
+	queryFieldText queryFieldType = iota
+	queryFieldNumber
+	queryFieldDate
+	// encrypted text searched by the blind index of its words
+	queryFieldEncrypted
+	// encrypted value searched by its blind index column
+	queryFieldBlindIndex
+)
+
+var queryFieldOperators = map[queryFieldType][]model.FilterOperator{
+	queryFieldText: {model.FilterOperatorEq, model.FilterOperatorNe, model.FilterOperatorContains,
+		model.FilterOperatorIn},
+	queryFieldNumber: {model.FilterOperatorEq, model.FilterOperatorNe, model.FilterOperatorIn,
+		model.FilterOperatorGt, model.FilterOperatorGte, model.FilterOperatorLt, model.FilterOperatorLte},
+	queryFieldDate: {model.FilterOperatorEq, model.FilterOperatorGt, model.FilterOperatorGte,
+		model.FilterOperatorLt, model.FilterOperatorLte},
+	queryFieldEncrypted:  {model.FilterOperatorContains},
+	queryFieldBlindIndex: {model.FilterOperatorEq},
+}
+
+var queryOperatorsSQL = map[model.FilterOperator]string{
+	model.FilterOperatorEq:  "=",
+	model.FilterOperatorNe:  "<>",
+	model.FilterOperatorGt:  ">",
+	model.FilterOperatorGte: ">=",
+	model.FilterOperatorLt:  "<",
+	model.FilterOperatorLte: "<=",
+}
+
+type queryField struct {
+	Column string
+	Type   queryFieldType
+	// BlindIndex hashes the value before comparing it to a queryFieldBlindIndex column
+	BlindIndex func(value string) string
+}
+
+func (f queryField) sortable() bool {
+	return f.Type != queryFieldEncrypted && f.Type != queryFieldBlindIndex
+}
+
+// sqlQuery is a whitelisted model.Query translated to parameterized SQL
+type sqlQuery struct {
+	Where   []string
+	Args    []interface{}
+	OrderBy []string
+	Limit   int
+}
+
+func (q sqlQuery) WhereClause() string {
+	if len(q.Where) == 0 {
+		return "1 = 1"
+	}
+
+	return strings.Join(q.Where, " AND ")
+}
+
+func (q sqlQuery) OrderByClause() string {
+	return strings.Join(q.OrderBy, ", ")
+}
+
+// LimitClause fetches one row beyond the page, which tells whether there is a next page
+func (q sqlQuery) LimitClause() string {
+	if q.Limit <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("LIMIT %d", q.Limit+1)
+}
+
+// buildQuery validates query against the fields allowed for entity and translates it to SQL
+func buildQuery(query model.Query, entity string, fields map[string]queryField, cipher *infra.Cipher) (sqlQuery, error) {
+	res := sqlQuery{}
+
+	for _, filter := range query.Filters {
+		field, ok := fields[filter.Field]
+		if !ok {
+			return res, &exception.InvalidQueryException{Err: fmt.Errorf("filter by %s is not allowed", filter.Field)}
+		}
+		if !allowsOperator(field.Type, filter.Operator) {
+			return res, &exception.InvalidQueryException{
+				Err: fmt.Errorf("operator %s is not allowed for %s", filter.Operator, filter.Field),
+			}
+		}
+
+		condition, args, err := buildCondition(filter, entity, field, cipher)
+		if err != nil {
+			return res, err
+		}
+
+		res.Where = append(res.Where, condition)
+		res.Args = append(res.Args, args...)
+	}
+
+	sorts := keysetSorts(query.Sorts)
+	backward := query.Cursor != nil && query.Cursor.Backward
+
+	columns := []string{}
+	for _, sort := range sorts {
+		field, ok := fields[sort.Field]
+		if !ok || !field.sortable() {
+			return res, &exception.InvalidQueryException{Err: fmt.Errorf("sort by %s is not allowed", sort.Field)}
+		}
+		columns = append(columns, field.Column)
+
+		direction := "ASC"
+		if sort.Desc != backward {
+			direction = "DESC"
+		}
+		res.OrderBy = append(res.OrderBy, field.Column+" "+direction)
+	}
+
+	if query.Cursor != nil {
+		condition, args, err := buildKeyset(*query.Cursor, sorts, columns, fields)
+		if err != nil {
+			return res, err
+		}
+
+		res.Where = append(res.Where, condition)
+		res.Args = append(res.Args, args...)
+	}
+	res.Limit = query.Limit
+
+	return res, nil
+}
+
+// keysetSorts returns the sorts ending by id, so every row has a unique position.
+// Rows are sorted by creation when no sort is given.
+func keysetSorts(sorts []model.Sort) []model.Sort {
+	res := []model.Sort{}
+	for _, sort := range sorts {
+		if sort.Field == "id" {
+			return append(res, sort)
+		}
+		res = append(res, sort)
+	}
+
+	if len(res) == 0 {
+		res = append(res, model.Sort{Field: "created_at"})
+	}
+
+	return append(res, model.Sort{Field: "id"})
+}
+
+func sortSignature(sorts []model.Sort) string {
+	fields := []string{}
+	for _, sort := range sorts {
+		if sort.Desc {
+			fields = append(fields, "-"+sort.Field)
+		} else {
+			fields = append(fields, sort.Field)
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// buildKeyset selects the rows after the cursor, or before it when the cursor is backward, as
+// (a > ?) OR (a = ? AND b > ?) OR ... for the sort fields
+func buildKeyset(cursor model.Cursor, sorts []model.Sort, columns []string, fields map[string]queryField) (string, []interface{}, error) {
+	if cursor.Sort != sortSignature(sorts) || len(cursor.Values) != len(sorts) {
+		return "", nil, &exception.InvalidQueryException{Err: fmt.Errorf("cursor does not match the query sort")}
+	}
+
+	values := []interface{}{}
+	for i, sort := range sorts {
+		value, err := parseQueryValue(sort.Field, fields[sort.Field].Type, fmt.Sprint(cursor.Values[i]))
+		if err != nil {
+			return "", nil, &exception.InvalidQueryException{Err: fmt.Errorf("invalid cursor")}
+		}
+		values = append(values, value)
+	}
+
+	conditions := []string{}
+	args := []interface{}{}
+	for i, sort := range sorts {
+		parts := []string{}
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[j]+" = ?")
+			args = append(args, values[j])
+		}
+
+		operator := ">"
+		if sort.Desc != cursor.Backward {
+			operator = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s ?", columns[i], operator))
+		args = append(args, values[i])
+
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args, nil
+}
+
+// paginate drops the extra row fetched by the limit clause, restores the order of
+// backward pages and points the cursors to the first and last rows of the page
+func paginate[T any](query model.Query, rows []T, value func(row T, field string) interface{}) ([]T, model.Pagination) {
+	res := model.Pagination{}
+	if query.Limit <= 0 {
+		return rows, res
+	}
+
+	more := len(rows) > query.Limit
+	if more {
+		rows = rows[:query.Limit]
+	}
+
+	backward := query.Cursor != nil && query.Cursor.Backward
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, res
+	}
+
+	sorts := keysetSorts(query.Sorts)
+	cursor := func(row T, backward bool) string {
+		values := []interface{}{}
+		for _, sort := range sorts {
+			values = append(values, value(row, sort.Field))
+		}
+
+		return model.Cursor{Sort: sortSignature(sorts), Values: values, Backward: backward}.Encode()
+	}
+
+	if (backward && more) || (!backward && query.Cursor != nil) {
+		res.PreviousCursor = cursor(rows[0], true)
+	}
+	if backward || more {
+		res.NextCursor = cursor(rows[len(rows)-1], false)
+	}
+
+	return rows, res
+}
+
+func allowsOperator(fieldType queryFieldType, operator model.FilterOperator) bool {
+	for _, op := range queryFieldOperators[fieldType] {
+		if op == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+func buildCondition(filter model.Filter, entity string, field queryField, cipher *infra.Cipher) (string, []interface{}, error) {
+	switch field.Type {
+	case queryFieldEncrypted:
+		tokens := cipher.BlindTokens(filter.Value)
+		if len(tokens) == 0 {
+			return "", nil, &exception.InvalidQueryException{Err: fmt.Errorf("invalid value for %s", filter.Field)}
+		}
+
+		conditions := []string{}
+		args := []interface{}{}
+		for _, token := range tokens {
+			conditions = append(conditions, `id IN (
+				SELECT entity_id FROM search_tokens WHERE entity = ? AND field = ? AND token = ?
+			)`)
+			args = append(args, entity, filter.Field, token)
+		}
+
+		return strings.Join(conditions, " AND "), args, nil
+
+	case queryFieldBlindIndex:
+		return field.Column + " = ?", []interface{}{field.BlindIndex(filter.Value)}, nil
+	}
+
+	if filter.Operator == model.FilterOperatorContains {
+		value := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.Value)
+		return field.Column + " LIKE ?", []interface{}{"%" + value + "%"}, nil
+	}
+
+	if filter.Operator == model.FilterOperatorIn {
+		values := strings.Split(filter.Value, ",")
+		args := []interface{}{}
+		for _, v := range values {
+			arg, err := parseQueryValue(filter.Field, field.Type, strings.TrimSpace(v))
+			if err != nil {
+				return "", nil, err
+			}
+			args = append(args, arg)
+		}
+
+		return fmt.Sprintf("%s IN (%s)", field.Column, placeholders(len(args))), args, nil
+	}
+
+	arg, err := parseQueryValue(filter.Field, field.Type, filter.Value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("%s %s ?", field.Column, queryOperatorsSQL[filter.Operator]), []interface{}{arg}, nil
+}
+
+func parseQueryValue(name string, fieldType queryFieldType, value string) (interface{}, error) {
+	switch fieldType {
+	case queryFieldNumber:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, &exception.InvalidQueryException{Err: fmt.Errorf("%s must be a number", name)}
+		}
+		return v, nil
+
+	case queryFieldDate:
+		for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.Format("2006-01-02T15:04:05"), nil
+			}
+		}
+		return nil, &exception.InvalidQueryException{Err: fmt.Errorf("%s must be a date as 2006-01-02", name)}
+	}
+
+	return value, nil
+}
+
+// placeholders returns n comma separated parameters for an IN clause
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// indexSearchTokens replaces the blind indexes of the words of an encrypted field
+func indexSearchTokens(ctx context.Context, db execer, cipher *infra.Cipher, entity string, entityID int, field, value string) error {
+	if _, err := db.ExecContext(ctx, `
+		DELETE FROM search_tokens
+		WHERE entity = ? AND entity_id = ? AND field = ?
+	`, entity, entityID, field); err != nil {
+		return err
+	}
+
+	tokens := cipher.BlindTokens(value)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	placeholders := []string{}
+	args := []interface{}{}
+	for _, token := range tokens {
+		placeholders = append(placeholders, "(?, ?, ?, ?)")
+		args = append(args, entity, entityID, field, token)
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO search_tokens (entity, entity_id, field, token)
+		VALUES %s
+	`, strings.Join(placeholders, ", ")), args...)
+
+	return err
+}
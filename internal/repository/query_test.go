@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+func Test_Query_BuildQuery(t *testing.T) {
+	cases := map[string]struct {
+		inputQuery      model.Query
+		expectedWhere   string
+		expectedArgs    []interface{}
+		expectedOrderBy string
+		expectedErr     error
+	}{
+		"should build conditions and order": {
+			inputQuery: model.Query{
+				Filters: []model.Filter{
+					{Field: "city", Operator: model.FilterOperatorEq, Value: "São Paulo"},
+					{Field: "neighborhood", Operator: model.FilterOperatorContains, Value: "50%"},
+					{Field: "id", Operator: model.FilterOperatorIn, Value: "1, 2"},
+					{Field: "created_at", Operator: model.FilterOperatorGte, Value: "2000-01-01"},
+				},
+				Sorts: []model.Sort{{Field: "created_at", Desc: true}},
+			},
+			expectedWhere:   "city = ? AND neighborhood LIKE ? AND id IN (?, ?) AND created_at >= ?",
+			expectedArgs:    []interface{}{"São Paulo", `%50\%%`, 1.0, 2.0, "2000-01-01T00:00:00"},
+			expectedOrderBy: "created_at DESC, id ASC",
+		},
+		"should search encrypted field by word tokens": {
+			inputQuery: model.Query{
+				Filters: []model.Filter{{Field: "name", Operator: model.FilterOperatorContains, Value: "Silva"}},
+			},
+			expectedWhere: `id IN (
+				SELECT entity_id FROM search_tokens WHERE entity = ? AND field = ? AND token = ?
+			)`,
+			expectedArgs:    []interface{}{"families", "name", (*infra.Cipher)(nil).BlindIndex("silva")},
+			expectedOrderBy: "created_at ASC, id ASC",
+		},
+		"should select rows after cursor": {
+			inputQuery: model.Query{
+				Sorts:  []model.Sort{{Field: "city", Desc: true}},
+				Cursor: &model.Cursor{Sort: "-city,id", Values: []interface{}{"Santos", 10.0}},
+			},
+			expectedWhere:   "((city < ?) OR (city = ? AND id > ?))",
+			expectedArgs:    []interface{}{"Santos", "Santos", 10.0},
+			expectedOrderBy: "city DESC, id ASC",
+		},
+		"should select rows before backward cursor in reverse order": {
+			inputQuery: model.Query{
+				Cursor: &model.Cursor{Sort: "created_at,id", Values: []interface{}{"2000-01-01T12:03:00", 10.0}, Backward: true},
+			},
+			expectedWhere:   "((created_at < ?) OR (created_at = ? AND id < ?))",
+			expectedArgs:    []interface{}{"2000-01-01T12:03:00", "2000-01-01T12:03:00", 10.0},
+			expectedOrderBy: "created_at DESC, id DESC",
+		},
+		"should throw invalid query exception when cursor does not match sort": {
+			inputQuery: model.Query{
+				Sorts:  []model.Sort{{Field: "city"}},
+				Cursor: &model.Cursor{Sort: "created_at,id", Values: []interface{}{"2000-01-01T12:03:00", 10.0}},
+			},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("cursor does not match the query sort")},
+		},
+		"should throw invalid query exception when field is not allowed": {
+			inputQuery:  model.Query{Filters: []model.Filter{{Field: "deleted_at", Operator: model.FilterOperatorEq}}},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("filter by deleted_at is not allowed")},
+		},
+		"should throw invalid query exception when operator is not allowed": {
+			inputQuery:  model.Query{Filters: []model.Filter{{Field: "name", Operator: model.FilterOperatorEq, Value: "Silva"}}},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("operator eq is not allowed for name")},
+		},
+		"should throw invalid query exception when value is invalid": {
+			inputQuery:  model.Query{Filters: []model.Filter{{Field: "id", Operator: model.FilterOperatorGt, Value: "one"}}},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("id must be a number")},
+		},
+		"should throw invalid query exception when sort is not allowed": {
+			inputQuery:  model.Query{Sorts: []model.Sort{{Field: "street"}}},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("sort by street is not allowed")},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			res, err := buildQuery(cs.inputQuery, "families", familyQueryFields, nil)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErr == nil {
+				assert.Equal(t, cs.expectedWhere, res.WhereClause())
+				assert.Equal(t, cs.expectedArgs, res.Args)
+				assert.Equal(t, cs.expectedOrderBy, res.OrderByClause())
+			}
+		})
+	}
+}
+
+func Test_Query_Paginate(t *testing.T) {
+	value := func(row int, field string) interface{} { return row }
+	cursor := func(row int, backward bool) string {
+		return model.Cursor{Sort: "created_at,id", Values: []interface{}{row, row}, Backward: backward}.Encode()
+	}
+
+	cases := map[string]struct {
+		inputQuery         model.Query
+		inputRows          []int
+		expectedRows       []int
+		expectedPagination model.Pagination
+	}{
+		"should return next cursor when there are more rows": {
+			inputQuery:         model.Query{Limit: 2},
+			inputRows:          []int{1, 2, 3},
+			expectedRows:       []int{1, 2},
+			expectedPagination: model.Pagination{NextCursor: cursor(2, false)},
+		},
+		"should return previous cursor when page follows a cursor": {
+			inputQuery:         model.Query{Limit: 2, Cursor: &model.Cursor{}},
+			inputRows:          []int{3, 4},
+			expectedRows:       []int{3, 4},
+			expectedPagination: model.Pagination{PreviousCursor: cursor(3, true)},
+		},
+		"should reverse backward page": {
+			inputQuery:   model.Query{Limit: 2, Cursor: &model.Cursor{Backward: true}},
+			inputRows:    []int{4, 3, 2},
+			expectedRows: []int{3, 4},
+			expectedPagination: model.Pagination{
+				PreviousCursor: cursor(3, true),
+				NextCursor:     cursor(4, false),
+			},
+		},
+		"should return rows without cursors when there is no limit": {
+			inputQuery:   model.Query{},
+			inputRows:    []int{1, 2, 3},
+			expectedRows: []int{1, 2, 3},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			rows, pagination := paginate(cs.inputQuery, cs.inputRows, value)
+
+			// then
+			assert.Equal(t, cs.expectedRows, rows)
+			assert.Equal(t, cs.expectedPagination, pagination)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/receipt_repository_mock.go -package mock . ReceiptRepository
+type ReceiptRepository interface {
+	FindOrCreate(ctx context.Context, organization string, donationID int) (*model.Receipt, error)
+}
+
+type ReceiptRepositoryImpl struct {
+	DB infra.MySQL
+}
+
+// FindOrCreate returns the receipt of the donation, numbering a new one with the next number
+// of the organization when the donation has none yet
+func (impl *ReceiptRepositoryImpl) FindOrCreate(ctx context.Context, organization string, donationID int) (*model.Receipt, error) {
+	defer observe(ctx, "receipt", "find_or_create")()
+
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := impl.find(ctx, tx, organization, donationID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if data != nil {
+		return data, tx.Commit()
+	}
+
+	// the sequence row stays locked until the commit, so concurrent receipts wait for their number
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO receipt_sequences (organization, last_number)
+		VALUES (?, LAST_INSERT_ID(1))
+		ON DUPLICATE KEY UPDATE last_number = LAST_INSERT_ID(last_number + 1)
+	`, organization)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	number, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := time.Now()
+	res, err = tx.ExecContext(ctx, `
+		INSERT INTO receipts (created_at, organization, number, donation_id)
+		VALUES (?, ?, ?, ?)
+	`, now.Format("2006-01-02T15:04:05"), organization, number, donationID)
+	if err != nil {
+		tx.Rollback()
+		if e, ok := err.(*mysql.MySQLError); ok && e.Number == 1062 {
+			// a concurrent request numbered the same donation first
+			return impl.FindOrCreate(ctx, organization, donationID)
+		}
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &model.Receipt{
+		ID:           int(id),
+		CreatedAt:    now,
+		Organization: organization,
+		Number:       int(number),
+		DonationID:   donationID,
+	}, nil
+}
+
+func (impl *ReceiptRepositoryImpl) find(ctx context.Context, tx *sql.Tx, organization string, donationID int) (*model.Receipt, error) {
+	res, err := tx.QueryContext(ctx, `
+		SELECT id,
+			created_at,
+			organization,
+			number,
+			donation_id
+		FROM receipts
+		WHERE organization = ? AND donation_id = ?
+		LIMIT 1
+	`, organization, donationID)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var data *model.Receipt
+	for res.Next() {
+		data = &model.Receipt{}
+		var createdAt string
+
+		if err := res.Scan(&data.ID, &createdAt, &data.Organization, &data.Number, &data.DonationID); err != nil {
+			return nil, err
+		}
+
+		t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(createdAt, " ", "T", 1))
+		if err != nil {
+			return nil, err
+		}
+		data.CreatedAt = t
+	}
+
+	return data, res.Err()
+}
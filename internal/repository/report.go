@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// distributionGroupColumns are the key and label of each report group
+var distributionGroupColumns = map[model.DistributionGroup][2]string{
+	model.DistributionGroupResource:     {"r.id", "r.name"},
+	model.DistributionGroupNeighborhood: {"f.neighborhood", "f.neighborhood"},
+	model.DistributionGroupCity:         {"f.city", "f.city"},
+	model.DistributionGroupMonth:        {"DATE_FORMAT(rf.created_at, '%Y-%m')", "DATE_FORMAT(rf.created_at, '%Y-%m')"},
+}
+
+//go:generate mockgen -destination ../../mock/report_repository_mock.go -package mock . ReportRepository
+type ReportRepository interface {
+	Distributions(ctx context.Context, period model.DistributionPeriod, group model.DistributionGroup) ([]model.DistributionReport, error)
+}
+
+type ReportRepositoryImpl struct {
+	DB infra.MySQL
+}
+
+// Distributions sums the donations made from period.From until before period.To by group. Families
+// are counted once per group, and the totals are split by measurement since kilograms and liters
+// of different resources cannot be added.
+func (impl *ReportRepositoryImpl) Distributions(ctx context.Context, period model.DistributionPeriod,
+	group model.DistributionGroup) ([]model.DistributionReport, error) {
+	defer observe(ctx, "report", "distributions")()
+
+	columns, ok := distributionGroupColumns[group]
+	if !ok {
+		return nil, fmt.Errorf("invalid distribution group %s", group)
+	}
+
+	from := period.From.Format("2006-01-02T15:04:05")
+	to := period.To.Format("2006-01-02T15:04:05")
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s AS group_key,
+			MIN(%s) AS group_label,
+			MIN(r.id),
+			SUM(rf.quantity),
+			COUNT(rf.id),
+			COUNT(DISTINCT rf.family_id)
+		FROM resources_to_families rf
+		JOIN resources r ON r.id = rf.resource_id
+		JOIN families f ON f.id = rf.family_id
+		WHERE rf.created_at >= ? AND rf.created_at < ?
+		GROUP BY group_key
+		ORDER BY group_label, group_key
+	`, columns[0], columns[1]), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	data := []model.DistributionReport{}
+	index := map[string]int{}
+	for res.Next() {
+		var key string
+		var report model.DistributionReport
+		if err := res.Scan(&key, &report.Group, &report.ResourceID, &report.Quantity,
+			&report.Donations, &report.Families); err != nil {
+			return nil, err
+		}
+		if group != model.DistributionGroupResource {
+			report.ResourceID = 0
+		}
+		report.Totals = []model.DistributionTotal{}
+
+		index[key] = len(data)
+		data = append(data, report)
+	}
+	if err := res.Err(); err != nil {
+		return nil, err
+	}
+
+	totals, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s AS group_key,
+			r.measurement,
+			SUM(rf.quantity * r.amount)
+		FROM resources_to_families rf
+		JOIN resources r ON r.id = rf.resource_id
+		JOIN families f ON f.id = rf.family_id
+		WHERE rf.created_at >= ? AND rf.created_at < ?
+		GROUP BY group_key, r.measurement
+		ORDER BY r.measurement
+	`, columns[0]), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer totals.Close()
+
+	for totals.Next() {
+		var key string
+		var total model.DistributionTotal
+		if err := totals.Scan(&key, &total.Measurement, &total.Amount); err != nil {
+			return nil, err
+		}
+
+		if i, ok := index[key]; ok {
+			data[i].Totals = append(data[i].Totals, total)
+		}
+	}
+
+	return data, totals.Err()
+}
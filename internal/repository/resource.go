@@ -14,21 +14,76 @@ import (
 
 //go:generate mockgen -destination ../../mock/resource_repository_mock.go -package mock . ResourceRepository
 type ResourceRepository interface {
-	FindAll(ctx context.Context) ([]model.Resource, error)
+	FindAll(ctx context.Context, query model.Query) ([]model.Resource, model.Pagination, error)
+	FindEach(ctx context.Context, query model.Query, fn func(data model.Resource) error) error
+	Count(ctx context.Context, query model.Query) (int, error)
 	FindOneById(ctx context.Context, resourceID int) (*model.Resource, error)
 	Create(ctx context.Context, data model.Resource) (*model.Resource, error)
 	Update(ctx context.Context, data model.Resource) error
 	UpdateQuantity(ctx context.Context, resourceID int, quantity float64) error
 }
 
+var resourceQueryFields = map[string]queryField{
+	"id":          {Column: "id", Type: queryFieldNumber},
+	"created_at":  {Column: "created_at", Type: queryFieldDate},
+	"updated_at":  {Column: "updated_at", Type: queryFieldDate},
+	"name":        {Column: "name", Type: queryFieldText},
+	"amount":      {Column: "amount", Type: queryFieldNumber},
+	"measurement": {Column: "measurement", Type: queryFieldText},
+	"quantity":    {Column: "quantity", Type: queryFieldNumber},
+}
+
+func resourceCursorValue(resource model.Resource, field string) interface{} {
+	switch field {
+	case "created_at":
+		return resource.CreatedAt.Format("2006-01-02T15:04:05")
+	case "updated_at":
+		return resource.UpdatedAt.Format("2006-01-02T15:04:05")
+	case "name":
+		return resource.Name
+	case "amount":
+		return resource.Amount
+	case "measurement":
+		return resource.Measurement
+	case "quantity":
+		return resource.Quantity
+	}
+
+	return resource.ID
+}
+
 type ResourceRepositoryImpl struct {
 	DB infra.MySQL
 }
 
-func (impl *ResourceRepositoryImpl) FindAll(ctx context.Context) ([]model.Resource, error) {
+func (impl *ResourceRepositoryImpl) FindAll(ctx context.Context, query model.Query) ([]model.Resource, model.Pagination, error) {
+	defer observe(ctx, "resource", "find_all")()
+
 	data := []model.Resource{}
 
-	res, err := impl.DB.DB.Query(`
+	err := impl.FindEach(ctx, query, func(resource model.Resource) error {
+		data = append(data, resource)
+		return nil
+	})
+	if err != nil {
+		return nil, model.Pagination{}, err
+	}
+
+	data, pagination := paginate(query, data, resourceCursorValue)
+
+	return data, pagination, nil
+}
+
+// FindEach calls fn with each row matching query as it is read, without loading all rows in memory
+func (impl *ResourceRepositoryImpl) FindEach(ctx context.Context, query model.Query, fn func(resource model.Resource) error) error {
+	defer observe(ctx, "resource", "find_each")()
+
+	q, err := buildQuery(query, "resources", resourceQueryFields, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
 		SELECT id,
 			created_at,
 			updated_at,
@@ -36,22 +91,63 @@ func (impl *ResourceRepositoryImpl) FindAll(ctx context.Context) ([]model.Resour
 			amount,
 			measurement,
 			quantity
-		FROM resources`)
+		FROM resources
+		WHERE %s
+		ORDER BY %s
+		%s
+	`, q.WhereClause(), q.OrderByClause(), q.LimitClause()), q.Args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer res.Close()
 
 	for res.Next() {
 		resource, err := impl.Scan(res)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		if err := fn(*resource); err != nil {
+			return err
 		}
-		data = append(data, *resource)
 	}
-	return data, nil
+
+	return res.Err()
+}
+
+func (impl *ResourceRepositoryImpl) Count(ctx context.Context, query model.Query) (int, error) {
+	defer observe(ctx, "resource", "count")()
+
+	total := 0
+
+	query.Cursor = nil
+	q, err := buildQuery(query, "resources", resourceQueryFields, nil)
+	if err != nil {
+		return total, err
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT count(id) as total
+		FROM resources
+		WHERE %s
+	`, q.WhereClause()), q.Args...)
+	if err != nil {
+		return total, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		if err = res.Scan(&total); err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
 }
 
 func (impl *ResourceRepositoryImpl) FindOneById(ctx context.Context, resourceID int) (*model.Resource, error) {
+	defer observe(ctx, "resource", "find_one_by_id")()
+
 	res, err := impl.DB.DB.QueryContext(ctx, `
 		SELECT id,
 			created_at,
@@ -76,13 +172,15 @@ func (impl *ResourceRepositoryImpl) FindOneById(ctx context.Context, resourceID
 	}
 
 	if data == nil {
-		return nil, &exception.NotFoundException{Err: fmt.Errorf("resource %d not found", resourceID)}
+		return nil, &exception.NotFoundException{Code: "resource.not_found", Err: fmt.Errorf("resource %d not found", resourceID)}
 	}
 
 	return data, nil
 }
 
 func (impl *ResourceRepositoryImpl) Create(ctx context.Context, data model.Resource) (*model.Resource, error) {
+	defer observe(ctx, "resource", "create")()
+
 	now := time.Now()
 	nowMysql := now.Format("2006-01-02T15:04:05")
 	res, err := impl.DB.DB.ExecContext(ctx, `
@@ -106,13 +204,15 @@ func (impl *ResourceRepositoryImpl) Create(ctx context.Context, data model.Resou
 }
 
 func (impl *ResourceRepositoryImpl) Update(ctx context.Context, data model.Resource) error {
+	defer observe(ctx, "resource", "update")()
+
 	fields, values := impl.DB.BuildUpdateData(map[string]interface{}{
 		"name":        data.Name,
 		"amount":      data.Amount,
 		"measurement": data.Measurement,
 	})
 	if len(fields) == 0 {
-		return &exception.EmptyModelException{Err: fmt.Errorf("empty resource model")}
+		return &exception.EmptyModelException{Code: "resource.empty_update", Err: fmt.Errorf("empty resource model")}
 	}
 
 	query := fmt.Sprintf(`
@@ -136,13 +236,15 @@ func (impl *ResourceRepositoryImpl) Update(ctx context.Context, data model.Resou
 		return err
 	}
 	if rows == 0 {
-		return &exception.NotFoundException{Err: fmt.Errorf("resource %d not found", data.ID)}
+		return &exception.NotFoundException{Code: "resource.not_found", Err: fmt.Errorf("resource %d not found", data.ID)}
 	}
 
 	return nil
 }
 
 func (impl *ResourceRepositoryImpl) UpdateQuantity(ctx context.Context, resourceID int, quantity float64) error {
+	defer observe(ctx, "resource", "update_quantity")()
+
 	query := `
 		UPDATE resources
 		SET updated_at = ?,
@@ -160,7 +262,7 @@ func (impl *ResourceRepositoryImpl) UpdateQuantity(ctx context.Context, resource
 		return err
 	}
 	if rows == 0 {
-		return &exception.NotFoundException{Err: fmt.Errorf("resource %d not found", resourceID)}
+		return &exception.NotFoundException{Code: "resource.not_found", Err: fmt.Errorf("resource %d not found", resourceID)}
 	}
 
 	return nil
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/search_repository_mock.go -package mock . SearchRepository
+type SearchRepository interface {
+	Search(ctx context.Context, terms string, limit int) ([]model.SearchHit, error)
+}
+
+type SearchRepositoryImpl struct {
+	DB     infra.MySQL
+	Cipher *infra.Cipher
+}
+
+// Search finds families and persons by the blind indexes of the words in terms. Hits are ranked
+// by the number of distinct words they match; the words matched by a person also count for their
+// family, so "maria rua 25" ranks first the household where Maria lives at "Rua ..., 25".
+func (impl *SearchRepositoryImpl) Search(ctx context.Context, terms string, limit int) ([]model.SearchHit, error) {
+	defer observe(ctx, "search", "search")()
+
+	tokens := impl.Cipher.BlindTokens(terms)
+	if len(tokens) == 0 {
+		return []model.SearchHit{}, nil
+	}
+
+	persons := &PersonRepositoryImpl{DB: impl.DB, Cipher: impl.Cipher}
+	documents := []string{}
+	for _, word := range strings.Fields(terms) {
+		documents = append(documents, persons.DocumentIndex(word))
+	}
+
+	args := []interface{}{}
+	for _, values := range [][]string{tokens, tokens, documents, documents} {
+		for _, v := range values {
+			args = append(args, v)
+		}
+	}
+	args = append(args, limit)
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT hit.entity, hit.entity_id, COUNT(DISTINCT hit.token) AS score
+		FROM (
+			SELECT entity, entity_id, token
+			FROM search_tokens
+			WHERE token IN (%[1]s)
+			UNION ALL
+			SELECT 'families', p.family_id, s.token
+			FROM search_tokens s
+			JOIN persons p ON p.id = s.entity_id
+			WHERE s.entity = 'persons' AND s.token IN (%[1]s) AND p.deleted_at IS NULL
+			UNION ALL
+			SELECT 'persons', id, document_bidx
+			FROM persons
+			WHERE document_bidx IN (%[2]s)
+			UNION ALL
+			SELECT 'families', family_id, document_bidx
+			FROM persons
+			WHERE document_bidx IN (%[2]s) AND deleted_at IS NULL
+		) hit
+		LEFT JOIN families f ON hit.entity = 'families' AND f.id = hit.entity_id
+		LEFT JOIN persons p ON hit.entity = 'persons' AND p.id = hit.entity_id
+		WHERE COALESCE(f.deleted_at, p.deleted_at) IS NULL
+		GROUP BY hit.entity, hit.entity_id
+		ORDER BY score DESC, hit.entity, hit.entity_id
+		LIMIT ?
+	`, placeholders(len(tokens)), placeholders(len(documents))), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	type hit struct {
+		entity string
+		id     int
+		score  int
+	}
+
+	hits := []hit{}
+	familyIDs := []interface{}{}
+	personIDs := []interface{}{}
+	for res.Next() {
+		var h hit
+		if err := res.Scan(&h.entity, &h.id, &h.score); err != nil {
+			res.Close()
+			return nil, err
+		}
+		hits = append(hits, h)
+
+		if h.entity == "families" {
+			familyIDs = append(familyIDs, h.id)
+		} else {
+			personIDs = append(personIDs, h.id)
+		}
+	}
+	res.Close()
+
+	families, err := impl.findFamilies(ctx, familyIDs)
+	if err != nil {
+		return nil, err
+	}
+	people, err := impl.findPersons(ctx, personIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []model.SearchHit{}
+	for _, h := range hits {
+		if h.entity == "families" {
+			if family, ok := families[h.id]; ok {
+				data = append(data, model.SearchHit{Type: model.SearchHitFamily, Score: h.score, Family: family})
+			}
+		} else if person, ok := people[h.id]; ok {
+			data = append(data, model.SearchHit{Type: model.SearchHitPerson, Score: h.score, Person: person})
+		}
+	}
+
+	return data, nil
+}
+
+func (impl *SearchRepositoryImpl) findFamilies(ctx context.Context, ids []interface{}) (map[int]*model.Family, error) {
+	data := map[int]*model.Family{}
+	if len(ids) == 0 {
+		return data, nil
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id,
+			created_at,
+			updated_at,
+			name,
+			country,
+			state,
+			city,
+			neighborhood,
+			street,
+			number,
+			complement,
+			zipcode
+		FROM families
+		WHERE id IN (%s)
+	`, placeholders(len(ids))), ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	families := &FamilyRepositoryImpl{DB: impl.DB, Cipher: impl.Cipher}
+	for res.Next() {
+		family, err := families.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+		data[family.ID] = family
+	}
+
+	return data, nil
+}
+
+func (impl *SearchRepositoryImpl) findPersons(ctx context.Context, ids []interface{}) (map[int]*model.Person, error) {
+	data := map[int]*model.Person{}
+	if len(ids) == 0 {
+		return data, nil
+	}
+
+	res, err := impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id,
+			created_at,
+			updated_at,
+			family_id,
+			name,
+			document
+		FROM persons
+		WHERE id IN (%s)
+	`, placeholders(len(ids))), ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	persons := &PersonRepositoryImpl{DB: impl.DB, Cipher: impl.Cipher}
+	for res.Next() {
+		person, err := persons.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+		data[person.ID] = person
+	}
+
+	return data, nil
+}
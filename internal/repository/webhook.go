@@ -0,0 +1,432 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/webhook_repository_mock.go -package mock . WebhookRepository
+type WebhookRepository interface {
+	FindAll(ctx context.Context) ([]model.WebhookSubscription, error)
+	FindOneById(ctx context.Context, subscriptionID int) (*model.WebhookSubscription, error)
+	Create(ctx context.Context, data model.WebhookSubscription) (*model.WebhookSubscription, error)
+	Update(ctx context.Context, data model.WebhookSubscription) error
+	Delete(ctx context.Context, subscriptionID int) error
+	Enqueue(ctx context.Context, event, payload string) (int, error)
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookAttempt) error
+	FindDeliveries(ctx context.Context, subscriptionID, limit int) ([]model.WebhookDelivery, error)
+}
+
+type WebhookRepositoryImpl struct {
+	DB     infra.MySQL
+	Cipher *infra.Cipher
+}
+
+func (impl *WebhookRepositoryImpl) FindAll(ctx context.Context) ([]model.WebhookSubscription, error) {
+	defer observe(ctx, "webhook", "find_all")()
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT id,
+			created_at,
+			updated_at,
+			url,
+			events,
+			secret,
+			active
+		FROM webhook_subscriptions
+		WHERE deleted_at IS NULL
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	data := []model.WebhookSubscription{}
+	for res.Next() {
+		subscription, err := impl.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, *subscription)
+	}
+
+	return data, res.Err()
+}
+
+func (impl *WebhookRepositoryImpl) FindOneById(ctx context.Context, subscriptionID int) (*model.WebhookSubscription, error) {
+	defer observe(ctx, "webhook", "find_one_by_id")()
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT id,
+			created_at,
+			updated_at,
+			url,
+			events,
+			secret,
+			active
+		FROM webhook_subscriptions
+		WHERE id = ?
+			AND deleted_at IS NULL
+		LIMIT 1
+	`, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var data *model.WebhookSubscription
+	for res.Next() {
+		data, err = impl.Scan(res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if data == nil {
+		return nil, &exception.NotFoundException{Code: "webhook.not_found", Err: fmt.Errorf("webhook %d not found", subscriptionID)}
+	}
+
+	return data, nil
+}
+
+func (impl *WebhookRepositoryImpl) Create(ctx context.Context, data model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	defer observe(ctx, "webhook", "create")()
+
+	secret, err := impl.Cipher.Encrypt(data.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	nowMysql := now.Format("2006-01-02T15:04:05")
+
+	res, err := impl.DB.DB.ExecContext(ctx, `
+		INSERT INTO webhook_subscriptions (created_at, updated_at, url, events, secret, active)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, nowMysql, nowMysql, data.URL, strings.Join(data.Events, ","), secret, data.Active)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	data.ID = int(id)
+	data.CreatedAt = now
+	data.UpdatedAt = now
+
+	return &data, nil
+}
+
+// Update replaces the url, events and active of the subscription, keeping its secret
+func (impl *WebhookRepositoryImpl) Update(ctx context.Context, data model.WebhookSubscription) error {
+	defer observe(ctx, "webhook", "update")()
+
+	res, err := impl.DB.DB.ExecContext(ctx, `
+		UPDATE webhook_subscriptions
+		SET updated_at = ?, url = ?, events = ?, active = ?
+		WHERE id = ?
+			AND deleted_at IS NULL
+	`, time.Now().Format("2006-01-02T15:04:05"), data.URL, strings.Join(data.Events, ","), data.Active, data.ID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &exception.NotFoundException{Code: "webhook.not_found", Err: fmt.Errorf("webhook %d not found", data.ID)}
+	}
+
+	return nil
+}
+
+func (impl *WebhookRepositoryImpl) Delete(ctx context.Context, subscriptionID int) error {
+	defer observe(ctx, "webhook", "delete")()
+
+	_, err := impl.DB.DB.ExecContext(ctx, `
+		UPDATE webhook_subscriptions
+		SET deleted_at = NOW()
+		WHERE id = ?
+	`, subscriptionID)
+
+	return err
+}
+
+// Enqueue queues a delivery of the event to each active subscription of it, returning how many were queued
+func (impl *WebhookRepositoryImpl) Enqueue(ctx context.Context, event, payload string) (int, error) {
+	defer observe(ctx, "webhook", "enqueue")()
+
+	nowMysql := time.Now().Format("2006-01-02T15:04:05")
+
+	res, err := impl.DB.DB.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (created_at, updated_at, subscription_id, event, payload, status, attempts, next_attempt_at)
+		SELECT ?, ?, id, ?, ?, ?, 0, ?
+		FROM webhook_subscriptions
+		WHERE deleted_at IS NULL
+			AND active
+			AND FIND_IN_SET(?, events) > 0
+	`, nowMysql, nowMysql, event, payload, model.WebhookDeliveryPending, nowMysql, event)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+
+	return int(rows), err
+}
+
+// Claim takes up to limit pending deliveries due now and postpones them by lease, so other
+// instances skip them while they are sent. A delivery whose attempt is never saved is sent
+// again once the lease ends.
+func (impl *WebhookRepositoryImpl) Claim(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	defer observe(ctx, "webhook", "claim")()
+
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	res, err := tx.QueryContext(ctx, `
+		SELECT d.id,
+			d.created_at,
+			d.updated_at,
+			d.subscription_id,
+			d.event,
+			d.payload,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			s.url,
+			s.secret
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.status = ?
+			AND d.next_attempt_at <= ?
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT ?
+		FOR UPDATE OF d SKIP LOCKED
+	`, model.WebhookDeliveryPending, now.Format("2006-01-02T15:04:05"), limit)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	data := []model.WebhookDelivery{}
+	ids := []interface{}{}
+	for res.Next() {
+		delivery, err := impl.ScanDelivery(res, true)
+		if err != nil {
+			res.Close()
+			tx.Rollback()
+			return nil, err
+		}
+		data = append(data, *delivery)
+		ids = append(ids, delivery.ID)
+	}
+	res.Close()
+
+	if len(ids) > 0 {
+		args := append([]interface{}{now.Add(lease).Format("2006-01-02T15:04:05")}, ids...)
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			UPDATE webhook_deliveries
+			SET next_attempt_at = ?
+			WHERE id IN (%s)
+		`, strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")), args...); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// SaveAttempt records the attempt and the status, attempts and next attempt of the delivery after it
+func (impl *WebhookRepositoryImpl) SaveAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookAttempt) error {
+	defer observe(ctx, "webhook", "save_attempt")()
+
+	tx, err := impl.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	nowMysql := time.Now().Format("2006-01-02T15:04:05")
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO webhook_delivery_attempts (created_at, delivery_id, status_code, error, duration_ms)
+		VALUES (?, ?, ?, ?, ?)
+	`, attempt.CreatedAt.Format("2006-01-02T15:04:05"), delivery.ID, attempt.StatusCode,
+		attempt.Error, attempt.DurationMs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET updated_at = ?, status = ?, attempts = ?, next_attempt_at = ?
+		WHERE id = ?
+	`, nowMysql, delivery.Status, delivery.Attempts, delivery.NextAttemptAt.Format("2006-01-02T15:04:05"),
+		delivery.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindDeliveries returns the last deliveries of the subscription, newest first, with their attempts
+func (impl *WebhookRepositoryImpl) FindDeliveries(ctx context.Context, subscriptionID, limit int) ([]model.WebhookDelivery, error) {
+	defer observe(ctx, "webhook", "find_deliveries")()
+
+	res, err := impl.DB.DB.QueryContext(ctx, `
+		SELECT id,
+			created_at,
+			updated_at,
+			subscription_id,
+			event,
+			payload,
+			status,
+			attempts,
+			next_attempt_at
+		FROM webhook_deliveries
+		WHERE subscription_id = ?
+		ORDER BY id DESC
+		LIMIT ?
+	`, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []model.WebhookDelivery{}
+	index := map[int]int{}
+	ids := []interface{}{}
+	for res.Next() {
+		delivery, err := impl.ScanDelivery(res, false)
+		if err != nil {
+			res.Close()
+			return nil, err
+		}
+		delivery.History = []model.WebhookAttempt{}
+		index[delivery.ID] = len(data)
+		data = append(data, *delivery)
+		ids = append(ids, delivery.ID)
+	}
+	res.Close()
+
+	if len(ids) == 0 {
+		return data, nil
+	}
+
+	res, err = impl.DB.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id,
+			created_at,
+			delivery_id,
+			status_code,
+			error,
+			duration_ms
+		FROM webhook_delivery_attempts
+		WHERE delivery_id IN (%s)
+		ORDER BY id
+	`, strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")), ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var attempt model.WebhookAttempt
+		var createdAt string
+		if err := res.Scan(&attempt.ID, &createdAt, &attempt.DeliveryID, &attempt.StatusCode,
+			&attempt.Error, &attempt.DurationMs); err != nil {
+			return nil, err
+		}
+
+		t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(createdAt, " ", "T", 1))
+		if err != nil {
+			return nil, err
+		}
+		attempt.CreatedAt = t
+
+		i := index[attempt.DeliveryID]
+		data[i].History = append(data[i].History, attempt)
+	}
+
+	return data, res.Err()
+}
+
+func (impl *WebhookRepositoryImpl) Scan(res *sql.Rows) (*model.WebhookSubscription, error) {
+	var data = &model.WebhookSubscription{}
+	var createdAt, updatedAt, events string
+
+	if err := res.Scan(&data.ID, &createdAt, &updatedAt, &data.URL, &events, &data.Secret, &data.Active); err != nil {
+		return nil, err
+	}
+
+	if err := impl.Cipher.DecryptFields(&data.Secret); err != nil {
+		return nil, err
+	}
+	data.Events = strings.Split(events, ",")
+
+	t, err := time.Parse("2006-01-02T15:04:05", strings.Replace(createdAt, " ", "T", 1))
+	if err != nil {
+		return nil, err
+	}
+	data.CreatedAt = t
+
+	t, err = time.Parse("2006-01-02T15:04:05", strings.Replace(updatedAt, " ", "T", 1))
+	if err != nil {
+		return nil, err
+	}
+	data.UpdatedAt = t
+
+	return data, nil
+}
+
+// ScanDelivery reads a delivery row, followed by the url and secret of its subscription when withSubscription
+func (impl *WebhookRepositoryImpl) ScanDelivery(res *sql.Rows, withSubscription bool) (*model.WebhookDelivery, error) {
+	var data = &model.WebhookDelivery{}
+	var createdAt, updatedAt, nextAttemptAt string
+
+	dest := []interface{}{&data.ID, &createdAt, &updatedAt, &data.SubscriptionID, &data.Event, &data.Payload,
+		&data.Status, &data.Attempts, &nextAttemptAt}
+	if withSubscription {
+		dest = append(dest, &data.URL, &data.Secret)
+	}
+	if err := res.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if err := impl.Cipher.DecryptFields(&data.Secret); err != nil {
+		return nil, err
+	}
+
+	values := []string{createdAt, updatedAt, nextAttemptAt}
+	for i, t := range []*time.Time{&data.CreatedAt, &data.UpdatedAt, &data.NextAttemptAt} {
+		parsed, err := time.Parse("2006-01-02T15:04:05", strings.Replace(values[i], " ", "T", 1))
+		if err != nil {
+			return nil, err
+		}
+		*t = parsed
+	}
+
+	return data, nil
+}
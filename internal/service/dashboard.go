@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+const (
+	dashboardTopResources = 5
+	dashboardMaxRangeDays = 366
+)
+
+//go:generate mockgen -destination ../../mock/dashboard_service_mock.go -package mock . DashboardService
+type DashboardService interface {
+	Dashboard(ctx context.Context, days string) (DashboardResponse, error)
+}
+
+type DashboardServiceImpl struct {
+	DashboardRepository repository.DashboardRepository
+	// TTL is how long a dashboard is served from memory before being computed again
+	TTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]dashboardCacheEntry
+}
+
+type dashboardCacheEntry struct {
+	res     DashboardResponse
+	expires time.Time
+}
+
+// Dashboard returns the statistics of the current month and, when days is given as 30d, the
+// daily series of the last days including today
+func (impl *DashboardServiceImpl) Dashboard(ctx context.Context, days string) (DashboardResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.dashboard.dashboard")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.dashboard.dashboard"})
+
+	n := 0
+	if days != "" {
+		var err error
+		n, err = strconv.Atoi(strings.TrimSuffix(days, "d"))
+		if err != nil || !strings.HasSuffix(days, "d") || n < 1 || n > dashboardMaxRangeDays {
+			err := &exception.InvalidQueryException{
+				Err: fmt.Errorf("range must be a number of days up to %d, as 30d", dashboardMaxRangeDays),
+			}
+			log.Error(err.Error())
+			return DashboardResponse{}, err
+		}
+	}
+	key := strconv.Itoa(n)
+
+	now := time.Now()
+
+	impl.mu.Lock()
+	entry, ok := impl.cache[key]
+	impl.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.res, nil
+	}
+
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	summary, err := impl.DashboardRepository.Summary(ctx, month, dashboardTopResources)
+	if err != nil {
+		log.Error(err.Error())
+		return DashboardResponse{}, err
+	}
+
+	stock := make([]DistributionTotal, len(summary.Stock))
+	for i, t := range summary.Stock {
+		stock[i] = DistributionTotal{Measurement: t.Measurement, Amount: t.Amount}
+	}
+	top := make([]DashboardResource, len(summary.TopResources))
+	for i, r := range summary.TopResources {
+		top[i] = DashboardResource{
+			ResourceID: r.ResourceID,
+			Name:       r.Name,
+			Quantity:   r.Quantity,
+			Total:      DistributionTotal{Measurement: r.Total.Measurement, Amount: r.Total.Amount},
+		}
+	}
+
+	data := &Dashboard{
+		GeneratedAt:    now.Format("2006-01-02T15:04:05"),
+		ActiveFamilies: summary.ActiveFamilies,
+		PersonsServed:  summary.PersonsServed,
+		Stock:          stock,
+		ThisMonth:      DashboardDistributions(summary.ThisMonth),
+		LastMonth:      DashboardDistributions(summary.LastMonth),
+		TopResources:   top,
+	}
+
+	if n > 0 {
+		to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+		from := to.AddDate(0, 0, -n)
+
+		days, err := impl.DashboardRepository.Days(ctx, from, to)
+		if err != nil {
+			log.Error(err.Error())
+			return DashboardResponse{}, err
+		}
+
+		data.Series = dashboardSeries(from, n, days)
+	}
+
+	res := DashboardResponse{Data: data}
+
+	impl.mu.Lock()
+	if impl.cache == nil {
+		impl.cache = map[string]dashboardCacheEntry{}
+	}
+	impl.cache[key] = dashboardCacheEntry{res: res, expires: now.Add(impl.TTL)}
+	impl.mu.Unlock()
+
+	return res, nil
+}
+
+// dashboardSeries returns the n days from from, with zeros on the days without anything created
+func dashboardSeries(from time.Time, n int, days []model.DashboardDay) []DashboardDay {
+	byDate := map[string]model.DashboardDay{}
+	for _, day := range days {
+		byDate[day.Date.Format("2006-01-02")] = day
+	}
+
+	series := make([]DashboardDay, n)
+	for i := range series {
+		date := from.AddDate(0, 0, i).Format("2006-01-02")
+		day := byDate[date]
+
+		series[i] = DashboardDay{
+			Date:      date,
+			Families:  day.Families,
+			Persons:   day.Persons,
+			Resources: day.Resources,
+			Donations: day.Donations,
+			Quantity:  day.Quantity,
+		}
+	}
+
+	return series
+}
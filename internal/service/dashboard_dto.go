@@ -0,0 +1,38 @@
+package service
+
+type DashboardDistributions struct {
+	Donations int     `json:"donations" example:"48"`
+	Families  int     `json:"families" example:"40"`
+	Quantity  float64 `json:"quantity" example:"50"`
+}
+
+type DashboardResource struct {
+	ResourceID int               `json:"resource_id" example:"1"`
+	Name       string            `json:"name" example:"Arroz"`
+	Quantity   float64           `json:"quantity" example:"50"`
+	Total      DistributionTotal `json:"total"`
+}
+
+type DashboardDay struct {
+	Date      string  `json:"date" example:"2000-01-01"`
+	Families  int     `json:"families" example:"2"`
+	Persons   int     `json:"persons" example:"5"`
+	Resources int     `json:"resources" example:"1"`
+	Donations int     `json:"donations" example:"8"`
+	Quantity  float64 `json:"quantity" example:"10"`
+}
+
+type Dashboard struct {
+	GeneratedAt    string                 `json:"generated_at" example:"2000-01-01T12:03:00"`
+	ActiveFamilies int                    `json:"active_families" example:"120"`
+	PersonsServed  int                    `json:"persons_served" example:"310"`
+	Stock          []DistributionTotal    `json:"stock"`
+	ThisMonth      DashboardDistributions `json:"this_month"`
+	LastMonth      DashboardDistributions `json:"last_month"`
+	TopResources   []DashboardResource    `json:"top_resources"`
+	Series         []DashboardDay         `json:"series,omitempty"`
+}
+
+type DashboardResponse struct {
+	Data *Dashboard `json:"data"`
+}
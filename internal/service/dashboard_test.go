@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_DashboardService_Dashboard(t *testing.T) {
+	now := time.Now()
+	MONTH := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	TODAY := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	SUMMARY := &model.Dashboard{
+		ActiveFamilies: 120,
+		PersonsServed:  310,
+		Stock:          []model.DistributionTotal{{Measurement: "Kg", Amount: 500}},
+		ThisMonth:      model.DashboardDistributions{Donations: 48, Families: 40, Quantity: 50},
+		LastMonth:      model.DashboardDistributions{Donations: 30, Families: 28, Quantity: 35},
+		TopResources: []model.DashboardResource{{ResourceID: 1, Name: "Arroz", Quantity: 50,
+			Total: model.DistributionTotal{Measurement: "Kg", Amount: 250}}},
+	}
+
+	cases := map[string]struct {
+		inputRange     string
+		inputCalls     int
+		expectedSeries []service.DashboardDay
+		expectedErr    error
+		prepareMock    func(mockDashboardRepository *mock.MockDashboardRepository)
+	}{
+		"should return dashboard from cache on the next calls": {
+			inputCalls: 2,
+			prepareMock: func(mockDashboardRepository *mock.MockDashboardRepository) {
+				mockDashboardRepository.EXPECT().Summary(gomock.Any(), MONTH, 5).Return(SUMMARY, nil).Times(1)
+			},
+		},
+		"should return daily series filling days without data": {
+			inputRange: "3d",
+			inputCalls: 1,
+			expectedSeries: []service.DashboardDay{
+				{Date: TODAY.AddDate(0, 0, -2).Format("2006-01-02"), Families: 2, Persons: 5},
+				{Date: TODAY.AddDate(0, 0, -1).Format("2006-01-02")},
+				{Date: TODAY.Format("2006-01-02"), Donations: 8, Quantity: 10},
+			},
+			prepareMock: func(mockDashboardRepository *mock.MockDashboardRepository) {
+				mockDashboardRepository.EXPECT().Summary(gomock.Any(), MONTH, 5).Return(SUMMARY, nil)
+				mockDashboardRepository.EXPECT().Days(gomock.Any(), TODAY.AddDate(0, 0, -2), TODAY.AddDate(0, 0, 1)).
+					Return([]model.DashboardDay{
+						{Date: TODAY.AddDate(0, 0, -2), Families: 2, Persons: 5},
+						{Date: TODAY, Donations: 8, Quantity: 10},
+					}, nil)
+			},
+		},
+		"should throw invalid query exception when range is invalid": {
+			inputRange:  "1y",
+			inputCalls:  1,
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("range must be a number of days up to 366, as 30d")},
+			prepareMock: func(mockDashboardRepository *mock.MockDashboardRepository) {},
+		},
+		"should throw error without caching it": {
+			inputCalls:  2,
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockDashboardRepository *mock.MockDashboardRepository) {
+				mockDashboardRepository.EXPECT().Summary(gomock.Any(), MONTH, 5).Return(nil, fmt.Errorf("error")).Times(2)
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mockDashboardRepository := mock.NewMockDashboardRepository(ctrl)
+
+			impl := &service.DashboardServiceImpl{DashboardRepository: mockDashboardRepository, TTL: time.Minute}
+
+			cs.prepareMock(mockDashboardRepository)
+
+			for i := 0; i < cs.inputCalls; i++ {
+				// when
+				res, err := impl.Dashboard(ctx, cs.inputRange)
+
+				// then
+				assert.Equal(t, cs.expectedErr, err)
+				if cs.expectedErr == nil {
+					assert.Equal(t, 120, res.Data.ActiveFamilies)
+					assert.Equal(t, service.DashboardDistributions{Donations: 30, Families: 28, Quantity: 35}, res.Data.LastMonth)
+					assert.Equal(t, []service.DashboardResource{{ResourceID: 1, Name: "Arroz", Quantity: 50,
+						Total: service.DistributionTotal{Measurement: "Kg", Amount: 250}}}, res.Data.TopResources)
+					assert.Equal(t, cs.expectedSeries, res.Data.Series)
+				}
+			}
+		})
+	}
+}
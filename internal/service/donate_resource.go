@@ -4,9 +4,12 @@ import (
 	"context"
 
 	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
 	"github.com/viniosilva/socialassistanceapi/internal/repository"
 )
 
+//go:generate mockgen -destination ../../mock/donate_resource_service_mock.go -package mock . DonateResourceService
 type DonateResourceService interface {
 	Donate(ctx context.Context, dto DonateResourceDonateDto) error
 	Return(ctx context.Context, resourceID int) error
@@ -14,26 +17,68 @@ type DonateResourceService interface {
 
 type DonateResourceServiceImpl struct {
 	DonateResourceRepository repository.DonateResourceRepository
+	ResourceRepository       repository.ResourceRepository
+	Webhooks                 WebhookPublisher
+	Stock                    StockPublisher
+	// LowStockQuantity publishes resource.low_stock when a donation leaves the resource at or below it
+	LowStockQuantity float64
 }
 
 func (impl *DonateResourceServiceImpl) Donate(ctx context.Context, dto DonateResourceDonateDto) error {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.donate_resource.donate"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.donate_resource.donate")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.donate_resource.donate"})
 
 	if err := impl.DonateResourceRepository.Donate(ctx, dto.ResourceID, dto.FamilyID, dto.Quantity); err != nil {
 		log.Error(err.Error())
 		return err
 	}
+	infra.DonationsTotal.Inc()
+
+	if impl.Webhooks != nil {
+		impl.Webhooks.Publish(ctx, model.WebhookEventDonationCreated, DonationEvent{
+			ResourceID: dto.ResourceID,
+			FamilyID:   dto.FamilyID,
+			Quantity:   dto.Quantity,
+		})
+	}
+	if impl.Webhooks == nil && impl.Stock == nil {
+		return nil
+	}
+
+	resource, err := impl.ResourceRepository.FindOneById(ctx, dto.ResourceID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil
+	}
+
+	if impl.Stock != nil {
+		impl.Stock.Publish(ctx, *resource, StockReasonDonate)
+	}
+
+	publishLowStock(ctx, impl.Webhooks, impl.LowStockQuantity, *resource, resource.Quantity+dto.Quantity)
 
 	return nil
 }
 
 func (impl *DonateResourceServiceImpl) Return(ctx context.Context, resourceID int) error {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.donate_resource.return"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.donate_resource.return")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.donate_resource.return"})
 
 	if err := impl.DonateResourceRepository.Return(ctx, resourceID); err != nil {
 		log.Error(err.Error())
 		return err
 	}
 
+	if impl.Stock != nil {
+		resource, err := impl.ResourceRepository.FindOneById(ctx, resourceID)
+		if err != nil {
+			log.Error(err.Error())
+			return nil
+		}
+		impl.Stock.Publish(ctx, *resource, StockReasonReturn)
+	}
+
 	return nil
 }
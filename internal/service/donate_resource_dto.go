@@ -5,3 +5,11 @@ type DonateResourceDonateDto struct {
 	FamilyID   int     `json:"family_id" example:"1" binding:"required"`
 	Quantity   float64 `json:"quantity" example:"10" binding:"required,gte=0"`
 }
+
+type Donation struct {
+	ID         int     `json:"id" example:"1"`
+	CreatedAt  string  `json:"created_at" example:"2000-01-01T12:03:00"`
+	ResourceID int     `json:"resource_id" example:"1"`
+	FamilyID   int     `json:"family_id" example:"1"`
+	Quantity   float64 `json:"quantity" example:"10"`
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
 	"github.com/viniosilva/socialassistanceapi/internal/service"
 	"github.com/viniosilva/socialassistanceapi/mock"
 )
@@ -59,6 +60,71 @@ func Test_DonateResourceService_Donate(t *testing.T) {
 	}
 }
 
+func Test_DonateResourceService_Donate_Webhooks(t *testing.T) {
+	cases := map[string]struct {
+		inputQuantity float64
+		prepareMock   func(mockResourceRepository *mock.MockResourceRepository, mockWebhooks *mock.MockWebhookPublisher)
+	}{
+		"should publish donation and low stock when quantity reaches the threshold": {
+			inputQuantity: 6,
+			prepareMock: func(mockResourceRepository *mock.MockResourceRepository, mockWebhooks *mock.MockWebhookPublisher) {
+				mockWebhooks.EXPECT().Publish(gomock.Any(), model.WebhookEventDonationCreated,
+					service.DonationEvent{ResourceID: 1, FamilyID: 1, Quantity: 6})
+				mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(&model.Resource{ID: 1, Name: "Arroz", Quantity: 4}, nil)
+				mockWebhooks.EXPECT().Publish(gomock.Any(), model.WebhookEventResourceLow, gomock.Any()).
+					Do(func(ctx context.Context, event string, data interface{}) {
+						assert.Equal(t, 4.0, data.(service.Resource).Quantity)
+					})
+			},
+		},
+		"should not publish low stock again when quantity was already low": {
+			inputQuantity: 1,
+			prepareMock: func(mockResourceRepository *mock.MockResourceRepository, mockWebhooks *mock.MockWebhookPublisher) {
+				mockWebhooks.EXPECT().Publish(gomock.Any(), model.WebhookEventDonationCreated,
+					service.DonationEvent{ResourceID: 1, FamilyID: 1, Quantity: 1})
+				mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(&model.Resource{ID: 1, Name: "Arroz", Quantity: 3}, nil)
+			},
+		},
+		"should not publish low stock when quantity is above the threshold": {
+			inputQuantity: 1,
+			prepareMock: func(mockResourceRepository *mock.MockResourceRepository, mockWebhooks *mock.MockWebhookPublisher) {
+				mockWebhooks.EXPECT().Publish(gomock.Any(), model.WebhookEventDonationCreated,
+					service.DonationEvent{ResourceID: 1, FamilyID: 1, Quantity: 1})
+				mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(&model.Resource{ID: 1, Name: "Arroz", Quantity: 9}, nil)
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockDonateResourceRepository := mock.NewMockDonateResourceRepository(ctrl)
+			mockResourceRepository := mock.NewMockResourceRepository(ctrl)
+			mockWebhooks := mock.NewMockWebhookPublisher(ctrl)
+			mockDonateResourceRepository.EXPECT().Donate(gomock.Any(), 1, 1, cs.inputQuantity).Return(nil)
+			cs.prepareMock(mockResourceRepository, mockWebhooks)
+
+			impl := &service.DonateResourceServiceImpl{
+				DonateResourceRepository: mockDonateResourceRepository,
+				ResourceRepository:       mockResourceRepository,
+				Webhooks:                 mockWebhooks,
+				LowStockQuantity:         5,
+			}
+
+			// when
+			err := impl.Donate(ctx, service.DonateResourceDonateDto{ResourceID: 1, FamilyID: 1, Quantity: cs.inputQuantity})
+
+			// then
+			assert.Nil(t, err)
+		})
+	}
+}
+
 func Test_DonateResourceService_Return(t *testing.T) {
 	cases := map[string]struct {
 		inputResourceID int
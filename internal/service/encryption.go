@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+//go:generate mockgen -destination ../../mock/encryption_service_mock.go -package mock . EncryptionService
+type EncryptionService interface {
+	RotateKeys(ctx context.Context) (int, error)
+}
+
+type EncryptionServiceImpl struct {
+	EncryptionRepository repository.EncryptionRepository
+}
+
+func (impl *EncryptionServiceImpl) RotateKeys(ctx context.Context) (int, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.encryption.rotate_keys")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.encryption.rotate_keys"})
+
+	total, err := impl.EncryptionRepository.RotateKeys(ctx)
+	if err != nil {
+		log.Error(err.Error())
+		return total, err
+	}
+
+	log.Infof("%d rows rotated", total)
+
+	return total, nil
+}
@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_EncryptionService_RotateKeys(t *testing.T) {
+	cases := map[string]struct {
+		expectedRes int
+		expectedErr error
+		prepareMock func(mockEncryptionRepository *mock.MockEncryptionRepository)
+	}{
+		"should rotate keys": {
+			expectedRes: 2,
+			prepareMock: func(mockEncryptionRepository *mock.MockEncryptionRepository) {
+				mockEncryptionRepository.EXPECT().RotateKeys(gomock.Any()).Return(2, nil)
+			},
+		},
+		"should throw error": {
+			expectedRes: 1,
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockEncryptionRepository *mock.MockEncryptionRepository) {
+				mockEncryptionRepository.EXPECT().RotateKeys(gomock.Any()).Return(1, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockEncryptionRepository := mock.NewMockEncryptionRepository(ctrl)
+			cs.prepareMock(mockEncryptionRepository)
+
+			impl := &service.EncryptionServiceImpl{EncryptionRepository: mockEncryptionRepository}
+
+			// when
+			res, err := impl.RotateKeys(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+// exportFields are the exported fields of each entity in their default order
+var exportFields = map[string][]string{
+	"families": {"id", "created_at", "updated_at", "name", "country", "state", "city", "neighborhood",
+		"street", "number", "complement", "zipcode"},
+	"persons":   {"id", "created_at", "updated_at", "family_id", "name", "document"},
+	"resources": {"id", "created_at", "updated_at", "name", "amount", "measurement", "quantity"},
+	"donations": {"id", "created_at", "resource_id", "family_id", "quantity"},
+}
+
+//go:generate mockgen -destination ../../mock/export_service_mock.go -package mock . ExportService
+type ExportService interface {
+	Export(ctx context.Context, dto ExportDto, w io.Writer) error
+}
+
+type ExportServiceImpl struct {
+	FamilyRepository         repository.FamilyRepository
+	PersonRepository         repository.PersonRepository
+	ResourceRepository       repository.ResourceRepository
+	DonateResourceRepository repository.DonateResourceRepository
+	DateFormat               string
+	Columns                  map[string]map[string]string
+}
+
+// Export writes the header and then each row as it is read from the repository. Nothing is
+// written to w when the request is invalid or the query fails before the first rows are flushed.
+func (impl *ExportServiceImpl) Export(ctx context.Context, dto ExportDto, w io.Writer) error {
+	ctx, span := infra.StartSpan(ctx, "internal.service.export.export")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.export.export"})
+
+	allowed, ok := exportFields[dto.Entity]
+	if !ok {
+		err := &exception.NotFoundException{Code: "export.not_found", Err: fmt.Errorf("export of %s not found", dto.Entity)}
+		log.Error(err.Error())
+		return err
+	}
+
+	fields := dto.Fields
+	if len(fields) == 0 {
+		fields = allowed
+	}
+	for _, field := range fields {
+		if !contains(allowed, field) {
+			err := &exception.InvalidQueryException{Err: fmt.Errorf("invalid field %s", field)}
+			log.Error(err.Error())
+			return err
+		}
+	}
+
+	writer, err := infra.NewSpreadsheetWriter(dto.Format, w)
+	if err != nil {
+		err := &exception.InvalidQueryException{Err: err}
+		log.Error(err.Error())
+		return err
+	}
+
+	header := make([]interface{}, len(fields))
+	for i, field := range fields {
+		header[i] = field
+		if column := impl.Columns[dto.Entity][field]; column != "" {
+			header[i] = column
+		}
+	}
+	if err := writer.Write(header); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	dto.Query.Cursor = nil
+	switch dto.Entity {
+	case "families":
+		err = impl.FamilyRepository.FindEach(ctx, dto.Query, func(data model.Family) error {
+			return writer.Write(impl.row(fields, map[string]interface{}{
+				"id": data.ID, "created_at": data.CreatedAt, "updated_at": data.UpdatedAt, "name": data.Name,
+				"country": data.Country, "state": data.State, "city": data.City, "neighborhood": data.Neighborhood,
+				"street": data.Street, "number": data.Number, "complement": data.Complement, "zipcode": data.Zipcode,
+			}))
+		})
+	case "persons":
+		err = impl.PersonRepository.FindEach(ctx, dto.Query, func(data model.Person) error {
+			return writer.Write(impl.row(fields, map[string]interface{}{
+				"id": data.ID, "created_at": data.CreatedAt, "updated_at": data.UpdatedAt,
+				"family_id": data.FamilyID, "name": data.Name, "document": data.Document,
+			}))
+		})
+	case "resources":
+		err = impl.ResourceRepository.FindEach(ctx, dto.Query, func(data model.Resource) error {
+			return writer.Write(impl.row(fields, map[string]interface{}{
+				"id": data.ID, "created_at": data.CreatedAt, "updated_at": data.UpdatedAt, "name": data.Name,
+				"amount": data.Amount, "measurement": data.Measurement, "quantity": data.Quantity,
+			}))
+		})
+	case "donations":
+		err = impl.DonateResourceRepository.FindEach(ctx, dto.Query, func(data model.ResourceToFamily) error {
+			return writer.Write(impl.row(fields, map[string]interface{}{
+				"id": data.ID, "created_at": data.CreatedAt, "resource_id": data.ResourceID,
+				"family_id": data.FamilyID, "quantity": data.Quantity,
+			}))
+		})
+	}
+	if err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (impl *ExportServiceImpl) row(fields []string, values map[string]interface{}) []interface{} {
+	dateFormat := impl.DateFormat
+	if dateFormat == "" {
+		dateFormat = "2006-01-02T15:04:05"
+	}
+
+	row := make([]interface{}, len(fields))
+	for i, field := range fields {
+		row[i] = values[field]
+		if t, ok := row[i].(time.Time); ok {
+			row[i] = t.Format(dateFormat)
+		}
+	}
+
+	return row
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,11 @@
+package service
+
+import "github.com/viniosilva/socialassistanceapi/internal/model"
+
+type ExportDto struct {
+	Entity string
+	Format string
+	Query  model.Query
+	// Fields are the exported columns in order, all of them when empty
+	Fields []string
+}
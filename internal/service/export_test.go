@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_ExportService_Export(t *testing.T) {
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		inputDto        service.ExportDto
+		inputColumns    map[string]map[string]string
+		expectedContent string
+		expectedErr     error
+		prepareMock     func(mockFamilyRepository *mock.MockFamilyRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository)
+	}{
+		"should export families with configured columns and date format": {
+			inputDto: service.ExportDto{Entity: "families", Format: "csv", Fields: []string{"name", "zipcode", "created_at"},
+				Query: model.Query{Filters: []model.Filter{{Field: "city", Operator: model.FilterOperatorEq, Value: "Santos"}}}},
+			inputColumns:    map[string]map[string]string{"families": {"zipcode": "cep"}},
+			expectedContent: "name,cep,created_at\nSilva,11010000,01/01/2000\nSouza,11015000,01/01/2000\n",
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository) {
+				query := model.Query{Filters: []model.Filter{{Field: "city", Operator: model.FilterOperatorEq, Value: "Santos"}}}
+				mockFamilyRepository.EXPECT().FindEach(gomock.Any(), query, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, query model.Query, fn func(model.Family) error) error {
+						fn(model.Family{Name: "Silva", Zipcode: "11010000", CreatedAt: DATETIME})
+						return fn(model.Family{Name: "Souza", Zipcode: "11015000", CreatedAt: DATETIME})
+					})
+			},
+		},
+		"should export all fields of donations": {
+			inputDto:        service.ExportDto{Entity: "donations", Format: "csv"},
+			expectedContent: "id,created_at,resource_id,family_id,quantity\n1,01/01/2000,2,3,1.5\n",
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository) {
+				mockDonateResourceRepository.EXPECT().FindEach(gomock.Any(), model.Query{}, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, query model.Query, fn func(model.ResourceToFamily) error) error {
+						return fn(model.ResourceToFamily{ID: 1, CreatedAt: DATETIME, ResourceID: 2, FamilyID: 3, Quantity: 1.5})
+					})
+			},
+		},
+		"should throw not found exception when entity is unknown": {
+			inputDto:    service.ExportDto{Entity: "notes", Format: "csv"},
+			expectedErr: &exception.NotFoundException{Code: "export.not_found", Err: fmt.Errorf("export of notes not found")},
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository) {
+			},
+		},
+		"should throw invalid query exception when field is unknown": {
+			inputDto:    service.ExportDto{Entity: "families", Format: "csv", Fields: []string{"name", "deleted_at"}},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("invalid field deleted_at")},
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository) {
+			},
+		},
+		"should throw invalid query exception when format is unsupported": {
+			inputDto:    service.ExportDto{Entity: "families", Format: "ods"},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("unsupported spreadsheet format ods")},
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository) {
+			},
+		},
+		"should throw error without writing": {
+			inputDto:    service.ExportDto{Entity: "families", Format: "csv"},
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository) {
+				mockFamilyRepository.EXPECT().FindEach(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mockFamilyRepository := mock.NewMockFamilyRepository(ctrl)
+			mockDonateResourceRepository := mock.NewMockDonateResourceRepository(ctrl)
+
+			impl := &service.ExportServiceImpl{
+				FamilyRepository:         mockFamilyRepository,
+				DonateResourceRepository: mockDonateResourceRepository,
+				DateFormat:               "02/01/2006",
+				Columns:                  cs.inputColumns,
+			}
+
+			cs.prepareMock(mockFamilyRepository, mockDonateResourceRepository)
+
+			var buf bytes.Buffer
+
+			// when
+			err := impl.Export(ctx, cs.inputDto, &buf)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedContent, buf.String())
+		})
+	}
+}
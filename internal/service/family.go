@@ -4,46 +4,60 @@ import (
 	"context"
 
 	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
 	"github.com/viniosilva/socialassistanceapi/internal/model"
 	"github.com/viniosilva/socialassistanceapi/internal/repository"
 )
 
 //go:generate mockgen -destination ../../mock/family_service_mock.go -package mock . FamilyService
 type FamilyService interface {
-	FindAll(ctx context.Context, limit, offset int) ([]model.Family, int, error)
-	FindOneById(ctx context.Context, familyID int) (*model.Family, error)
+	FindAll(ctx context.Context, query model.Query) ([]model.Family, model.Pagination, error)
+	FindOneById(ctx context.Context, familyID int, include []string) (*model.Family, error)
+	Include(ctx context.Context, families []model.Family, include []string) error
 	Create(ctx context.Context, dto FamilyCreateDto) (*model.Family, error)
 	Update(ctx context.Context, dto FamilyUpdateDto) error
 	Delete(ctx context.Context, familyID int) error
 }
 
 type FamilyServiceImpl struct {
-	FamilyRepository repository.FamilyRepository
+	FamilyRepository         repository.FamilyRepository
+	PersonRepository         repository.PersonRepository
+	DonateResourceRepository repository.DonateResourceRepository
+	Webhooks                 WebhookPublisher
 }
 
-func (impl *FamilyServiceImpl) FindAll(ctx context.Context, limit, offset int) ([]model.Family, int, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.family.find_all"})
+func (impl *FamilyServiceImpl) FindAll(ctx context.Context, query model.Query) ([]model.Family, model.Pagination, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.family.find_all")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.family.find_all"})
 
-	data, err := impl.FamilyRepository.FindAll(ctx, limit, offset)
+	data, pagination, err := impl.FamilyRepository.FindAll(ctx, query)
 	if err != nil {
 		log.Error(err.Error())
-		return nil, 0, err
+		return nil, model.Pagination{}, err
 	}
 
-	total := 0
-	if len(data) > 0 {
-		total, err = impl.FamilyRepository.Count(ctx)
+	if query.Total {
+		total, err := impl.FamilyRepository.Count(ctx, query)
 		if err != nil {
 			log.Error(err.Error())
-			return nil, 0, err
+			return nil, model.Pagination{}, err
 		}
+		pagination.Total = &total
 	}
 
-	return data, total, nil
+	if err := impl.include(ctx, data, query.Include); err != nil {
+		log.Error(err.Error())
+		return nil, model.Pagination{}, err
+	}
+
+	return data, pagination, nil
 }
 
-func (impl *FamilyServiceImpl) FindOneById(ctx context.Context, familyID int) (*model.Family, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.family.find_one_by_id"})
+func (impl *FamilyServiceImpl) FindOneById(ctx context.Context, familyID int, include []string) (*model.Family, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.family.find_one_by_id")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.family.find_one_by_id"})
 
 	data, err := impl.FamilyRepository.FindOneById(ctx, familyID)
 	if err != nil {
@@ -51,11 +65,81 @@ func (impl *FamilyServiceImpl) FindOneById(ctx context.Context, familyID int) (*
 		return nil, err
 	}
 
-	return data, nil
+	families := []model.Family{*data}
+	if err := impl.include(ctx, families, include); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &families[0], nil
+}
+
+// Include loads the persons and donations of the given families in place, as FindAll does
+func (impl *FamilyServiceImpl) Include(ctx context.Context, families []model.Family, include []string) error {
+	ctx, span := infra.StartSpan(ctx, "internal.service.family.include")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.family.include"})
+
+	if err := impl.include(ctx, families, include); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// include loads the persons and donations of all families with one query each
+func (impl *FamilyServiceImpl) include(ctx context.Context, families []model.Family, include []string) error {
+	if len(families) == 0 || len(include) == 0 {
+		return nil
+	}
+
+	ids := []int{}
+	index := map[int]int{}
+	for i, family := range families {
+		ids = append(ids, family.ID)
+		index[family.ID] = i
+	}
+
+	for _, name := range include {
+		switch name {
+		case "persons":
+			persons, err := impl.PersonRepository.FindAllByFamilyIDs(ctx, ids)
+			if err != nil {
+				return err
+			}
+
+			for i := range families {
+				families[i].Persons = []model.Person{}
+			}
+			for _, person := range persons {
+				i := index[person.FamilyID]
+				families[i].Persons = append(families[i].Persons, person)
+			}
+
+		case "donations":
+			donations, err := impl.DonateResourceRepository.FindAllByFamilyIDs(ctx, ids)
+			if err != nil {
+				return err
+			}
+
+			for i := range families {
+				families[i].Donations = []model.ResourceToFamily{}
+			}
+			for _, donation := range donations {
+				i := index[donation.FamilyID]
+				families[i].Donations = append(families[i].Donations, donation)
+			}
+		}
+	}
+
+	return nil
 }
 
 func (impl *FamilyServiceImpl) Create(ctx context.Context, dto FamilyCreateDto) (*model.Family, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.family.create"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.family.create")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.family.create"})
 
 	data, err := impl.FamilyRepository.Create(ctx, model.Family{
 		Name:         dto.Name,
@@ -74,11 +158,37 @@ func (impl *FamilyServiceImpl) Create(ctx context.Context, dto FamilyCreateDto)
 		return nil, err
 	}
 
+	publishFamilyCreated(ctx, impl.Webhooks, *data)
+
 	return data, nil
 }
 
+// publishFamilyCreated publishes family.created for the family saved, by a request or an import
+func publishFamilyCreated(ctx context.Context, webhooks WebhookPublisher, data model.Family) {
+	if webhooks == nil {
+		return
+	}
+
+	webhooks.Publish(ctx, model.WebhookEventFamilyCreated, Family{
+		ID:           data.ID,
+		Name:         data.Name,
+		CreatedAt:    data.CreatedAt.Format("2006-01-02T15:04:05"),
+		UpdatedAt:    data.UpdatedAt.Format("2006-01-02T15:04:05"),
+		Country:      data.Country,
+		State:        data.State,
+		City:         data.City,
+		Neighborhood: data.Neighborhood,
+		Street:       data.Street,
+		Number:       data.Number,
+		Complement:   data.Complement,
+		Zipcode:      data.Zipcode,
+	})
+}
+
 func (impl *FamilyServiceImpl) Update(ctx context.Context, dto FamilyUpdateDto) error {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.family.update"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.family.update")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.family.update"})
 
 	if err := impl.FamilyRepository.Update(ctx, model.Family{
 		ID:           dto.ID,
@@ -100,7 +210,9 @@ func (impl *FamilyServiceImpl) Update(ctx context.Context, dto FamilyUpdateDto)
 }
 
 func (impl *FamilyServiceImpl) Delete(ctx context.Context, familyID int) error {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.family.delete"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.family.delete")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.family.delete"})
 
 	if err := impl.FamilyRepository.Delete(ctx, familyID); err != nil {
 		log.Error(err.Error())
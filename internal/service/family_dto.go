@@ -1,5 +1,8 @@
 package service
 
+// FamilyEncryptedFields are the encrypted fields of the families, whose filter values the meta redacts
+var FamilyEncryptedFields = []string{"name", "street", "number", "complement"}
+
 type Family struct {
 	ID           int    `json:"id" example:"1"`
 	Name         string `json:"name" example:"Sauro"`
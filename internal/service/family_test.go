@@ -15,17 +15,17 @@ import (
 )
 
 func Test_FamilyService_FindAll(t *testing.T) {
+	total := 1
+
 	cases := map[string]struct {
-		inputLimit    int
-		inputOffset   int
-		expectedRes   []model.Family
-		expectedTotal int
-		expectedErr   error
-		prepareMock   func(mockFamilyRepository *mock.MockFamilyRepository)
+		inputQuery         model.Query
+		expectedRes        []model.Family
+		expectedPagination model.Pagination
+		expectedErr        error
+		prepareMock        func(mockFamilyRepository *mock.MockFamilyRepository)
 	}{
 		"should return families list": {
-			inputLimit:  10,
-			inputOffset: 0,
+			inputQuery: model.Query{Limit: 10},
 			expectedRes: []model.Family{{
 				ID:           1,
 				CreatedAt:    time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC),
@@ -40,9 +40,9 @@ func Test_FamilyService_FindAll(t *testing.T) {
 				Complement:   "1",
 				Zipcode:      "02180110",
 			}},
-			expectedTotal: 1,
+			expectedPagination: model.Pagination{NextCursor: "next"},
 			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository) {
-				mockFamilyRepository.EXPECT().FindAll(gomock.Any(), 10, 0).Return([]model.Family{{
+				mockFamilyRepository.EXPECT().FindAll(gomock.Any(), model.Query{Limit: 10}).Return([]model.Family{{
 					ID:           1,
 					CreatedAt:    time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC),
 					UpdatedAt:    time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC),
@@ -55,26 +55,43 @@ func Test_FamilyService_FindAll(t *testing.T) {
 					Number:       "1",
 					Complement:   "1",
 					Zipcode:      "02180110",
-				}}, nil)
-				mockFamilyRepository.EXPECT().Count(gomock.Any()).Return(1, nil)
+				}}, model.Pagination{NextCursor: "next"}, nil)
+			},
+		},
+		"should return families list with total when requested": {
+			inputQuery:         model.Query{Limit: 10, Total: true},
+			expectedRes:        []model.Family{{ID: 1}},
+			expectedPagination: model.Pagination{Total: &total},
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository) {
+				mockFamilyRepository.EXPECT().FindAll(gomock.Any(), model.Query{Limit: 10, Total: true}).
+					Return([]model.Family{{ID: 1}}, model.Pagination{}, nil)
+				mockFamilyRepository.EXPECT().Count(gomock.Any(), model.Query{Limit: 10, Total: true}).Return(1, nil)
 			},
 		},
 		"should return empty families list": {
-			inputLimit:    10,
-			inputOffset:   0,
-			expectedRes:   []model.Family{},
-			expectedTotal: 0,
+			inputQuery:  model.Query{Limit: 10},
+			expectedRes: []model.Family{},
 			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository) {
-				mockFamilyRepository.EXPECT().FindAll(gomock.Any(), 10, 0).Return([]model.Family{}, nil)
+				mockFamilyRepository.EXPECT().FindAll(gomock.Any(), model.Query{Limit: 10}).
+					Return([]model.Family{}, model.Pagination{}, nil)
 			},
 		},
 		"should throw error when FindAll": {
-			inputLimit:    10,
-			inputOffset:   0,
-			expectedErr:   fmt.Errorf("error"),
-			expectedTotal: 0,
+			inputQuery:  model.Query{Limit: 10},
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository) {
+				mockFamilyRepository.EXPECT().FindAll(gomock.Any(), model.Query{Limit: 10}).
+					Return(nil, model.Pagination{}, fmt.Errorf("error"))
+			},
+		},
+		"should throw error when Count": {
+			inputQuery:  model.Query{Limit: 10, Total: true},
+			expectedErr: fmt.Errorf("error"),
 			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository) {
-				mockFamilyRepository.EXPECT().FindAll(gomock.Any(), 10, 0).Return(nil, fmt.Errorf("error"))
+				mockFamilyRepository.EXPECT().FindAll(gomock.Any(), model.Query{Limit: 10, Total: true}).
+					Return([]model.Family{{ID: 1}}, model.Pagination{}, nil)
+				mockFamilyRepository.EXPECT().Count(gomock.Any(), model.Query{Limit: 10, Total: true}).
+					Return(0, fmt.Errorf("error"))
 			},
 		},
 	}
@@ -90,11 +107,11 @@ func Test_FamilyService_FindAll(t *testing.T) {
 			impl := &service.FamilyServiceImpl{FamilyRepository: mockFamilyRepository}
 
 			// when
-			res, total, err := impl.FindAll(ctx, cs.inputLimit, cs.inputOffset)
+			res, pagination, err := impl.FindAll(ctx, cs.inputQuery)
 
 			// then
 			assert.Equal(t, cs.expectedRes, res)
-			assert.Equal(t, cs.expectedTotal, total)
+			assert.Equal(t, cs.expectedPagination, pagination)
 			assert.Equal(t, cs.expectedErr, err)
 		})
 	}
@@ -103,9 +120,11 @@ func Test_FamilyService_FindAll(t *testing.T) {
 func Test_FamilyService_FindOneByID(t *testing.T) {
 	cases := map[string]struct {
 		inputFamilyID int
+		inputInclude  []string
 		expectedRes   *model.Family
 		expectedErr   error
-		prepareMock   func(mockFamilyRepository *mock.MockFamilyRepository)
+		prepareMock   func(mockFamilyRepository *mock.MockFamilyRepository, mockPersonRepository *mock.MockPersonRepository,
+			mockDonateResourceRepository *mock.MockDonateResourceRepository)
 	}{
 		"should return family when exists": {
 			inputFamilyID: 1,
@@ -123,7 +142,8 @@ func Test_FamilyService_FindOneByID(t *testing.T) {
 				Complement:   "1",
 				Zipcode:      "02180110",
 			},
-			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository) {
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockPersonRepository *mock.MockPersonRepository,
+				mockDonateResourceRepository *mock.MockDonateResourceRepository) {
 				mockFamilyRepository.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Family{
 					ID:           1,
 					CreatedAt:    time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC),
@@ -140,10 +160,39 @@ func Test_FamilyService_FindOneByID(t *testing.T) {
 				}, nil)
 			},
 		},
+		"should return family with persons and donations when included": {
+			inputFamilyID: 1,
+			inputInclude:  []string{"persons", "donations"},
+			expectedRes: &model.Family{
+				ID:        1,
+				Name:      "Sauro",
+				Persons:   []model.Person{{ID: 1, FamilyID: 1, Name: "Maria"}},
+				Donations: []model.ResourceToFamily{},
+			},
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockPersonRepository *mock.MockPersonRepository,
+				mockDonateResourceRepository *mock.MockDonateResourceRepository) {
+				mockFamilyRepository.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Family{ID: 1, Name: "Sauro"}, nil)
+				mockPersonRepository.EXPECT().FindAllByFamilyIDs(gomock.Any(), []int{1}).
+					Return([]model.Person{{ID: 1, FamilyID: 1, Name: "Maria"}}, nil)
+				mockDonateResourceRepository.EXPECT().FindAllByFamilyIDs(gomock.Any(), []int{1}).
+					Return([]model.ResourceToFamily{}, nil)
+			},
+		},
+		"should throw error when include persons": {
+			inputFamilyID: 1,
+			inputInclude:  []string{"persons"},
+			expectedErr:   fmt.Errorf("error"),
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockPersonRepository *mock.MockPersonRepository,
+				mockDonateResourceRepository *mock.MockDonateResourceRepository) {
+				mockFamilyRepository.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Family{ID: 1, Name: "Sauro"}, nil)
+				mockPersonRepository.EXPECT().FindAllByFamilyIDs(gomock.Any(), []int{1}).Return(nil, fmt.Errorf("error"))
+			},
+		},
 		"should return empty when family not exists": {
 			inputFamilyID: 1,
 			expectedErr:   &exception.NotFoundException{Err: fmt.Errorf("family 1 not found")},
-			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository) {
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockPersonRepository *mock.MockPersonRepository,
+				mockDonateResourceRepository *mock.MockDonateResourceRepository) {
 				mockFamilyRepository.EXPECT().FindOneById(gomock.Any(), 1).
 					Return(nil, &exception.NotFoundException{Err: fmt.Errorf("family 1 not found")})
 			},
@@ -151,7 +200,8 @@ func Test_FamilyService_FindOneByID(t *testing.T) {
 		"should throw error": {
 			inputFamilyID: 1,
 			expectedErr:   fmt.Errorf("error"),
-			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository) {
+			prepareMock: func(mockFamilyRepository *mock.MockFamilyRepository, mockPersonRepository *mock.MockPersonRepository,
+				mockDonateResourceRepository *mock.MockDonateResourceRepository) {
 				mockFamilyRepository.EXPECT().FindOneById(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
 			},
 		},
@@ -163,12 +213,18 @@ func Test_FamilyService_FindOneByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockFamilyRepository := mock.NewMockFamilyRepository(ctrl)
-			cs.prepareMock(mockFamilyRepository)
+			mockPersonRepository := mock.NewMockPersonRepository(ctrl)
+			mockDonateResourceRepository := mock.NewMockDonateResourceRepository(ctrl)
+			cs.prepareMock(mockFamilyRepository, mockPersonRepository, mockDonateResourceRepository)
 
-			impl := &service.FamilyServiceImpl{FamilyRepository: mockFamilyRepository}
+			impl := &service.FamilyServiceImpl{
+				FamilyRepository:         mockFamilyRepository,
+				PersonRepository:         mockPersonRepository,
+				DonateResourceRepository: mockDonateResourceRepository,
+			}
 
 			// when
-			res, err := impl.FindOneById(ctx, cs.inputFamilyID)
+			res, err := impl.FindOneById(ctx, cs.inputFamilyID, cs.inputInclude)
 
 			// then
 			assert.Equal(t, cs.expectedRes, res)
@@ -177,6 +233,57 @@ func Test_FamilyService_FindOneByID(t *testing.T) {
 	}
 }
 
+func Test_FamilyService_Include(t *testing.T) {
+	cases := map[string]struct {
+		inputFamilies    []model.Family
+		inputInclude     []string
+		expectedFamilies []model.Family
+		expectedErr      error
+		prepareMock      func(mockPersonRepository *mock.MockPersonRepository)
+	}{
+		"should load persons of all families at once": {
+			inputFamilies: []model.Family{{ID: 1}, {ID: 2}},
+			inputInclude:  []string{"persons"},
+			expectedFamilies: []model.Family{
+				{ID: 1, Persons: []model.Person{}},
+				{ID: 2, Persons: []model.Person{{ID: 1, FamilyID: 2, Name: "Maria"}}},
+			},
+			prepareMock: func(mockPersonRepository *mock.MockPersonRepository) {
+				mockPersonRepository.EXPECT().FindAllByFamilyIDs(gomock.Any(), []int{1, 2}).
+					Return([]model.Person{{ID: 1, FamilyID: 2, Name: "Maria"}}, nil)
+			},
+		},
+		"should throw error": {
+			inputFamilies:    []model.Family{{ID: 1}},
+			inputInclude:     []string{"persons"},
+			expectedFamilies: []model.Family{{ID: 1}},
+			expectedErr:      fmt.Errorf("error"),
+			prepareMock: func(mockPersonRepository *mock.MockPersonRepository) {
+				mockPersonRepository.EXPECT().FindAllByFamilyIDs(gomock.Any(), []int{1}).Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockPersonRepository := mock.NewMockPersonRepository(ctrl)
+			cs.prepareMock(mockPersonRepository)
+
+			impl := &service.FamilyServiceImpl{PersonRepository: mockPersonRepository}
+
+			// when
+			err := impl.Include(ctx, cs.inputFamilies, cs.inputInclude)
+
+			// then
+			assert.Equal(t, cs.expectedFamilies, cs.inputFamilies)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
 func Test_FamilyService_Create(t *testing.T) {
 	cases := map[string]struct {
 		inputDto    service.FamilyCreateDto
@@ -2,22 +2,39 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
 	"github.com/viniosilva/socialassistanceapi/internal/repository"
 )
 
 //go:generate mockgen -destination ../../mock/health_service_mock.go -package mock . HealthService
 type HealthService interface {
 	Ping(ctx context.Context) HealthResponse
+	Live(ctx context.Context) HealthResponse
+	Ready(ctx context.Context) ReadinessResponse
+}
+
+// HealthCheck is a dependency the API needs to serve, Check returns why it is down
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
 }
 
 type HealthServiceImpl struct {
 	HealthRepository repository.HealthRepository
+	// Checks are run on readiness, each one cancelled after Timeout
+	Checks  []HealthCheck
+	Timeout time.Duration
 }
 
 func (impl *HealthServiceImpl) Ping(ctx context.Context) HealthResponse {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.health.ping"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.health.ping")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.health.ping"})
 
 	if err := impl.HealthRepository.Ping(ctx); err != nil {
 		log.Error(err.Error())
@@ -26,3 +43,126 @@ func (impl *HealthServiceImpl) Ping(ctx context.Context) HealthResponse {
 
 	return HealthResponse{HealthStatusUp}
 }
+
+// Live tells the process answers, whatever its dependencies, so it is only restarted when stuck
+func (impl *HealthServiceImpl) Live(ctx context.Context) HealthResponse {
+	return HealthResponse{Status: HealthStatusUp}
+}
+
+// Ready runs the checks at once, the API is up when all of them pass
+func (impl *HealthServiceImpl) Ready(ctx context.Context) ReadinessResponse {
+	ctx, span := infra.StartSpan(ctx, "internal.service.health.ready")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.health.ready"})
+
+	res := ReadinessResponse{Status: HealthStatusUp, Checks: make([]HealthCheckResult, len(impl.Checks))}
+
+	var wg sync.WaitGroup
+	for i, check := range impl.Checks {
+		wg.Add(1)
+		go func(i int, check HealthCheck) {
+			defer wg.Done()
+			res.Checks[i] = impl.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, check := range res.Checks {
+		if check.Status == HealthStatusDown {
+			log.WithFields(logrus.Fields{"check": check.Name}).Error(check.Error)
+			res.Status = HealthStatusDown
+		}
+	}
+
+	return res
+}
+
+func (impl *HealthServiceImpl) run(ctx context.Context, check HealthCheck) HealthCheckResult {
+	if impl.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, impl.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check.Check(ctx) }()
+
+	// a check ignoring ctx still answers down on time
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out: %w", ctx.Err())
+	}
+
+	res := HealthCheckResult{Name: check.Name, Status: HealthStatusUp, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		res.Status = HealthStatusDown
+		res.Error = err.Error()
+	}
+
+	return res
+}
+
+// DatabaseHealthCheck pings MySQL
+func DatabaseHealthCheck(healthRepository repository.HealthRepository) HealthCheck {
+	return HealthCheck{Name: "database", Check: healthRepository.Ping}
+}
+
+// MigrationHealthCheck fails while the database is behind the version the API was built for,
+// or its last migration failed
+func MigrationHealthCheck(healthRepository repository.HealthRepository, version int) HealthCheck {
+	return HealthCheck{Name: "migration", Check: func(ctx context.Context) error {
+		current, dirty, err := healthRepository.MigrationVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", current)
+		}
+		if current < version {
+			return fmt.Errorf("database is at migration %d, expected %d", current, version)
+		}
+		return nil
+	}}
+}
+
+// DiskHealthCheck fails when the file system of path has less than minFree bytes available
+func DiskHealthCheck(path string, minFree uint64) HealthCheck {
+	return HealthCheck{Name: "disk", Check: func(ctx context.Context) error {
+		free, err := infra.FreeDiskSpace(path)
+		if err != nil {
+			return err
+		}
+		if free < minFree {
+			return fmt.Errorf("%d bytes free at %s, expected %d", free, path, minFree)
+		}
+		return nil
+	}}
+}
+
+// SchedulerHealthCheck fails when the jobs are not scheduled
+func SchedulerHealthCheck(jobService JobService) HealthCheck {
+	return HealthCheck{Name: "scheduler", Check: func(ctx context.Context) error {
+		if !jobService.Started() {
+			return fmt.Errorf("scheduler is not started")
+		}
+		return nil
+	}}
+}
+
+// QueueHealthCheck fails when messages of the queue, webhooks or notifications, are pending for
+// longer than maxDelay after they were due
+func QueueHealthCheck(healthRepository repository.HealthRepository, queue string, maxDelay time.Duration) HealthCheck {
+	return HealthCheck{Name: queue + "_queue", Check: func(ctx context.Context) error {
+		total, err := healthRepository.CountOverdue(ctx, queue, time.Now().Add(-maxDelay))
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			return fmt.Errorf("%d %s overdue for more than %s", total, queue, maxDelay)
+		}
+		return nil
+	}}
+}
@@ -10,3 +10,15 @@ const (
 type HealthResponse struct {
 	Status HealthStatus `json:"status"`
 }
+
+type HealthCheckResult struct {
+	Name      string       `json:"name" example:"database"`
+	Status    HealthStatus `json:"status" example:"up"`
+	LatencyMs int64        `json:"latency_ms" example:"2"`
+	Error     string       `json:"error,omitempty" example:""`
+}
+
+type ReadinessResponse struct {
+	Status HealthStatus        `json:"status" example:"up"`
+	Checks []HealthCheckResult `json:"checks"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -47,3 +48,135 @@ func Test_HealthService_Ping(t *testing.T) {
 		})
 	}
 }
+
+func Test_HealthService_Ready(t *testing.T) {
+	cases := map[string]struct {
+		inputChecks    []service.HealthCheck
+		expectedStatus service.HealthStatus
+		expectedChecks []service.HealthStatus
+		expectedErrors []string
+	}{
+		"should return up when all checks pass": {
+			inputChecks: []service.HealthCheck{
+				{Name: "database", Check: func(ctx context.Context) error { return nil }},
+				{Name: "disk", Check: func(ctx context.Context) error { return nil }},
+			},
+			expectedStatus: service.HealthStatusUp,
+			expectedChecks: []service.HealthStatus{service.HealthStatusUp, service.HealthStatusUp},
+			expectedErrors: []string{"", ""},
+		},
+		"should return down when a check fails": {
+			inputChecks: []service.HealthCheck{
+				{Name: "database", Check: func(ctx context.Context) error { return nil }},
+				{Name: "disk", Check: func(ctx context.Context) error { return fmt.Errorf("error") }},
+			},
+			expectedStatus: service.HealthStatusDown,
+			expectedChecks: []service.HealthStatus{service.HealthStatusUp, service.HealthStatusDown},
+			expectedErrors: []string{"", "error"},
+		},
+		"should return down when a check times out": {
+			inputChecks: []service.HealthCheck{
+				{Name: "database", Check: func(ctx context.Context) error { time.Sleep(time.Second); return nil }},
+			},
+			expectedStatus: service.HealthStatusDown,
+			expectedChecks: []service.HealthStatus{service.HealthStatusDown},
+			expectedErrors: []string{"timed out: context deadline exceeded"},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			impl := &service.HealthServiceImpl{Checks: cs.inputChecks, Timeout: 50 * time.Millisecond}
+
+			// when
+			res := impl.Ready(context.Background())
+
+			// then
+			assert.Equal(t, cs.expectedStatus, res.Status)
+			for i, check := range res.Checks {
+				assert.Equal(t, cs.inputChecks[i].Name, check.Name)
+				assert.Equal(t, cs.expectedChecks[i], check.Status)
+				assert.Equal(t, cs.expectedErrors[i], check.Error)
+			}
+		})
+	}
+}
+
+func Test_MigrationHealthCheck(t *testing.T) {
+	cases := map[string]struct {
+		expectedErr error
+		prepareMock func(mockHealthRepository *mock.MockHealthRepository)
+	}{
+		"should pass when database is at version": {
+			prepareMock: func(mockHealthRepository *mock.MockHealthRepository) {
+				mockHealthRepository.EXPECT().MigrationVersion(gomock.Any()).Return(14, false, nil)
+			},
+		},
+		"should fail when database is behind": {
+			expectedErr: fmt.Errorf("database is at migration 13, expected 14"),
+			prepareMock: func(mockHealthRepository *mock.MockHealthRepository) {
+				mockHealthRepository.EXPECT().MigrationVersion(gomock.Any()).Return(13, false, nil)
+			},
+		},
+		"should fail when migration is dirty": {
+			expectedErr: fmt.Errorf("migration 14 is dirty"),
+			prepareMock: func(mockHealthRepository *mock.MockHealthRepository) {
+				mockHealthRepository.EXPECT().MigrationVersion(gomock.Any()).Return(14, true, nil)
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+			mockHealthRepository := mock.NewMockHealthRepository(ctrl)
+			cs.prepareMock(mockHealthRepository)
+
+			impl := service.MigrationHealthCheck(mockHealthRepository, 14)
+
+			// when
+			err := impl.Check(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_QueueHealthCheck(t *testing.T) {
+	cases := map[string]struct {
+		expectedErr error
+		prepareMock func(mockHealthRepository *mock.MockHealthRepository)
+	}{
+		"should pass when nothing is overdue": {
+			prepareMock: func(mockHealthRepository *mock.MockHealthRepository) {
+				mockHealthRepository.EXPECT().CountOverdue(gomock.Any(), "webhooks", gomock.Any()).Return(0, nil)
+			},
+		},
+		"should fail when messages are overdue": {
+			expectedErr: fmt.Errorf("3 webhooks overdue for more than 15m0s"),
+			prepareMock: func(mockHealthRepository *mock.MockHealthRepository) {
+				mockHealthRepository.EXPECT().CountOverdue(gomock.Any(), "webhooks", gomock.Any()).Return(3, nil)
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+			mockHealthRepository := mock.NewMockHealthRepository(ctrl)
+			cs.prepareMock(mockHealthRepository)
+
+			impl := service.QueueHealthCheck(mockHealthRepository, "webhooks", 15*time.Minute)
+
+			// when
+			err := impl.Check(ctx)
+
+			// then
+			assert.Equal(t, "webhooks_queue", impl.Name)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+//go:generate mockgen -destination ../../mock/idempotency_service_mock.go -package mock . IdempotencyService
+type IdempotencyService interface {
+	Begin(ctx context.Context, key, requestHash string) (*model.IdempotencyKey, error)
+	Complete(ctx context.Context, key string, status int, contentType string, body []byte) error
+	Release(ctx context.Context, key string) error
+	Purge(ctx context.Context) (int, error)
+}
+
+// IdempotencyServiceImpl keeps the responses for TTL. A key in progress is reserved only for Lease,
+// so one left by a crashed process can be retried once it ends.
+type IdempotencyServiceImpl struct {
+	IdempotencyRepository repository.IdempotencyRepository
+	TTL                   time.Duration
+	Lease                 time.Duration
+}
+
+// Begin reserves the key for a new request. When the key was already used by the
+// same request, the stored response is returned to be replayed.
+func (impl *IdempotencyServiceImpl) Begin(ctx context.Context, key, requestHash string) (*model.IdempotencyKey, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.idempotency.begin")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.idempotency.begin"})
+
+	data, err := impl.IdempotencyRepository.FindOneByKey(ctx, key)
+	if err != nil {
+		if _, ok := err.(*exception.NotFoundException); !ok {
+			log.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	if data != nil {
+		if data.RequestHash != requestHash {
+			return nil, &exception.PayloadMismatchException{
+				Code: "idempotency_key.payload_mismatch",
+				Err:  fmt.Errorf("idempotency key %s was used with a different request", key),
+			}
+		}
+		if data.Status == 0 {
+			return nil, &exception.ConflictException{
+				Code: "idempotency_key.in_progress",
+				Err:  fmt.Errorf("request with idempotency key %s is in progress", key),
+			}
+		}
+
+		return data, nil
+	}
+
+	now := time.Now()
+	if err := impl.IdempotencyRepository.Create(ctx, model.IdempotencyKey{
+		Key:         key,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(impl.Lease),
+		RequestHash: requestHash,
+	}); err != nil {
+		if _, ok := err.(*exception.ConflictException); ok {
+			return nil, &exception.ConflictException{
+				Code: "idempotency_key.in_progress",
+				Err:  fmt.Errorf("request with idempotency key %s is in progress", key),
+			}
+		}
+
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (impl *IdempotencyServiceImpl) Complete(ctx context.Context, key string, status int, contentType string, body []byte) error {
+	ctx, span := infra.StartSpan(ctx, "internal.service.idempotency.complete")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.idempotency.complete"})
+
+	if err := impl.IdempotencyRepository.Update(ctx, model.IdempotencyKey{
+		Key:         key,
+		ExpiresAt:   time.Now().Add(impl.TTL),
+		Status:      status,
+		ContentType: contentType,
+		Body:        body,
+	}); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (impl *IdempotencyServiceImpl) Release(ctx context.Context, key string) error {
+	ctx, span := infra.StartSpan(ctx, "internal.service.idempotency.release")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.idempotency.release"})
+
+	if err := impl.IdempotencyRepository.Delete(ctx, key); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Purge deletes the expired keys, returning how many were deleted
+func (impl *IdempotencyServiceImpl) Purge(ctx context.Context) (int, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.idempotency.purge")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.idempotency.purge"})
+
+	total, err := impl.IdempotencyRepository.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		log.Error(err.Error())
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_IdempotencyService_Begin(t *testing.T) {
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		inputKey    string
+		inputHash   string
+		expectedRes *model.IdempotencyKey
+		expectedErr error
+		prepareMock func(mockIdempotencyRepository *mock.MockIdempotencyRepository)
+	}{
+		"should reserve key when not exists": {
+			inputKey:  "key",
+			inputHash: "hash",
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().FindOneByKey(gomock.Any(), "key").
+					Return(nil, &exception.NotFoundException{Code: "idempotency_key.not_found", Err: fmt.Errorf("idempotency key key not found")})
+				mockIdempotencyRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data model.IdempotencyKey) error {
+						assert.Equal(t, "hash", data.RequestHash)
+						assert.WithinDuration(t, time.Now().Add(time.Minute), data.ExpiresAt, time.Second)
+						return nil
+					})
+			},
+		},
+		"should return stored response when request is the same": {
+			inputKey:  "key",
+			inputHash: "hash",
+			expectedRes: &model.IdempotencyKey{Key: "key", CreatedAt: DATETIME, ExpiresAt: DATETIME,
+				RequestHash: "hash", Status: 201, ContentType: "application/json", Body: []byte("{}")},
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().FindOneByKey(gomock.Any(), "key").
+					Return(&model.IdempotencyKey{Key: "key", CreatedAt: DATETIME, ExpiresAt: DATETIME,
+						RequestHash: "hash", Status: 201, ContentType: "application/json", Body: []byte("{}")}, nil)
+			},
+		},
+		"should throw payload mismatch exception when request is different": {
+			inputKey:    "key",
+			inputHash:   "other",
+			expectedErr: &exception.PayloadMismatchException{Code: "idempotency_key.payload_mismatch", Err: fmt.Errorf("idempotency key key was used with a different request")},
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().FindOneByKey(gomock.Any(), "key").
+					Return(&model.IdempotencyKey{Key: "key", RequestHash: "hash", Status: 201}, nil)
+			},
+		},
+		"should throw conflict exception when request is in progress": {
+			inputKey:    "key",
+			inputHash:   "hash",
+			expectedErr: &exception.ConflictException{Code: "idempotency_key.in_progress", Err: fmt.Errorf("request with idempotency key key is in progress")},
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().FindOneByKey(gomock.Any(), "key").
+					Return(&model.IdempotencyKey{Key: "key", RequestHash: "hash"}, nil)
+			},
+		},
+		"should throw conflict exception when key was reserved concurrently": {
+			inputKey:    "key",
+			inputHash:   "hash",
+			expectedErr: &exception.ConflictException{Code: "idempotency_key.in_progress", Err: fmt.Errorf("request with idempotency key key is in progress")},
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().FindOneByKey(gomock.Any(), "key").
+					Return(nil, &exception.NotFoundException{Code: "idempotency_key.not_found", Err: fmt.Errorf("idempotency key key not found")})
+				mockIdempotencyRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(&exception.ConflictException{Code: "idempotency_key.exists", Err: fmt.Errorf("idempotency key key already exists")})
+			},
+		},
+		"should throw error": {
+			inputKey:    "key",
+			inputHash:   "hash",
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().FindOneByKey(gomock.Any(), "key").Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockIdempotencyRepository := mock.NewMockIdempotencyRepository(ctrl)
+			cs.prepareMock(mockIdempotencyRepository)
+
+			impl := &service.IdempotencyServiceImpl{IdempotencyRepository: mockIdempotencyRepository, TTL: time.Hour,
+				Lease: time.Minute}
+
+			// when
+			res, err := impl.Begin(ctx, cs.inputKey, cs.inputHash)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_IdempotencyService_Complete(t *testing.T) {
+	cases := map[string]struct {
+		expectedErr error
+		prepareMock func(mockIdempotencyRepository *mock.MockIdempotencyRepository)
+	}{
+		"should store response": {
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data model.IdempotencyKey) error {
+						assert.Equal(t, model.IdempotencyKey{Key: "key", Status: 204, ContentType: "", Body: []byte{}},
+							model.IdempotencyKey{Key: data.Key, Status: data.Status, ContentType: data.ContentType, Body: data.Body})
+						assert.WithinDuration(t, time.Now().Add(time.Hour), data.ExpiresAt, time.Second)
+						return nil
+					})
+			},
+		},
+		"should throw error": {
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockIdempotencyRepository := mock.NewMockIdempotencyRepository(ctrl)
+			cs.prepareMock(mockIdempotencyRepository)
+
+			impl := &service.IdempotencyServiceImpl{IdempotencyRepository: mockIdempotencyRepository, TTL: time.Hour}
+
+			// when
+			err := impl.Complete(ctx, "key", 204, "", []byte{})
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_IdempotencyService_Release(t *testing.T) {
+	cases := map[string]struct {
+		expectedErr error
+		prepareMock func(mockIdempotencyRepository *mock.MockIdempotencyRepository)
+	}{
+		"should release key": {
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().Delete(gomock.Any(), "key").Return(nil)
+			},
+		},
+		"should throw error": {
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockIdempotencyRepository *mock.MockIdempotencyRepository) {
+				mockIdempotencyRepository.EXPECT().Delete(gomock.Any(), "key").Return(fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockIdempotencyRepository := mock.NewMockIdempotencyRepository(ctrl)
+			cs.prepareMock(mockIdempotencyRepository)
+
+			impl := &service.IdempotencyServiceImpl{IdempotencyRepository: mockIdempotencyRepository}
+
+			// when
+			err := impl.Release(ctx, "key")
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+// importColumns are the accepted header names. Rows with the same family value belong
+// to the same family, which takes its fields from its first row; rows without it are
+// families of their own.
+var importColumns = []string{"family", "name", "country", "state", "city", "neighborhood",
+	"street", "number", "complement", "zipcode", "person_name", "person_document"}
+
+// importValidator checks the rows with the binding rules of the create dtos
+var importValidator = newImportValidator()
+
+func newImportValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("json"), ",")[0]
+	})
+
+	return v
+}
+
+//go:generate mockgen -destination ../../mock/import_service_mock.go -package mock . ImportService
+type ImportService interface {
+	Import(ctx context.Context, dto ImportCreateDto) (ImportResponse, error)
+	FindOneById(ctx context.Context, importID int) (ImportResponse, error)
+}
+
+type ImportServiceImpl struct {
+	ImportRepository repository.ImportRepository
+	Webhooks         WebhookPublisher
+}
+
+// Import validates every row and saves the families and persons only when no row has errors
+// and it is not a dry run, publishing family.created for each family. The import is recorded in
+// every case.
+func (impl *ImportServiceImpl) Import(ctx context.Context, dto ImportCreateDto) (ImportResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.import.import")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.import.import"})
+
+	columns := map[string]int{}
+	if len(dto.Rows) > 0 {
+		for i, name := range dto.Rows[0] {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+	}
+
+	known := false
+	for _, name := range importColumns {
+		if _, ok := columns[name]; ok {
+			known = true
+		}
+	}
+	if !known {
+		err := &exception.InvalidFileException{
+			Err: fmt.Errorf("the first row must have the columns %s", strings.Join(importColumns, ", ")),
+		}
+		log.Error(err.Error())
+		return ImportResponse{}, err
+	}
+
+	data := model.Import{Filename: dto.Filename, DryRun: dto.DryRun, Errors: []model.ImportRowError{}}
+	families := []model.Family{}
+	familyIndex := map[string]int{}
+
+	for i, row := range dto.Rows[1:] {
+		line := i + 2
+		value := func(name string) string {
+			if c, ok := columns[name]; ok && c < len(row) {
+				return strings.TrimSpace(row[c])
+			}
+			return ""
+		}
+
+		if isEmptyRow(row) {
+			continue
+		}
+		data.Rows++
+
+		key := value("family")
+		index, ok := familyIndex[key]
+		if !ok || key == "" {
+			family := FamilyCreateDto{
+				Name:         value("name"),
+				Country:      value("country"),
+				State:        value("state"),
+				City:         value("city"),
+				Neighborhood: value("neighborhood"),
+				Street:       value("street"),
+				Number:       value("number"),
+				Complement:   value("complement"),
+				Zipcode:      value("zipcode"),
+			}
+			data.Errors = append(data.Errors, validateImportRow(line, "", family)...)
+
+			families = append(families, model.Family{
+				Name:         family.Name,
+				Country:      family.Country,
+				State:        family.State,
+				City:         family.City,
+				Neighborhood: family.Neighborhood,
+				Street:       family.Street,
+				Number:       family.Number,
+				Complement:   family.Complement,
+				Zipcode:      family.Zipcode,
+				Persons:      []model.Person{},
+			})
+			index = len(families) - 1
+			if key != "" {
+				familyIndex[key] = index
+			}
+		}
+
+		person := PersonCreateDto{Name: value("person_name"), Document: value("person_document")}
+		if person.Name == "" && person.Document == "" {
+			continue
+		}
+		data.Errors = append(data.Errors, validateImportRow(line, "person_", person, "FamilyID")...)
+		families[index].Persons = append(families[index].Persons, model.Person{
+			Name:     person.Name,
+			Document: person.Document,
+		})
+	}
+
+	data.Families = len(families)
+	for _, family := range families {
+		data.Persons += len(family.Persons)
+	}
+
+	switch {
+	case len(data.Errors) > 0:
+		data.Status = model.ImportStatusInvalid
+		families = nil
+	case dto.DryRun:
+		data.Status = model.ImportStatusValidated
+		families = nil
+	default:
+		data.Status = model.ImportStatusCompleted
+	}
+
+	res, err := impl.ImportRepository.Create(ctx, data, families)
+	if err != nil {
+		log.Error(err.Error())
+
+		data.Status = model.ImportStatusFailed
+		if _, e := impl.ImportRepository.Create(ctx, data, nil); e != nil {
+			log.Error(e.Error())
+		}
+		return ImportResponse{}, err
+	}
+
+	for _, family := range families {
+		publishFamilyCreated(ctx, impl.Webhooks, family)
+	}
+
+	return ImportResponse{Data: newImport(*res)}, nil
+}
+
+func (impl *ImportServiceImpl) FindOneById(ctx context.Context, importID int) (ImportResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.import.find_one_by_id")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.import.find_one_by_id"})
+
+	data, err := impl.ImportRepository.FindOneById(ctx, importID)
+	if err != nil {
+		log.Error(err.Error())
+		return ImportResponse{}, err
+	}
+
+	return ImportResponse{Data: newImport(*data)}, nil
+}
+
+func validateImportRow(line int, prefix string, dto interface{}, except ...string) []model.ImportRowError {
+	var err error
+	if len(except) > 0 {
+		err = importValidator.StructExcept(dto, except...)
+	} else {
+		err = importValidator.Struct(dto)
+	}
+
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+
+	res := []model.ImportRowError{}
+	for _, e := range errs {
+		field := prefix + e.Field()
+		res = append(res, model.ImportRowError{
+			Row:     line,
+			Field:   field,
+			Message: fmt.Sprintf("%s is %s", field, e.Tag()),
+		})
+	}
+
+	return res
+}
+
+func isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newImport(data model.Import) *Import {
+	res := &Import{
+		ID:        data.ID,
+		CreatedAt: data.CreatedAt.Format("2006-01-02T15:04:05"),
+		Filename:  data.Filename,
+		DryRun:    data.DryRun,
+		Status:    string(data.Status),
+		Rows:      data.Rows,
+		Families:  data.Families,
+		Persons:   data.Persons,
+		Errors:    []ImportRowError{},
+	}
+
+	for _, e := range data.Errors {
+		res.Errors = append(res.Errors, ImportRowError{Row: e.Row, Field: e.Field, Message: e.Message})
+	}
+
+	return res
+}
@@ -0,0 +1,30 @@
+package service
+
+type ImportCreateDto struct {
+	Filename string
+	DryRun   bool
+	// Rows has the header row followed by one row per person, or per family without persons
+	Rows [][]string
+}
+
+type ImportRowError struct {
+	Row     int    `json:"row" example:"2"`
+	Field   string `json:"field" example:"zipcode"`
+	Message string `json:"message" example:"zipcode is required"`
+}
+
+type Import struct {
+	ID        int              `json:"id" example:"1"`
+	CreatedAt string           `json:"created_at" example:"2000-01-01T12:03:00"`
+	Filename  string           `json:"filename" example:"centro.csv"`
+	DryRun    bool             `json:"dry_run" example:"false"`
+	Status    string           `json:"status" example:"completed"`
+	Rows      int              `json:"rows" example:"120"`
+	Families  int              `json:"families" example:"40"`
+	Persons   int              `json:"persons" example:"110"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+type ImportResponse struct {
+	Data *Import `json:"data"`
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_ImportService_Import(t *testing.T) {
+	const DATE = "2000-01-01T12:03:00"
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	HEADER := []string{"family", "name", "country", "state", "city", "neighborhood", "street", "number",
+		"complement", "zipcode", "person_name", "person_document"}
+	FAMILY := model.Family{Name: "Silva", Country: "BR", State: "SP", City: "São Paulo", Neighborhood: "Centro",
+		Street: "Rua São João", Number: "25", Zipcode: "01035000", Persons: []model.Person{
+			{Name: "Maria", Document: "123.456.789-00"},
+			{Name: "José"},
+		}}
+
+	cases := map[string]struct {
+		inputDto    service.ImportCreateDto
+		expectedRes service.ImportResponse
+		expectedErr error
+		prepareMock func(mockImportRepository *mock.MockImportRepository, mockWebhooks *mock.MockWebhookPublisher)
+	}{
+		"should import families grouped by family column and publish them": {
+			inputDto: service.ImportCreateDto{Filename: "centro.csv", Rows: [][]string{
+				HEADER,
+				{"1", "Silva", "BR", "SP", "São Paulo", "Centro", "Rua São João", "25", "", "01035000", "Maria", "123.456.789-00"},
+				{"1", "", "", "", "", "", "", "", "", "", "José", ""},
+				{"", "", "", "", "", "", "", "", "", "", "", ""},
+			}},
+			expectedRes: service.ImportResponse{Data: &service.Import{ID: 1, CreatedAt: DATE, Filename: "centro.csv",
+				Status: "completed", Rows: 2, Families: 1, Persons: 2, Errors: []service.ImportRowError{}}},
+			prepareMock: func(mockImportRepository *mock.MockImportRepository, mockWebhooks *mock.MockWebhookPublisher) {
+				data := model.Import{Filename: "centro.csv", Status: model.ImportStatusCompleted, Rows: 2, Families: 1,
+					Persons: 2, Errors: []model.ImportRowError{}}
+				res := data
+				res.ID = 1
+				res.CreatedAt = DATETIME
+				mockImportRepository.EXPECT().Create(gomock.Any(), data, []model.Family{FAMILY}).DoAndReturn(
+					func(ctx context.Context, data model.Import, families []model.Family) (*model.Import, error) {
+						families[0].ID = 1
+						families[0].CreatedAt = DATETIME
+						families[0].UpdatedAt = DATETIME
+						return &res, nil
+					})
+				mockWebhooks.EXPECT().Publish(gomock.Any(), model.WebhookEventFamilyCreated, service.Family{
+					ID: 1, CreatedAt: DATE, UpdatedAt: DATE, Name: "Silva", Country: "BR", State: "SP", City: "São Paulo",
+					Neighborhood: "Centro", Street: "Rua São João", Number: "25", Zipcode: "01035000",
+				})
+			},
+		},
+		"should only validate rows when dry run": {
+			inputDto: service.ImportCreateDto{Filename: "centro.csv", DryRun: true, Rows: [][]string{
+				HEADER,
+				{"", "Silva", "BR", "SP", "São Paulo", "Centro", "Rua São João", "25", "", "01035000", "", ""},
+			}},
+			expectedRes: service.ImportResponse{Data: &service.Import{ID: 1, CreatedAt: DATE, Filename: "centro.csv",
+				DryRun: true, Status: "validated", Rows: 1, Families: 1, Errors: []service.ImportRowError{}}},
+			prepareMock: func(mockImportRepository *mock.MockImportRepository, mockWebhooks *mock.MockWebhookPublisher) {
+				data := model.Import{Filename: "centro.csv", DryRun: true, Status: model.ImportStatusValidated, Rows: 1,
+					Families: 1, Errors: []model.ImportRowError{}}
+				res := data
+				res.ID = 1
+				res.CreatedAt = DATETIME
+				mockImportRepository.EXPECT().Create(gomock.Any(), data, nil).Return(&res, nil)
+			},
+		},
+		"should return row errors without importing": {
+			inputDto: service.ImportCreateDto{Filename: "centro.csv", Rows: [][]string{
+				HEADER,
+				{"", "Silva", "BR", "SP", "São Paulo", "Centro", "Rua São João", "25", "", "", "", "123"},
+			}},
+			expectedRes: service.ImportResponse{Data: &service.Import{ID: 1, CreatedAt: DATE, Filename: "centro.csv",
+				Status: "invalid", Rows: 1, Families: 1, Persons: 1, Errors: []service.ImportRowError{
+					{Row: 2, Field: "zipcode", Message: "zipcode is required"},
+					{Row: 2, Field: "person_name", Message: "person_name is required"},
+				}}},
+			prepareMock: func(mockImportRepository *mock.MockImportRepository, mockWebhooks *mock.MockWebhookPublisher) {
+				data := model.Import{Filename: "centro.csv", Status: model.ImportStatusInvalid, Rows: 1, Families: 1,
+					Persons: 1, Errors: []model.ImportRowError{
+						{Row: 2, Field: "zipcode", Message: "zipcode is required"},
+						{Row: 2, Field: "person_name", Message: "person_name is required"},
+					}}
+				res := data
+				res.ID = 1
+				res.CreatedAt = DATETIME
+				mockImportRepository.EXPECT().Create(gomock.Any(), data, nil).Return(&res, nil)
+			},
+		},
+		"should throw invalid file exception when header is unknown": {
+			inputDto: service.ImportCreateDto{Filename: "centro.csv", Rows: [][]string{{"nome", "cep"}}},
+			expectedErr: &exception.InvalidFileException{Err: fmt.Errorf("the first row must have the columns " +
+				"family, name, country, state, city, neighborhood, street, number, complement, zipcode, person_name, person_document")},
+			prepareMock: func(mockImportRepository *mock.MockImportRepository, mockWebhooks *mock.MockWebhookPublisher) {},
+		},
+		"should record failed import when saving throws error": {
+			inputDto: service.ImportCreateDto{Filename: "centro.csv", Rows: [][]string{
+				HEADER,
+				{"", "Silva", "BR", "SP", "São Paulo", "Centro", "Rua São João", "25", "", "01035000", "", ""},
+			}},
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockImportRepository *mock.MockImportRepository, mockWebhooks *mock.MockWebhookPublisher) {
+				mockImportRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Len(1)).Return(nil, fmt.Errorf("error"))
+				mockImportRepository.EXPECT().Create(gomock.Any(), model.Import{Filename: "centro.csv",
+					Status: model.ImportStatusFailed, Rows: 1, Families: 1, Errors: []model.ImportRowError{}}, nil).
+					Return(&model.Import{}, nil)
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockImportRepository := mock.NewMockImportRepository(ctrl)
+			mockWebhooks := mock.NewMockWebhookPublisher(ctrl)
+			cs.prepareMock(mockImportRepository, mockWebhooks)
+
+			impl := &service.ImportServiceImpl{ImportRepository: mockImportRepository, Webhooks: mockWebhooks}
+
+			// when
+			res, err := impl.Import(ctx, cs.inputDto)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_ImportService_FindOneByID(t *testing.T) {
+	const DATE = "2000-01-01T12:03:00"
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		inputImportID int
+		expectedRes   service.ImportResponse
+		expectedErr   error
+		prepareMock   func(mockImportRepository *mock.MockImportRepository)
+	}{
+		"should return import when exists": {
+			inputImportID: 1,
+			expectedRes: service.ImportResponse{Data: &service.Import{ID: 1, CreatedAt: DATE, Filename: "centro.csv",
+				Status: "completed", Rows: 1, Families: 1, Errors: []service.ImportRowError{}}},
+			prepareMock: func(mockImportRepository *mock.MockImportRepository) {
+				mockImportRepository.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Import{ID: 1, CreatedAt: DATETIME,
+					Filename: "centro.csv", Status: model.ImportStatusCompleted, Rows: 1, Families: 1}, nil)
+			},
+		},
+		"should throw not found exception when import not exists": {
+			inputImportID: 1,
+			expectedErr:   &exception.NotFoundException{Err: fmt.Errorf("import 1 not found")},
+			prepareMock: func(mockImportRepository *mock.MockImportRepository) {
+				mockImportRepository.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Err: fmt.Errorf("import 1 not found")})
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockImportRepository := mock.NewMockImportRepository(ctrl)
+			cs.prepareMock(mockImportRepository)
+
+			impl := &service.ImportServiceImpl{ImportRepository: mockImportRepository}
+
+			// when
+			res, err := impl.FindOneById(ctx, cs.inputImportID)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScheduledJob is a recurring task run on its cron Schedule, or only when triggered without one
+type ScheduledJob struct {
+	Name     string
+	Schedule string
+	Run      func(ctx context.Context) error
+}
+
+//go:generate mockgen -destination ../../mock/job_service_mock.go -package mock . JobService
+type JobService interface {
+	Start(ctx context.Context) error
+	Started() bool
+	Wait()
+	FindAll(ctx context.Context) (JobsResponse, error)
+	FindRuns(ctx context.Context, job string, limit int) (JobRunsResponse, error)
+	Trigger(ctx context.Context, job string) (JobRunResponse, error)
+	Purge(ctx context.Context) (int, error)
+}
+
+type JobServiceImpl struct {
+	JobRepository repository.JobRepository
+	Jobs          []ScheduledJob
+	// Owner identifies the replica in the leases and in the history of runs
+	Owner string
+	// Timeout cancels a run, its lease lasts a minute longer in case the replica dies
+	Timeout  time.Duration
+	Location *time.Location
+	// Retention is how long the history of runs is kept
+	Retention time.Duration
+
+	// ctx is the one of Start, cancelled by the shutdown, on which the triggered runs are based too
+	ctx     context.Context
+	cron    *cron.Cron
+	entries map[string]cron.EntryID
+	running sync.WaitGroup
+	started int32
+}
+
+// Start schedules the jobs until ctx is done. Each run takes the lease of its job first,
+// so the replicas skip the jobs another one is running.
+func (impl *JobServiceImpl) Start(ctx context.Context) error {
+	location := impl.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	impl.ctx = ctx
+	impl.cron = cron.New(cron.WithLocation(location))
+	impl.entries = map[string]cron.EntryID{}
+
+	for _, job := range impl.Jobs {
+		if job.Schedule == "" {
+			continue
+		}
+
+		job := job
+		id, err := impl.cron.AddFunc(job.Schedule, func() {
+			// each scheduled run is a trace of its own
+			ctx, span := infra.StartSpan(ctx, "job "+job.Name, trace.WithNewRoot())
+			defer span.End()
+
+			run, err := impl.begin(ctx, job, model.JobTriggeredBySchedule)
+			if err != nil {
+				return
+			}
+			impl.execute(ctx, job, *run)
+		})
+		if err != nil {
+			return fmt.Errorf("invalid schedule %q of job %s: %w", job.Schedule, job.Name, err)
+		}
+		impl.entries[job.Name] = id
+	}
+
+	impl.cron.Start()
+	atomic.StoreInt32(&impl.started, 1)
+	go func() {
+		<-ctx.Done()
+		atomic.StoreInt32(&impl.started, 0)
+		impl.cron.Stop()
+	}()
+
+	return nil
+}
+
+// Started tells whether the jobs are scheduled, from Start until its ctx is done
+func (impl *JobServiceImpl) Started() bool {
+	return atomic.LoadInt32(&impl.started) == 1
+}
+
+// Wait blocks until the runs in progress finish
+func (impl *JobServiceImpl) Wait() {
+	impl.running.Wait()
+}
+
+func (impl *JobServiceImpl) FindAll(ctx context.Context) (JobsResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.job.find_all")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.job.find_all"})
+
+	res := []Job{}
+	for _, job := range impl.Jobs {
+		runs, err := impl.JobRepository.FindRuns(ctx, job.Name, 1)
+		if err != nil {
+			log.Error(err.Error())
+			return JobsResponse{}, err
+		}
+
+		data := Job{Name: job.Name, Schedule: job.Schedule}
+		if len(runs) > 0 {
+			data.LastRun = impl.Scan(runs[0])
+		}
+		if id, ok := impl.entries[job.Name]; ok && impl.cron != nil {
+			if next := impl.cron.Entry(id).Next; !next.IsZero() {
+				data.NextRunAt = next.Format("2006-01-02T15:04:05")
+			}
+		}
+
+		res = append(res, data)
+	}
+
+	return JobsResponse{Data: res}, nil
+}
+
+func (impl *JobServiceImpl) FindRuns(ctx context.Context, job string, limit int) (JobRunsResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.job.find_runs")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.job.find_runs"})
+
+	if _, err := impl.find(job); err != nil {
+		log.Error(err.Error())
+		return JobRunsResponse{}, err
+	}
+
+	runs, err := impl.JobRepository.FindRuns(ctx, job, limit)
+	if err != nil {
+		log.Error(err.Error())
+		return JobRunsResponse{}, err
+	}
+
+	res := []JobRun{}
+	for _, run := range runs {
+		res = append(res, *impl.Scan(run))
+	}
+
+	return JobRunsResponse{Data: res}, nil
+}
+
+// Trigger runs the job in background now, unless a replica is already running it
+func (impl *JobServiceImpl) Trigger(ctx context.Context, job string) (JobRunResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.job.trigger")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.job.trigger"})
+
+	scheduled, err := impl.find(job)
+	if err != nil {
+		log.Error(err.Error())
+		return JobRunResponse{}, err
+	}
+
+	run, err := impl.begin(ctx, *scheduled, model.JobTriggeredByManual)
+	if err != nil {
+		return JobRunResponse{}, err
+	}
+
+	// the run outlives the request, but stays in its trace, and is cancelled with the scheduled ones
+	runCtx := impl.ctx
+	if runCtx == nil {
+		runCtx = context.Background()
+	}
+	go impl.execute(trace.ContextWithSpan(runCtx, span), *scheduled, *run)
+
+	return JobRunResponse{Data: impl.Scan(*run)}, nil
+}
+
+// Purge deletes the runs older than the retention, returning how many were deleted
+func (impl *JobServiceImpl) Purge(ctx context.Context) (int, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.job.purge")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.job.purge"})
+
+	total, err := impl.JobRepository.DeleteRuns(ctx, time.Now().Add(-impl.Retention))
+	if err != nil {
+		log.Error(err.Error())
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (impl *JobServiceImpl) find(job string) (*ScheduledJob, error) {
+	for _, scheduled := range impl.Jobs {
+		if scheduled.Name == job {
+			return &scheduled, nil
+		}
+	}
+
+	return nil, &exception.NotFoundException{Code: "job.not_found", Err: fmt.Errorf("job %s not found", job)}
+}
+
+// begin takes the lease of the job and records its run
+func (impl *JobServiceImpl) begin(ctx context.Context, job ScheduledJob, triggeredBy string) (*model.JobRun, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.job.begin")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.job.begin",
+		"job": job.Name})
+
+	acquired, err := impl.JobRepository.Acquire(ctx, job.Name, impl.Owner, impl.Timeout+time.Minute)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	if !acquired {
+		err := &exception.ConflictException{Code: "job.already_running", Err: fmt.Errorf("job %s is already running", job.Name)}
+		log.Debug(err.Error())
+		return nil, err
+	}
+
+	impl.running.Add(1)
+
+	run, err := impl.JobRepository.CreateRun(ctx, model.JobRun{
+		Job:         job.Name,
+		TriggeredBy: triggeredBy,
+		Owner:       impl.Owner,
+		Status:      model.JobRunRunning,
+		StartedAt:   time.Now(),
+	})
+	if err != nil {
+		log.Error(err.Error())
+		impl.JobRepository.Release(ctx, job.Name, impl.Owner)
+		impl.running.Done()
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// execute runs the job begun, records how it went and releases its lease
+func (impl *JobServiceImpl) execute(ctx context.Context, job ScheduledJob, run model.JobRun) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.job.execute")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.job.execute",
+		"job": job.Name, "run_id": run.ID})
+	defer impl.running.Done()
+
+	err := impl.call(ctx, job)
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = finishedAt.Sub(run.StartedAt).Milliseconds()
+	run.Status = model.JobRunSucceeded
+	if err != nil {
+		log.Error(err.Error())
+		run.Status = model.JobRunFailed
+		run.Error = truncate(err.Error(), 1000)
+	}
+
+	// the run is recorded even when ctx was cancelled by the shutdown
+	ctx = trace.ContextWithSpan(context.Background(), span)
+	if err := impl.JobRepository.FinishRun(ctx, run); err != nil {
+		log.Error(err.Error())
+	}
+	if err := impl.JobRepository.Release(ctx, job.Name, impl.Owner); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+func (impl *JobServiceImpl) call(ctx context.Context, job ScheduledJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if impl.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, impl.Timeout)
+		defer cancel()
+	}
+
+	return job.Run(ctx)
+}
+
+// truncate cuts s to at most max bytes, on a rune boundary so the text stays valid UTF-8
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+
+	return s[:max]
+}
+
+func (impl *JobServiceImpl) Scan(data model.JobRun) *JobRun {
+	finishedAt := ""
+	if data.FinishedAt != nil {
+		finishedAt = data.FinishedAt.Format("2006-01-02T15:04:05")
+	}
+
+	return &JobRun{
+		ID:          data.ID,
+		Job:         data.Job,
+		TriggeredBy: data.TriggeredBy,
+		Owner:       data.Owner,
+		Status:      data.Status,
+		StartedAt:   data.StartedAt.Format("2006-01-02T15:04:05"),
+		FinishedAt:  finishedAt,
+		DurationMs:  data.DurationMs,
+		Error:       data.Error,
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+type JobRun struct {
+	ID          int    `json:"id" example:"1"`
+	Job         string `json:"job" example:"purge_idempotency_keys"`
+	TriggeredBy string `json:"triggered_by" example:"schedule"`
+	Owner       string `json:"owner" example:"api-7d9f-1"`
+	Status      string `json:"status" example:"succeeded"`
+	StartedAt   string `json:"started_at" example:"2000-01-01T12:03:00"`
+	FinishedAt  string `json:"finished_at" example:"2000-01-01T12:03:01"`
+	DurationMs  int64  `json:"duration_ms" example:"120"`
+	Error       string `json:"error" example:""`
+}
+
+type Job struct {
+	Name string `json:"name" example:"purge_idempotency_keys"`
+	// Schedule is the cron expression, jobs without one only run when triggered
+	Schedule  string  `json:"schedule" example:"0 * * * *"`
+	NextRunAt string  `json:"next_run_at" example:"2000-01-01T13:00:00"`
+	LastRun   *JobRun `json:"last_run"`
+}
+
+type JobsResponse struct {
+	Data []Job `json:"data"`
+}
+
+type JobRunResponse struct {
+	Data *JobRun `json:"data"`
+}
+
+type JobRunsResponse struct {
+	Data []JobRun `json:"data"`
+}
@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_JobService_Trigger(t *testing.T) {
+	cases := map[string]struct {
+		inputJob       string
+		inputRun       func(ctx context.Context) error
+		expectedErr    error
+		expectedStatus string
+		expectedError  string
+		prepareMock    func(mock *mock.MockJobRepository)
+	}{
+		"should run job and record it succeeded": {
+			inputJob:       "purge",
+			inputRun:       func(ctx context.Context) error { return nil },
+			expectedStatus: model.JobRunSucceeded,
+			prepareMock: func(mock *mock.MockJobRepository) {
+				mock.EXPECT().Acquire(gomock.Any(), "purge", "replica-1", time.Minute+time.Second).Return(true, nil)
+				mock.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data model.JobRun) (*model.JobRun, error) {
+						data.ID = 1
+						return &data, nil
+					})
+				mock.EXPECT().Release(gomock.Any(), "purge", "replica-1").Return(nil)
+			},
+		},
+		"should record the error of the job": {
+			inputJob:       "purge",
+			inputRun:       func(ctx context.Context) error { return fmt.Errorf("error") },
+			expectedStatus: model.JobRunFailed,
+			expectedError:  "error",
+			prepareMock: func(mock *mock.MockJobRepository) {
+				mock.EXPECT().Acquire(gomock.Any(), "purge", "replica-1", gomock.Any()).Return(true, nil)
+				mock.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data model.JobRun) (*model.JobRun, error) {
+						data.ID = 1
+						return &data, nil
+					})
+				mock.EXPECT().Release(gomock.Any(), "purge", "replica-1").Return(nil)
+			},
+		},
+		"should cut long error on a rune boundary": {
+			inputJob:       "purge",
+			inputRun:       func(ctx context.Context) error { return fmt.Errorf("a%s", strings.Repeat("é", 600)) },
+			expectedStatus: model.JobRunFailed,
+			expectedError:  "a" + strings.Repeat("é", 499),
+			prepareMock: func(mock *mock.MockJobRepository) {
+				mock.EXPECT().Acquire(gomock.Any(), "purge", "replica-1", gomock.Any()).Return(true, nil)
+				mock.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data model.JobRun) (*model.JobRun, error) {
+						data.ID = 1
+						return &data, nil
+					})
+				mock.EXPECT().Release(gomock.Any(), "purge", "replica-1").Return(nil)
+			},
+		},
+		"should record the panic of the job": {
+			inputJob:       "purge",
+			inputRun:       func(ctx context.Context) error { panic("boom") },
+			expectedStatus: model.JobRunFailed,
+			expectedError:  "panic: boom",
+			prepareMock: func(mock *mock.MockJobRepository) {
+				mock.EXPECT().Acquire(gomock.Any(), "purge", "replica-1", gomock.Any()).Return(true, nil)
+				mock.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data model.JobRun) (*model.JobRun, error) {
+						data.ID = 1
+						return &data, nil
+					})
+				mock.EXPECT().Release(gomock.Any(), "purge", "replica-1").Return(nil)
+			},
+		},
+		"should throw conflict when another replica holds the lease": {
+			inputJob:    "purge",
+			expectedErr: &exception.ConflictException{Code: "job.already_running", Err: fmt.Errorf("job purge is already running")},
+			prepareMock: func(mock *mock.MockJobRepository) {
+				mock.EXPECT().Acquire(gomock.Any(), "purge", "replica-1", gomock.Any()).Return(false, nil)
+			},
+		},
+		"should throw not found when job does not exist": {
+			inputJob:    "report",
+			expectedErr: &exception.NotFoundException{Code: "job.not_found", Err: fmt.Errorf("job report not found")},
+			prepareMock: func(mock *mock.MockJobRepository) {},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockJobRepository := mock.NewMockJobRepository(ctrl)
+			cs.prepareMock(mockJobRepository)
+
+			var finished model.JobRun
+			mockJobRepository.EXPECT().FinishRun(gomock.Any(), gomock.Any()).
+				Do(func(ctx context.Context, data model.JobRun) { finished = data }).Return(nil).MaxTimes(1)
+
+			impl := &service.JobServiceImpl{
+				JobRepository: mockJobRepository,
+				Jobs:          []service.ScheduledJob{{Name: "purge", Schedule: "0 * * * *", Run: cs.inputRun}},
+				Owner:         "replica-1",
+				Timeout:       time.Second,
+			}
+
+			// when
+			res, err := impl.Trigger(ctx, cs.inputJob)
+			impl.Wait()
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErr == nil {
+				assert.Equal(t, model.JobRunRunning, res.Data.Status)
+				assert.Equal(t, model.JobTriggeredByManual, res.Data.TriggeredBy)
+				assert.Equal(t, 1, finished.ID)
+				assert.Equal(t, cs.expectedStatus, finished.Status)
+				assert.Equal(t, cs.expectedError, finished.Error)
+				assert.NotNil(t, finished.FinishedAt)
+			}
+		})
+	}
+}
+
+func Test_JobService_Trigger_Shutdown(t *testing.T) {
+	t.Run("should cancel triggered run with the scheduler", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockJobRepository := mock.NewMockJobRepository(ctrl)
+		mockJobRepository.EXPECT().Acquire(gomock.Any(), "purge", "replica-1", gomock.Any()).Return(true, nil)
+		mockJobRepository.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, data model.JobRun) (*model.JobRun, error) {
+				data.ID = 1
+				return &data, nil
+			})
+		mockJobRepository.EXPECT().Release(gomock.Any(), "purge", "replica-1").Return(nil)
+
+		var finished model.JobRun
+		mockJobRepository.EXPECT().FinishRun(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, data model.JobRun) { finished = data }).Return(nil)
+
+		started := make(chan struct{})
+		impl := &service.JobServiceImpl{
+			JobRepository: mockJobRepository,
+			Jobs: []service.ScheduledJob{{Name: "purge", Run: func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}}},
+			Owner:   "replica-1",
+			Timeout: time.Minute,
+		}
+		assert.Nil(t, impl.Start(ctx))
+
+		// when
+		_, err := impl.Trigger(context.Background(), "purge")
+		<-started
+		cancel()
+		impl.Wait()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, model.JobRunFailed, finished.Status)
+		assert.Equal(t, context.Canceled.Error(), finished.Error)
+	})
+}
+
+func Test_JobService_Start(t *testing.T) {
+	cases := map[string]struct {
+		inputSchedule string
+		expectedErr   bool
+	}{
+		"should schedule job": {
+			inputSchedule: "30 3 * * *",
+		},
+		"should not schedule job without schedule": {
+			inputSchedule: "",
+		},
+		"should throw error when schedule is invalid": {
+			inputSchedule: "every day",
+			expectedErr:   true,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockJobRepository := mock.NewMockJobRepository(ctrl)
+			mockJobRepository.EXPECT().FindRuns(gomock.Any(), "purge", 1).Return([]model.JobRun{}, nil).AnyTimes()
+
+			impl := &service.JobServiceImpl{
+				JobRepository: mockJobRepository,
+				Jobs: []service.ScheduledJob{{Name: "purge", Schedule: cs.inputSchedule,
+					Run: func(ctx context.Context) error { return nil }}},
+				Location: time.UTC,
+			}
+
+			// when
+			err := impl.Start(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err != nil)
+			if !cs.expectedErr {
+				res, err := impl.FindAll(ctx)
+				assert.Nil(t, err)
+				assert.Equal(t, cs.inputSchedule != "", res.Data[0].NextRunAt != "")
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+var resourceQuantityDesc = prometheus.NewDesc(
+	"socialassistance_resource_quantity",
+	"Quantity in stock of each resource.",
+	[]string{"resource_id", "name", "measurement"}, nil,
+)
+
+// StockCollector reads the stock of the resources on every scrape
+type StockCollector struct {
+	ResourceRepository repository.ResourceRepository
+	Timeout            time.Duration
+}
+
+func (impl *StockCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resourceQuantityDesc
+}
+
+func (impl *StockCollector) Collect(ch chan<- prometheus.Metric) {
+	log := logrus.WithFields(logrus.Fields{"path": "internal.service.metrics.collect"})
+
+	ctx := context.Background()
+	if impl.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, impl.Timeout)
+		defer cancel()
+	}
+
+	err := impl.ResourceRepository.FindEach(ctx, model.Query{}, func(data model.Resource) error {
+		ch <- prometheus.MustNewConstMetric(resourceQuantityDesc, prometheus.GaugeValue, data.Quantity,
+			strconv.Itoa(data.ID), data.Name, data.Measurement)
+		return nil
+	})
+	if err != nil {
+		log.Error(err.Error())
+		ch <- prometheus.NewInvalidMetric(resourceQuantityDesc, err)
+	}
+}
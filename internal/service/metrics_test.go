@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_StockCollector_Collect(t *testing.T) {
+	cases := map[string]struct {
+		expectedMetrics string
+		expectedErr     bool
+		prepareMock     func(mock *mock.MockResourceRepository)
+	}{
+		"should collect quantity of each resource": {
+			expectedMetrics: `
+# HELP socialassistance_resource_quantity Quantity in stock of each resource.
+# TYPE socialassistance_resource_quantity gauge
+socialassistance_resource_quantity{measurement="kg",name="Arroz",resource_id="1"} 10
+socialassistance_resource_quantity{measurement="l",name="Leite",resource_id="2"} 0
+`,
+			prepareMock: func(mock *mock.MockResourceRepository) {
+				mock.EXPECT().FindEach(gomock.Any(), model.Query{}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, query model.Query, fn func(data model.Resource) error) error {
+						fn(model.Resource{ID: 1, Name: "Arroz", Measurement: "kg", Quantity: 10})
+						fn(model.Resource{ID: 2, Name: "Leite", Measurement: "l", Quantity: 0})
+						return nil
+					})
+			},
+		},
+		"should throw error when repository fails": {
+			expectedErr: true,
+			prepareMock: func(mock *mock.MockResourceRepository) {
+				mock.EXPECT().FindEach(gomock.Any(), model.Query{}, gomock.Any()).Return(fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockResourceRepository := mock.NewMockResourceRepository(ctrl)
+			cs.prepareMock(mockResourceRepository)
+
+			impl := &service.StockCollector{ResourceRepository: mockResourceRepository}
+
+			// when
+			err := testutil.CollectAndCompare(impl, strings.NewReader(cs.expectedMetrics))
+
+			// then
+			assert.Equal(t, cs.expectedErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+// notificationMaxBackoff caps the wait between attempts of a notification
+const notificationMaxBackoff = 24 * time.Hour
+
+type notificationTemplate struct {
+	Subject *template.Template
+	Body    *template.Template
+}
+
+var notificationTemplates = map[string]notificationTemplate{
+	NotificationTemplateBasketReady: {
+		Subject: template.Must(template.New("subject").Parse("Sua cesta básica está pronta")),
+		Body: template.Must(template.New("body").Parse(
+			"Olá, {{.Name}}! Sua cesta básica está pronta para retirada" +
+				"{{if .Date}} a partir de {{.Date}}{{end}}{{if .Time}} às {{.Time}}{{end}}" +
+				"{{if .Place}} em {{.Place}}{{end}}. Não esqueça de levar um documento com foto." +
+				"{{if .Organization}}\n{{.Organization}}{{end}}")),
+	},
+	NotificationTemplateAppointmentReminder: {
+		Subject: template.Must(template.New("subject").Parse("Lembrete de atendimento")),
+		Body: template.Must(template.New("body").Parse(
+			"Olá, {{.Name}}! Lembramos que seu atendimento está marcado para {{.Date}}" +
+				"{{if .Time}} às {{.Time}}{{end}}{{if .Place}} em {{.Place}}{{end}}. " +
+				"Se não puder comparecer, por favor nos avise." +
+				"{{if .Organization}}\n{{.Organization}}{{end}}")),
+	},
+	NotificationTemplateBasketPickupReminder: {
+		Subject: template.Must(template.New("subject").Parse("Sua cesta básica está esperando por você")),
+		Body: template.Must(template.New("body").Parse(
+			"Olá, {{.Name}}! Sua cesta básica ainda está aguardando a retirada{{if .Place}} em {{.Place}}{{end}}. " +
+				"Não esqueça de levar um documento com foto." +
+				"{{if .Organization}}\n{{.Organization}}{{end}}")),
+	},
+}
+
+//go:generate mockgen -destination ../../mock/notification_service_mock.go -package mock . NotificationService
+type NotificationService interface {
+	FindContact(ctx context.Context, personID int) (PersonContactResponse, error)
+	SaveContact(ctx context.Context, dto SavePersonContactDto) (PersonContactResponse, error)
+	FindAll(ctx context.Context, query model.Query) (NotificationsResponse, error)
+	Notify(ctx context.Context, dto NotifyDto) (NotifyResponse, error)
+	Deliver(ctx context.Context) (int, error)
+	RemindPickups(ctx context.Context) (int, error)
+}
+
+type NotificationServiceImpl struct {
+	NotificationRepository repository.NotificationRepository
+	PersonRepository       repository.PersonRepository
+	FamilyRepository       repository.FamilyRepository
+	// Senders by channel, notifications of a channel without sender fail
+	Senders map[string]infra.Sender
+	// Organization signs the messages
+	Organization string
+	// BatchSize notifications are claimed by each Deliver, which gives up on one after MaxAttempts
+	// attempts. The n-th retry waits Backoff * 2^(n-1).
+	BatchSize   int
+	MaxAttempts int
+	Backoff     time.Duration
+	// Timeout bounds each send, and with the batch size the lease of the claimed notifications
+	Timeout time.Duration
+	// ReminderAfter is how long after basket_ready the families that didn't pick up are reminded
+	ReminderAfter time.Duration
+}
+
+func (impl *NotificationServiceImpl) FindContact(ctx context.Context, personID int) (PersonContactResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.notification.find_contact")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.notification.find_contact"})
+
+	if _, err := impl.PersonRepository.FindOneById(ctx, personID); err != nil {
+		log.Error(err.Error())
+		return PersonContactResponse{}, err
+	}
+
+	contacts, err := impl.NotificationRepository.FindContacts(ctx, []int{personID})
+	if err != nil {
+		log.Error(err.Error())
+		return PersonContactResponse{}, err
+	}
+
+	if len(contacts) == 0 {
+		return PersonContactResponse{Data: &PersonContact{PersonID: personID, Channels: []string{}}}, nil
+	}
+
+	return PersonContactResponse{Data: impl.ScanContact(contacts[0])}, nil
+}
+
+func (impl *NotificationServiceImpl) SaveContact(ctx context.Context, dto SavePersonContactDto) (PersonContactResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.notification.save_contact")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.notification.save_contact"})
+
+	channels := []string{}
+	seen := map[string]bool{}
+	for _, channel := range dto.Channels {
+		if seen[channel] {
+			continue
+		}
+		seen[channel] = true
+
+		if recipient(channel, dto.Email, dto.Phone) == "" {
+			err := &exception.EmptyModelException{Code: "notification.missing_recipient", Err: fmt.Errorf("%s channel needs the %s", channel, recipientField(channel))}
+			log.Error(err.Error())
+			return PersonContactResponse{}, err
+		}
+		channels = append(channels, channel)
+	}
+
+	if _, err := impl.PersonRepository.FindOneById(ctx, dto.PersonID); err != nil {
+		log.Error(err.Error())
+		return PersonContactResponse{}, err
+	}
+
+	contact := model.PersonContact{
+		PersonID:  dto.PersonID,
+		UpdatedAt: time.Now(),
+		Email:     dto.Email,
+		Phone:     dto.Phone,
+		Channels:  channels,
+	}
+	if err := impl.NotificationRepository.SaveContact(ctx, contact); err != nil {
+		log.Error(err.Error())
+		return PersonContactResponse{}, err
+	}
+
+	return PersonContactResponse{Data: impl.ScanContact(contact)}, nil
+}
+
+func (impl *NotificationServiceImpl) FindAll(ctx context.Context, query model.Query) (NotificationsResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.notification.find_all")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.notification.find_all"})
+
+	notifications, pagination, err := impl.NotificationRepository.FindAll(ctx, query)
+	if err != nil {
+		log.Error(err.Error())
+		return NotificationsResponse{}, err
+	}
+
+	if query.Total {
+		total, err := impl.NotificationRepository.Count(ctx, query)
+		if err != nil {
+			log.Error(err.Error())
+			return NotificationsResponse{}, err
+		}
+		pagination.Total = &total
+	}
+
+	res := []Notification{}
+	for _, notification := range notifications {
+		res = append(res, *impl.Scan(notification))
+	}
+
+	return NotificationsResponse{
+		PaginationResponse: NewPaginationResponse(pagination),
+		Meta:               NewQueryMeta(query),
+		Data:               res,
+	}, nil
+}
+
+// Notify renders the template to the person, or to every person of the family, and queues it
+// on each channel they opted in to. Persons without contact are skipped.
+func (impl *NotificationServiceImpl) Notify(ctx context.Context, dto NotifyDto) (NotifyResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.notification.notify")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.notification.notify"})
+
+	tmpl, ok := notificationTemplates[dto.Template]
+	if !ok {
+		err := &exception.EmptyModelException{Code: "notification.unknown_template", Err: fmt.Errorf("template %s not found", dto.Template)}
+		log.Error(err.Error())
+		return NotifyResponse{}, err
+	}
+
+	persons := []model.Person{}
+	if dto.PersonID > 0 {
+		person, err := impl.PersonRepository.FindOneById(ctx, dto.PersonID)
+		if err != nil {
+			log.Error(err.Error())
+			return NotifyResponse{}, err
+		}
+		persons = append(persons, *person)
+	} else {
+		if _, err := impl.FamilyRepository.FindOneById(ctx, dto.FamilyID); err != nil {
+			log.Error(err.Error())
+			return NotifyResponse{}, err
+		}
+
+		family, err := impl.PersonRepository.FindAllByFamilyIDs(ctx, []int{dto.FamilyID})
+		if err != nil {
+			log.Error(err.Error())
+			return NotifyResponse{}, err
+		}
+		persons = family
+	}
+
+	personIDs := []int{}
+	names := map[int]string{}
+	for _, person := range persons {
+		personIDs = append(personIDs, person.ID)
+		names[person.ID] = person.Name
+	}
+
+	contacts, err := impl.NotificationRepository.FindContacts(ctx, personIDs)
+	if err != nil {
+		log.Error(err.Error())
+		return NotifyResponse{}, err
+	}
+
+	date := ""
+	if dto.Date != "" {
+		d, err := time.Parse("2006-01-02", dto.Date)
+		if err != nil {
+			err := &exception.EmptyModelException{Code: "notification.invalid_date", Err: fmt.Errorf("invalid date %s", dto.Date)}
+			log.Error(err.Error())
+			return NotifyResponse{}, err
+		}
+		date = d.Format("02/01/2006")
+	}
+
+	notifications := []model.Notification{}
+	for _, contact := range contacts {
+		data := map[string]string{
+			"Name":         firstName(names[contact.PersonID]),
+			"Date":         date,
+			"Time":         dto.Time,
+			"Place":        dto.Place,
+			"Organization": impl.Organization,
+		}
+
+		var subject, body bytes.Buffer
+		if err := tmpl.Subject.Execute(&subject, data); err != nil {
+			log.Error(err.Error())
+			return NotifyResponse{}, err
+		}
+		if err := tmpl.Body.Execute(&body, data); err != nil {
+			log.Error(err.Error())
+			return NotifyResponse{}, err
+		}
+
+		for _, channel := range contact.Channels {
+			to := recipient(channel, contact.Email, contact.Phone)
+			if to == "" {
+				continue
+			}
+
+			notifications = append(notifications, model.Notification{
+				PersonID:  contact.PersonID,
+				Channel:   channel,
+				Template:  dto.Template,
+				Recipient: to,
+				Subject:   subject.String(),
+				Body:      body.String(),
+			})
+		}
+	}
+
+	res := []Notification{}
+	if len(notifications) == 0 {
+		return NotifyResponse{Data: res}, nil
+	}
+
+	notifications, err = impl.NotificationRepository.Create(ctx, notifications)
+	if err != nil {
+		log.Error(err.Error())
+		return NotifyResponse{}, err
+	}
+
+	for _, notification := range notifications {
+		res = append(res, *impl.Scan(notification))
+	}
+
+	return NotifyResponse{Data: res}, nil
+}
+
+// RemindPickups queues basket_pickup_reminder to the persons sent basket_ready at least
+// ReminderAfter ago whose family got no donation since, once, returning how many were queued
+func (impl *NotificationServiceImpl) RemindPickups(ctx context.Context) (int, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.notification.remind_pickups")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.notification.remind_pickups"})
+
+	personIDs, err := impl.NotificationRepository.FindPersonsToRemind(ctx, NotificationTemplateBasketReady,
+		NotificationTemplateBasketPickupReminder, time.Now().Add(-impl.ReminderAfter))
+	if err != nil {
+		log.Error(err.Error())
+		return 0, err
+	}
+
+	total := 0
+	for _, personID := range personIDs {
+		res, err := impl.Notify(ctx, NotifyDto{PersonID: personID, Template: NotificationTemplateBasketPickupReminder})
+		if err != nil {
+			log.Error(err.Error())
+			return total, err
+		}
+		total += len(res.Data)
+	}
+
+	return total, nil
+}
+
+// Deliver sends a batch of the queued notifications that are due, returning how many were sent.
+// Notifications refused by the sender are retried with exponential backoff.
+func (impl *NotificationServiceImpl) Deliver(ctx context.Context) (int, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.notification.deliver")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.notification.deliver"})
+
+	// the claimed notifications are sent one by one, so the lease covers each of them timing out
+	lease := time.Duration(impl.BatchSize)*impl.Timeout + time.Minute
+
+	notifications, err := impl.NotificationRepository.Claim(ctx, impl.BatchSize, lease)
+	if err != nil {
+		log.Error(err.Error())
+		return 0, err
+	}
+
+	for _, notification := range notifications {
+		err := impl.send(ctx, notification)
+
+		notification.Attempts++
+		notification.Error = ""
+		if err == nil {
+			now := time.Now()
+			notification.Status = model.NotificationSent
+			notification.SentAt = &now
+		} else {
+			notification.Error = truncate(err.Error(), 1000)
+			if notification.Attempts >= impl.MaxAttempts {
+				notification.Status = model.NotificationFailed
+			} else {
+				notification.NextAttemptAt = time.Now().Add(impl.backoff(notification.Attempts))
+			}
+		}
+
+		if err := impl.NotificationRepository.Save(ctx, notification); err != nil {
+			log.Error(err.Error())
+			return 0, err
+		}
+	}
+
+	return len(notifications), nil
+}
+
+func (impl *NotificationServiceImpl) send(ctx context.Context, notification model.Notification) error {
+	sender, ok := impl.Senders[notification.Channel]
+	if !ok {
+		return fmt.Errorf("no sender for channel %s", notification.Channel)
+	}
+
+	if impl.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, impl.Timeout)
+		defer cancel()
+	}
+
+	return sender.Send(ctx, infra.Message{
+		Channel: notification.Channel,
+		To:      notification.Recipient,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+	})
+}
+
+func (impl *NotificationServiceImpl) backoff(attempts int) time.Duration {
+	wait := impl.Backoff
+	for i := 1; i < attempts && wait < notificationMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > notificationMaxBackoff {
+		return notificationMaxBackoff
+	}
+
+	return wait
+}
+
+func (impl *NotificationServiceImpl) ScanContact(data model.PersonContact) *PersonContact {
+	return &PersonContact{
+		PersonID:  data.PersonID,
+		UpdatedAt: data.UpdatedAt.Format("2006-01-02T15:04:05"),
+		Email:     data.Email,
+		Phone:     data.Phone,
+		Channels:  data.Channels,
+	}
+}
+
+func (impl *NotificationServiceImpl) Scan(data model.Notification) *Notification {
+	sentAt := ""
+	if data.SentAt != nil {
+		sentAt = data.SentAt.Format("2006-01-02T15:04:05")
+	}
+
+	return &Notification{
+		ID:        data.ID,
+		CreatedAt: data.CreatedAt.Format("2006-01-02T15:04:05"),
+		PersonID:  data.PersonID,
+		Channel:   data.Channel,
+		Template:  data.Template,
+		Recipient: data.Recipient,
+		Subject:   data.Subject,
+		Body:      data.Body,
+		Status:    data.Status,
+		Attempts:  data.Attempts,
+		Error:     data.Error,
+		SentAt:    sentAt,
+	}
+}
+
+// recipient is the e-mail or the phone the channel sends to
+func recipient(channel, email, phone string) string {
+	if channel == model.NotificationChannelEmail {
+		return email
+	}
+
+	return phone
+}
+
+// firstName greets the person by the first name
+func firstName(name string) string {
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return name
+	}
+
+	return fields[0]
+}
+
+func recipientField(channel string) string {
+	if channel == model.NotificationChannelEmail {
+		return "email"
+	}
+
+	return "phone"
+}
@@ -0,0 +1,62 @@
+package service
+
+const (
+	NotificationTemplateBasketReady          = "basket_ready"
+	NotificationTemplateAppointmentReminder  = "appointment_reminder"
+	NotificationTemplateBasketPickupReminder = "basket_pickup_reminder"
+)
+
+type PersonContact struct {
+	PersonID  int      `json:"person_id" example:"1"`
+	UpdatedAt string   `json:"updated_at" example:"2000-01-01T12:03:00"`
+	Email     string   `json:"email" example:"maria@email.com"`
+	Phone     string   `json:"phone" example:"+5521999999999"`
+	Channels  []string `json:"channels" example:"email,whatsapp"`
+}
+
+type PersonContactResponse struct {
+	Data *PersonContact `json:"data"`
+}
+
+type SavePersonContactDto struct {
+	PersonID int    `json:"-"`
+	Email    string `json:"email" example:"maria@email.com" binding:"omitempty,email,max=254"`
+	Phone    string `json:"phone" example:"+5521999999999" binding:"omitempty,e164"`
+	// Channels the person opted in to, each needs its email or phone
+	Channels []string `json:"channels" example:"email,whatsapp" binding:"dive,oneof=email sms whatsapp"`
+}
+
+type NotifyDto struct {
+	// FamilyID notifies every person of the family, PersonID only the person
+	FamilyID int    `json:"family_id" example:"1" binding:"required_without=PersonID"`
+	PersonID int    `json:"person_id" example:"0"`
+	Template string `json:"template" example:"appointment_reminder" binding:"required,oneof=basket_ready appointment_reminder basket_pickup_reminder"`
+	Date     string `json:"date" example:"2000-01-02" binding:"required_if=Template appointment_reminder,omitempty,datetime=2006-01-02"`
+	Time     string `json:"time" example:"09:30" binding:"omitempty,datetime=15:04"`
+	Place    string `json:"place" example:"Rua Barão da Torre, 100" binding:"max=255"`
+}
+
+type Notification struct {
+	ID        int    `json:"id" example:"1"`
+	CreatedAt string `json:"created_at" example:"2000-01-01T12:03:00"`
+	PersonID  int    `json:"person_id" example:"1"`
+	Channel   string `json:"channel" example:"whatsapp"`
+	Template  string `json:"template" example:"appointment_reminder"`
+	Recipient string `json:"recipient" example:"+5521999999999"`
+	Subject   string `json:"subject" example:"Lembrete de atendimento"`
+	Body      string `json:"body" example:"Olá, Maria! Lembramos que seu atendimento está marcado para 02/01/2000."`
+	Status    string `json:"status" example:"sent"`
+	Attempts  int    `json:"attempts" example:"1"`
+	Error     string `json:"error" example:""`
+	SentAt    string `json:"sent_at" example:"2000-01-01T12:03:05"`
+}
+
+type NotificationsResponse struct {
+	PaginationResponse
+	Meta QueryMeta      `json:"meta"`
+	Data []Notification `json:"data"`
+}
+
+type NotifyResponse struct {
+	Data []Notification `json:"data"`
+}
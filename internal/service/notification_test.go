@@ -0,0 +1,354 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_NotificationService_Notify(t *testing.T) {
+	cases := map[string]struct {
+		inputDto       service.NotifyDto
+		expectedQueued []model.Notification
+		expectedErr    error
+		prepareMock    func(mockPerson *mock.MockPersonRepository, mockFamily *mock.MockFamilyRepository, mockNotification *mock.MockNotificationRepository)
+	}{
+		"should queue the reminder on each channel of each person of the family": {
+			inputDto: service.NotifyDto{FamilyID: 1, Template: service.NotificationTemplateAppointmentReminder,
+				Date: "2000-01-02", Time: "09:30", Place: "Rua Barão da Torre, 100"},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockFamily *mock.MockFamilyRepository, mockNotification *mock.MockNotificationRepository) {
+				mockFamily.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Family{ID: 1}, nil)
+				mockPerson.EXPECT().FindAllByFamilyIDs(gomock.Any(), []int{1}).Return([]model.Person{
+					{ID: 1, FamilyID: 1, Name: "Maria da Silva"},
+					{ID: 2, FamilyID: 1, Name: "João da Silva"},
+				}, nil)
+				mockNotification.EXPECT().FindContacts(gomock.Any(), []int{1, 2}).Return([]model.PersonContact{
+					{PersonID: 1, Email: "maria@email.com", Phone: "+5521999999999", Channels: []string{"email", "whatsapp"}},
+					{PersonID: 2, Channels: []string{"sms"}},
+				}, nil)
+			},
+			expectedQueued: []model.Notification{
+				{PersonID: 1, Channel: "email", Template: "appointment_reminder", Recipient: "maria@email.com",
+					Subject: "Lembrete de atendimento",
+					Body: "Olá, Maria! Lembramos que seu atendimento está marcado para 02/01/2000 às 09:30 " +
+						"em Rua Barão da Torre, 100. Se não puder comparecer, por favor nos avise.\nIpanema Box"},
+				{PersonID: 1, Channel: "whatsapp", Template: "appointment_reminder", Recipient: "+5521999999999",
+					Subject: "Lembrete de atendimento",
+					Body: "Olá, Maria! Lembramos que seu atendimento está marcado para 02/01/2000 às 09:30 " +
+						"em Rua Barão da Torre, 100. Se não puder comparecer, por favor nos avise.\nIpanema Box"},
+			},
+		},
+		"should queue basket ready to the person": {
+			inputDto: service.NotifyDto{PersonID: 1, Template: service.NotificationTemplateBasketReady},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockFamily *mock.MockFamilyRepository, mockNotification *mock.MockNotificationRepository) {
+				mockPerson.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Person{ID: 1, Name: "Maria"}, nil)
+				mockNotification.EXPECT().FindContacts(gomock.Any(), []int{1}).Return([]model.PersonContact{
+					{PersonID: 1, Phone: "+5521999999999", Channels: []string{"sms"}},
+				}, nil)
+			},
+			expectedQueued: []model.Notification{
+				{PersonID: 1, Channel: "sms", Template: "basket_ready", Recipient: "+5521999999999",
+					Subject: "Sua cesta básica está pronta",
+					Body: "Olá, Maria! Sua cesta básica está pronta para retirada. " +
+						"Não esqueça de levar um documento com foto.\nIpanema Box"},
+			},
+		},
+		"should not queue when nobody opted in": {
+			inputDto: service.NotifyDto{PersonID: 1, Template: service.NotificationTemplateBasketReady},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockFamily *mock.MockFamilyRepository, mockNotification *mock.MockNotificationRepository) {
+				mockPerson.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Person{ID: 1, Name: "Maria"}, nil)
+				mockNotification.EXPECT().FindContacts(gomock.Any(), []int{1}).Return([]model.PersonContact{}, nil)
+			},
+			expectedQueued: []model.Notification{},
+		},
+		"should throw not found when family does not exist": {
+			inputDto: service.NotifyDto{FamilyID: 1, Template: service.NotificationTemplateBasketReady},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockFamily *mock.MockFamilyRepository, mockNotification *mock.MockNotificationRepository) {
+				mockFamily.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Code: "family.not_found", Err: fmt.Errorf("family 1 not found")})
+			},
+			expectedErr: &exception.NotFoundException{Code: "family.not_found", Err: fmt.Errorf("family 1 not found")},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockPersonRepository := mock.NewMockPersonRepository(ctrl)
+			mockFamilyRepository := mock.NewMockFamilyRepository(ctrl)
+			mockNotificationRepository := mock.NewMockNotificationRepository(ctrl)
+			cs.prepareMock(mockPersonRepository, mockFamilyRepository, mockNotificationRepository)
+
+			queued := []model.Notification{}
+			mockNotificationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, data []model.Notification) ([]model.Notification, error) {
+					queued = data
+					return data, nil
+				}).MaxTimes(1)
+
+			impl := &service.NotificationServiceImpl{
+				NotificationRepository: mockNotificationRepository,
+				PersonRepository:       mockPersonRepository,
+				FamilyRepository:       mockFamilyRepository,
+				Organization:           "Ipanema Box",
+			}
+
+			// when
+			res, err := impl.Notify(ctx, cs.inputDto)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErr == nil {
+				assert.Equal(t, cs.expectedQueued, queued)
+				assert.Len(t, res.Data, len(cs.expectedQueued))
+			}
+		})
+	}
+}
+
+func Test_NotificationService_SaveContact(t *testing.T) {
+	cases := map[string]struct {
+		inputDto    service.SavePersonContactDto
+		expectedErr error
+		prepareMock func(mockPerson *mock.MockPersonRepository, mockNotification *mock.MockNotificationRepository)
+	}{
+		"should save contact without repeated channels": {
+			inputDto: service.SavePersonContactDto{PersonID: 1, Phone: "+5521999999999",
+				Channels: []string{"sms", "whatsapp", "sms"}},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockNotification *mock.MockNotificationRepository) {
+				mockPerson.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Person{ID: 1}, nil)
+				mockNotification.EXPECT().SaveContact(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, data model.PersonContact) {
+						assert.Equal(t, []string{"sms", "whatsapp"}, data.Channels)
+					}).Return(nil)
+			},
+		},
+		"should throw empty model when channel has no recipient": {
+			inputDto:    service.SavePersonContactDto{PersonID: 1, Phone: "+5521999999999", Channels: []string{"email"}},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockNotification *mock.MockNotificationRepository) {},
+			expectedErr: &exception.EmptyModelException{Code: "notification.missing_recipient", Err: fmt.Errorf("email channel needs the email")},
+		},
+		"should throw not found when person does not exist": {
+			inputDto: service.SavePersonContactDto{PersonID: 1},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockNotification *mock.MockNotificationRepository) {
+				mockPerson.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Code: "person.not_found", Err: fmt.Errorf("person 1 not found")})
+			},
+			expectedErr: &exception.NotFoundException{Code: "person.not_found", Err: fmt.Errorf("person 1 not found")},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockPersonRepository := mock.NewMockPersonRepository(ctrl)
+			mockNotificationRepository := mock.NewMockNotificationRepository(ctrl)
+			cs.prepareMock(mockPersonRepository, mockNotificationRepository)
+
+			impl := &service.NotificationServiceImpl{
+				NotificationRepository: mockNotificationRepository,
+				PersonRepository:       mockPersonRepository,
+			}
+
+			// when
+			_, err := impl.SaveContact(ctx, cs.inputDto)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_NotificationService_RemindPickups(t *testing.T) {
+	cases := map[string]struct {
+		expectedRes    int
+		expectedQueued []model.Notification
+		expectedErr    error
+		prepareMock    func(mockPerson *mock.MockPersonRepository, mockNotification *mock.MockNotificationRepository)
+	}{
+		"should queue the reminder to the persons who didn't pick up": {
+			expectedRes: 1,
+			expectedQueued: []model.Notification{
+				{PersonID: 1, Channel: "sms", Template: "basket_pickup_reminder", Recipient: "+5521999999999",
+					Subject: "Sua cesta básica está esperando por você",
+					Body: "Olá, Maria! Sua cesta básica ainda está aguardando a retirada. " +
+						"Não esqueça de levar um documento com foto.\nIpanema Box"},
+			},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockNotification *mock.MockNotificationRepository) {
+				mockNotification.EXPECT().FindPersonsToRemind(gomock.Any(), "basket_ready", "basket_pickup_reminder", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, template, reminder string, sentBefore time.Time) ([]int, error) {
+						assert.WithinDuration(t, time.Now().Add(-72*time.Hour), sentBefore, time.Second)
+						return []int{1}, nil
+					})
+				mockPerson.EXPECT().FindOneById(gomock.Any(), 1).Return(&model.Person{ID: 1, Name: "Maria"}, nil)
+				mockNotification.EXPECT().FindContacts(gomock.Any(), []int{1}).Return([]model.PersonContact{
+					{PersonID: 1, Phone: "+5521999999999", Channels: []string{"sms"}},
+				}, nil)
+			},
+		},
+		"should not queue when nobody is waiting": {
+			expectedQueued: []model.Notification{},
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockNotification *mock.MockNotificationRepository) {
+				mockNotification.EXPECT().FindPersonsToRemind(gomock.Any(), "basket_ready", "basket_pickup_reminder", gomock.Any()).
+					Return([]int{}, nil)
+			},
+		},
+		"should throw error": {
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockPerson *mock.MockPersonRepository, mockNotification *mock.MockNotificationRepository) {
+				mockNotification.EXPECT().FindPersonsToRemind(gomock.Any(), "basket_ready", "basket_pickup_reminder", gomock.Any()).
+					Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockPersonRepository := mock.NewMockPersonRepository(ctrl)
+			mockNotificationRepository := mock.NewMockNotificationRepository(ctrl)
+			cs.prepareMock(mockPersonRepository, mockNotificationRepository)
+
+			queued := []model.Notification{}
+			mockNotificationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, data []model.Notification) ([]model.Notification, error) {
+					queued = data
+					return data, nil
+				}).MaxTimes(1)
+
+			impl := &service.NotificationServiceImpl{
+				NotificationRepository: mockNotificationRepository,
+				PersonRepository:       mockPersonRepository,
+				Organization:           "Ipanema Box",
+				ReminderAfter:          72 * time.Hour,
+			}
+
+			// when
+			res, err := impl.RemindPickups(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedRes, res)
+			if cs.expectedErr == nil {
+				assert.Equal(t, cs.expectedQueued, queued)
+			}
+		})
+	}
+}
+
+func Test_NotificationService_Deliver(t *testing.T) {
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		inputAttempts      int
+		inputChannel       string
+		senderErr          error
+		expectedStatus     string
+		expectedError      string
+		expectedRetryAfter time.Duration
+	}{
+		"should send the notification": {
+			inputChannel:   "sms",
+			expectedStatus: model.NotificationSent,
+		},
+		"should retry with backoff when sender fails": {
+			inputAttempts:      2,
+			inputChannel:       "sms",
+			senderErr:          fmt.Errorf("gateway unavailable"),
+			expectedStatus:     model.NotificationPending,
+			expectedError:      "gateway unavailable",
+			expectedRetryAfter: 4 * time.Second,
+		},
+		"should cut long error on a rune boundary": {
+			inputChannel:       "sms",
+			senderErr:          fmt.Errorf("a%s", strings.Repeat("é", 600)),
+			expectedStatus:     model.NotificationPending,
+			expectedError:      "a" + strings.Repeat("é", 499),
+			expectedRetryAfter: time.Second,
+		},
+		"should give up after the last attempt": {
+			inputAttempts:  4,
+			inputChannel:   "sms",
+			senderErr:      fmt.Errorf("gateway unavailable"),
+			expectedStatus: model.NotificationFailed,
+			expectedError:  "gateway unavailable",
+		},
+		"should retry when the channel has no sender": {
+			inputChannel:       "whatsapp",
+			expectedStatus:     model.NotificationPending,
+			expectedError:      "no sender for channel whatsapp",
+			expectedRetryAfter: time.Second,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			notification := model.Notification{
+				ID:            1,
+				CreatedAt:     DATETIME,
+				PersonID:      1,
+				Channel:       cs.inputChannel,
+				Template:      service.NotificationTemplateBasketReady,
+				Recipient:     "+5521999999999",
+				Body:          "Olá, Maria!",
+				Status:        model.NotificationPending,
+				Attempts:      cs.inputAttempts,
+				NextAttemptAt: DATETIME,
+			}
+
+			mockNotificationRepository := mock.NewMockNotificationRepository(ctrl)
+			mockNotificationRepository.EXPECT().Claim(gomock.Any(), 10, gomock.Any()).Return([]model.Notification{notification}, nil)
+
+			var saved model.Notification
+			mockNotificationRepository.EXPECT().Save(gomock.Any(), gomock.Any()).
+				Do(func(ctx context.Context, data model.Notification) { saved = data }).Return(nil)
+
+			mockSender := mock.NewMockSender(ctrl)
+			mockSender.EXPECT().Send(gomock.Any(), infra.Message{Channel: "sms", To: "+5521999999999", Body: "Olá, Maria!"}).
+				Return(cs.senderErr).MaxTimes(1)
+
+			impl := &service.NotificationServiceImpl{
+				NotificationRepository: mockNotificationRepository,
+				Senders:                map[string]infra.Sender{"sms": mockSender},
+				BatchSize:              10,
+				MaxAttempts:            5,
+				Backoff:                time.Second,
+				Timeout:                time.Second,
+			}
+
+			// when
+			start := time.Now()
+			total, err := impl.Deliver(ctx)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, 1, total)
+			assert.Equal(t, cs.expectedStatus, saved.Status)
+			assert.Equal(t, cs.inputAttempts+1, saved.Attempts)
+			assert.Equal(t, cs.expectedError, saved.Error)
+			assert.Equal(t, cs.expectedStatus == model.NotificationSent, saved.SentAt != nil)
+			if cs.expectedRetryAfter > 0 {
+				assert.WithinDuration(t, start.Add(cs.expectedRetryAfter), saved.NextAttemptAt, time.Second)
+			}
+		})
+	}
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 
 	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
 	"github.com/viniosilva/socialassistanceapi/internal/model"
 	"github.com/viniosilva/socialassistanceapi/internal/repository"
 )
 
 //go:generate mockgen -destination ../../mock/person_service_mock.go -package mock . PersonService
 type PersonService interface {
-	FindAll(ctx context.Context) (PersonsResponse, error)
+	FindAll(ctx context.Context, query model.Query) (PersonsResponse, error)
 	FindOneById(ctx context.Context, personID int) (PersonResponse, error)
 	Create(ctx context.Context, dto PersonCreateDto) (PersonResponse, error)
 	Update(ctx context.Context, dto PersonUpdateDto) error
@@ -21,15 +22,26 @@ type PersonServiceImpl struct {
 	PersonRepository repository.PersonRepository
 }
 
-func (impl *PersonServiceImpl) FindAll(ctx context.Context) (PersonsResponse, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.person.find_all"})
+func (impl *PersonServiceImpl) FindAll(ctx context.Context, query model.Query) (PersonsResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.person.find_all")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.person.find_all"})
 
-	data, err := impl.PersonRepository.FindAll(ctx)
+	data, pagination, err := impl.PersonRepository.FindAll(ctx, query)
 	if err != nil {
 		log.Error(err.Error())
 		return PersonsResponse{}, err
 	}
 
+	if query.Total {
+		total, err := impl.PersonRepository.Count(ctx, query)
+		if err != nil {
+			log.Error(err.Error())
+			return PersonsResponse{}, err
+		}
+		pagination.Total = &total
+	}
+
 	res := []Person{}
 	for _, d := range data {
 		res = append(res, Person{
@@ -38,14 +50,21 @@ func (impl *PersonServiceImpl) FindAll(ctx context.Context) (PersonsResponse, er
 			UpdatedAt: d.UpdatedAt.Format("2006-01-02T15:04:05"),
 			FamilyID:  d.FamilyID,
 			Name:      d.Name,
+			Document:  d.Document,
 		})
 	}
 
-	return PersonsResponse{Data: res}, nil
+	return PersonsResponse{
+		PaginationResponse: NewPaginationResponse(pagination),
+		Meta:               NewQueryMeta(query, PersonEncryptedFields...),
+		Data:               res,
+	}, nil
 }
 
 func (impl *PersonServiceImpl) FindOneById(ctx context.Context, personID int) (PersonResponse, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.person.find_one_by_id"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.person.find_one_by_id")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.person.find_one_by_id"})
 
 	data, err := impl.PersonRepository.FindOneById(ctx, personID)
 	if err != nil || data == nil {
@@ -60,16 +79,20 @@ func (impl *PersonServiceImpl) FindOneById(ctx context.Context, personID int) (P
 			UpdatedAt: data.UpdatedAt.Format("2006-01-02T15:04:05"),
 			FamilyID:  data.FamilyID,
 			Name:      data.Name,
+			Document:  data.Document,
 		},
 	}, nil
 }
 
 func (impl *PersonServiceImpl) Create(ctx context.Context, dto PersonCreateDto) (PersonResponse, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.person.create"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.person.create")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.person.create"})
 
 	data, err := impl.PersonRepository.Create(ctx, model.Person{
 		FamilyID: dto.FamilyID,
 		Name:     dto.Name,
+		Document: dto.Document,
 	})
 	if err != nil {
 		log.Error(err.Error())
@@ -83,17 +106,21 @@ func (impl *PersonServiceImpl) Create(ctx context.Context, dto PersonCreateDto)
 			UpdatedAt: data.UpdatedAt.Format("2006-01-02T15:04:05"),
 			FamilyID:  data.FamilyID,
 			Name:      data.Name,
+			Document:  data.Document,
 		},
 	}, nil
 }
 
 func (impl *PersonServiceImpl) Update(ctx context.Context, dto PersonUpdateDto) error {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.person.update"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.person.update")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.person.update"})
 
 	if err := impl.PersonRepository.Update(ctx, model.Person{
 		ID:       dto.ID,
 		FamilyID: dto.FamilyID,
 		Name:     dto.Name,
+		Document: dto.Document,
 	}); err != nil {
 		log.Error(err.Error())
 		return err
@@ -103,7 +130,9 @@ func (impl *PersonServiceImpl) Update(ctx context.Context, dto PersonUpdateDto)
 }
 
 func (impl *PersonServiceImpl) Delete(ctx context.Context, personID int) error {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.person.delete"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.person.delete")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.person.delete"})
 
 	if err := impl.PersonRepository.Delete(ctx, personID); err != nil {
 		log.Error(err.Error())
@@ -1,5 +1,8 @@
 package service
 
+// PersonEncryptedFields are the encrypted fields of the persons, whose filter values the meta redacts
+var PersonEncryptedFields = []string{"name", "document"}
+
 type Person struct {
 	ID        int    `json:"id" example:"1"`
 	CreatedAt string `json:"created_at" example:"2000-01-01T12:03:00"`
@@ -7,6 +10,7 @@ type Person struct {
 	DeletedAt string `json:"deleted_at" example:"2000-01-01T12:03:00"`
 	FamilyID  int    `json:"family_id" example:"1"`
 	Name      string `json:"name" example:"Cláudio"`
+	Document  string `json:"document" example:"123.456.789-00"`
 }
 
 type PersonResponse struct {
@@ -14,16 +18,20 @@ type PersonResponse struct {
 }
 
 type PersonsResponse struct {
-	Data []Person `json:"data"`
+	PaginationResponse
+	Meta QueryMeta `json:"meta"`
+	Data []Person  `json:"data"`
 }
 
 type PersonCreateDto struct {
 	FamilyID int    `json:"family_id" example:"1" binding:"required"`
 	Name     string `json:"name" example:"Cláudio" binding:"required"`
+	Document string `json:"document" example:"123.456.789-00"`
 }
 
 type PersonUpdateDto struct {
 	ID       int    `json:"-"`
 	FamilyID int    `json:"family_id" example:"1"`
 	Name     string `json:"name" example:"Cláudio"`
+	Document string `json:"document" example:"123.456.789-00"`
 }
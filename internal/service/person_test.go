@@ -19,26 +19,40 @@ func Test_PersonService_FindAll(t *testing.T) {
 	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
 
 	cases := map[string]struct {
+		inputQuery  model.Query
 		expectedRes service.PersonsResponse
 		expectedErr error
 		prepareMock func(mockPersonRepository *mock.MockPersonRepository)
 	}{
+		"should redact encrypted filter values in meta": {
+			inputQuery: model.Query{Filters: []model.Filter{
+				{Field: "family_id", Operator: model.FilterOperatorEq, Value: "1"},
+				{Field: "document", Operator: model.FilterOperatorEq, Value: "123.456.789-00"},
+			}},
+			expectedRes: service.PersonsResponse{Meta: service.QueryMeta{Filters: []service.FilterMeta{
+				{Field: "family_id", Operator: "eq", Value: "1"},
+				{Field: "document", Operator: "eq", Value: service.RedactedFilterValue},
+			}, Sort: []string{}}, Data: []service.Person{}},
+			prepareMock: func(mockPersonRepository *mock.MockPersonRepository) {
+				mockPersonRepository.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return([]model.Person{}, model.Pagination{}, nil)
+			},
+		},
 		"should return persons list": {
-			expectedRes: service.PersonsResponse{Data: []service.Person{{ID: 1, CreatedAt: DATE, UpdatedAt: DATE, Name: "Test"}}},
+			expectedRes: service.PersonsResponse{Meta: service.QueryMeta{Filters: []service.FilterMeta{}, Sort: []string{}}, Data: []service.Person{{ID: 1, CreatedAt: DATE, UpdatedAt: DATE, Name: "Test"}}},
 			prepareMock: func(mockPersonRepository *mock.MockPersonRepository) {
-				mockPersonRepository.EXPECT().FindAll(gomock.Any()).Return([]model.Person{{ID: 1, CreatedAt: DATETIME, UpdatedAt: DATETIME, Name: "Test"}}, nil)
+				mockPersonRepository.EXPECT().FindAll(gomock.Any(), model.Query{}).Return([]model.Person{{ID: 1, CreatedAt: DATETIME, UpdatedAt: DATETIME, Name: "Test"}}, model.Pagination{}, nil)
 			},
 		},
 		"should return empty persons list": {
-			expectedRes: service.PersonsResponse{Data: []service.Person{}},
+			expectedRes: service.PersonsResponse{Meta: service.QueryMeta{Filters: []service.FilterMeta{}, Sort: []string{}}, Data: []service.Person{}},
 			prepareMock: func(mockPersonRepository *mock.MockPersonRepository) {
-				mockPersonRepository.EXPECT().FindAll(gomock.Any()).Return([]model.Person{}, nil)
+				mockPersonRepository.EXPECT().FindAll(gomock.Any(), model.Query{}).Return([]model.Person{}, model.Pagination{}, nil)
 			},
 		},
 		"should throw error": {
 			expectedErr: fmt.Errorf("error"),
 			prepareMock: func(mockPersonRepository *mock.MockPersonRepository) {
-				mockPersonRepository.EXPECT().FindAll(gomock.Any()).Return(nil, fmt.Errorf("error"))
+				mockPersonRepository.EXPECT().FindAll(gomock.Any(), model.Query{}).Return(nil, model.Pagination{}, fmt.Errorf("error"))
 			},
 		},
 	}
@@ -54,7 +68,7 @@ func Test_PersonService_FindAll(t *testing.T) {
 			impl := &service.PersonServiceImpl{PersonRepository: mockPersonRepository}
 
 			// when
-			res, err := impl.FindAll(ctx)
+			res, err := impl.FindAll(ctx, cs.inputQuery)
 
 			// then
 			assert.Equal(t, cs.expectedRes, res)
@@ -0,0 +1,62 @@
+package service
+
+import "github.com/viniosilva/socialassistanceapi/internal/model"
+
+// RedactedFilterValue replaces the values of the filters on encrypted fields in the meta, so the
+// responses, and their logs and caches, don't show the documents searched
+const RedactedFilterValue = "[REDACTED]"
+
+type FilterMeta struct {
+	Field    string `json:"field" example:"city"`
+	Operator string `json:"operator" example:"eq"`
+	Value    string `json:"value" example:"São Paulo"`
+}
+
+type QueryMeta struct {
+	Filters []FilterMeta `json:"filters"`
+	Sort    []string     `json:"sort" example:"-created_at"`
+}
+
+// PaginationResponse links to the pages around the current one. Previous and Next
+// are filled by the api with the request URL and the cursor, totals are only
+// computed when requested with total=true.
+type PaginationResponse struct {
+	Previous       string `json:"previous" example:"localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9&limit=10"`
+	Next           string `json:"next" example:"localhost:8080/api/v1/families?cursor=eyJzIjoiY3JlYXRlZF9hdCxpZCJ9&limit=10"`
+	PreviousCursor string `json:"previous_cursor" example:"eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"`
+	NextCursor     string `json:"next_cursor" example:"eyJzIjoiY3JlYXRlZF9hdCxpZCJ9"`
+	Total          *int   `json:"total,omitempty" example:"100"`
+}
+
+func NewPaginationResponse(pagination model.Pagination) PaginationResponse {
+	return PaginationResponse{
+		PreviousCursor: pagination.PreviousCursor,
+		NextCursor:     pagination.NextCursor,
+		Total:          pagination.Total,
+	}
+}
+
+// NewQueryMeta describes the filters and sorts of query, redacting the values of the encrypted fields
+func NewQueryMeta(query model.Query, encryptedFields ...string) QueryMeta {
+	meta := QueryMeta{Filters: []FilterMeta{}, Sort: []string{}}
+
+	for _, f := range query.Filters {
+		value := f.Value
+		for _, field := range encryptedFields {
+			if f.Field == field {
+				value = RedactedFilterValue
+			}
+		}
+		meta.Filters = append(meta.Filters, FilterMeta{Field: f.Field, Operator: string(f.Operator), Value: value})
+	}
+
+	for _, s := range query.Sorts {
+		if s.Desc {
+			meta.Sort = append(meta.Sort, "-"+s.Field)
+		} else {
+			meta.Sort = append(meta.Sort, s.Field)
+		}
+	}
+
+	return meta
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+//go:generate mockgen -destination ../../mock/receipt_service_mock.go -package mock . ReceiptService
+type ReceiptService interface {
+	Donation(ctx context.Context, donationID int) (ReceiptPdf, error)
+}
+
+type ReceiptServiceImpl struct {
+	ReceiptRepository        repository.ReceiptRepository
+	DonateResourceRepository repository.DonateResourceRepository
+	FamilyRepository         repository.FamilyRepository
+	ResourceRepository       repository.ResourceRepository
+	Organization             ReceiptOrganization
+}
+
+// Donation renders the receipt of a donation to be signed by the family. The receipt number is
+// given on the first print and kept on the next ones.
+func (impl *ReceiptServiceImpl) Donation(ctx context.Context, donationID int) (ReceiptPdf, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.receipt.donation")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.receipt.donation"})
+
+	donation, err := impl.DonateResourceRepository.FindOneById(ctx, donationID)
+	if err != nil {
+		log.Error(err.Error())
+		return ReceiptPdf{}, err
+	}
+
+	family, err := impl.FamilyRepository.FindOneById(ctx, donation.FamilyID)
+	if err != nil {
+		log.Error(err.Error())
+		return ReceiptPdf{}, err
+	}
+
+	resource, err := impl.ResourceRepository.FindOneById(ctx, donation.ResourceID)
+	if err != nil {
+		log.Error(err.Error())
+		return ReceiptPdf{}, err
+	}
+
+	receipt, err := impl.ReceiptRepository.FindOrCreate(ctx, impl.Organization.Name, donation.ID)
+	if err != nil {
+		log.Error(err.Error())
+		return ReceiptPdf{}, err
+	}
+
+	content, err := impl.render(*receipt, *donation, *family, *resource)
+	if err != nil {
+		log.Error(err.Error())
+		return ReceiptPdf{}, err
+	}
+
+	return ReceiptPdf{
+		Number:   receipt.Number,
+		Filename: fmt.Sprintf("receipt-%06d.pdf", receipt.Number),
+		Content:  content,
+	}, nil
+}
+
+func (impl *ReceiptServiceImpl) render(receipt model.Receipt, donation model.ResourceToFamily,
+	family model.Family, resource model.Resource) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetCreationDate(receipt.CreatedAt)
+	pdf.SetTitle(fmt.Sprintf("Donation receipt %06d", receipt.Number), true)
+	// core fonts are encoded as cp1252, so accents as in São Paulo must be translated
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 8, tr(impl.Organization.Name), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range []string{impl.Organization.Document, impl.Organization.Address} {
+		if line != "" {
+			pdf.CellFormat(0, 5, tr(line), "", 1, "L", false, 0, "")
+		}
+	}
+	pdf.Ln(6)
+
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(120, 8, "Donation receipt", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 8, fmt.Sprintf("No. %06d", receipt.Number), "", 1, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Date: %s", donation.CreatedAt.Format("2006-01-02")), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	address := strings.Join(nonEmpty(family.Street, family.Number, family.Complement), ", ")
+	city := strings.Join(nonEmpty(family.Neighborhood, family.City, family.State, family.Zipcode), " - ")
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 6, "Family", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 5, tr(family.Name), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, tr(address), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, tr(city), "", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(100, 7, "Item", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 7, "Quantity", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(0, 7, "Total", "1", 1, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(100, 7, tr(resource.Name), "1", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 7, fmt.Sprintf("%g", donation.Quantity), "1", 0, "R", false, 0, "")
+	pdf.CellFormat(0, 7, tr(fmt.Sprintf("%g %s", donation.Quantity*resource.Amount, resource.Measurement)),
+		"1", 1, "R", false, 0, "")
+
+	pdf.Ln(30)
+	x, y := pdf.GetXY()
+	pdf.Line(x, y, x+100, y)
+	pdf.Ln(2)
+	pdf.CellFormat(100, 5, tr(fmt.Sprintf("Received by the family %s", family.Name)), "", 1, "L", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func nonEmpty(values ...string) []string {
+	res := []string{}
+	for _, v := range values {
+		if v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,13 @@
+package service
+
+type ReceiptOrganization struct {
+	Name     string
+	Document string
+	Address  string
+}
+
+type ReceiptPdf struct {
+	Number   int
+	Filename string
+	Content  []byte
+}
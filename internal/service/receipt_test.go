@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_ReceiptService_Donation(t *testing.T) {
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+	DONATION := model.ResourceToFamily{ID: 1, CreatedAt: DATETIME, ResourceID: 2, FamilyID: 3, Quantity: 2}
+
+	cases := map[string]struct {
+		inputDonationID  int
+		expectedNumber   int
+		expectedFilename string
+		expectedErr      error
+		prepareMock      func(mockReceiptRepository *mock.MockReceiptRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository,
+			mockFamilyRepository *mock.MockFamilyRepository, mockResourceRepository *mock.MockResourceRepository)
+	}{
+		"should render receipt": {
+			inputDonationID:  1,
+			expectedNumber:   12,
+			expectedFilename: "receipt-000012.pdf",
+			prepareMock: func(mockReceiptRepository *mock.MockReceiptRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository,
+				mockFamilyRepository *mock.MockFamilyRepository, mockResourceRepository *mock.MockResourceRepository) {
+				mockDonateResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).Return(&DONATION, nil)
+				mockFamilyRepository.EXPECT().FindOneById(gomock.Any(), 3).Return(&model.Family{ID: 3, Name: "Sauro",
+					City: "São Paulo", State: "SP", Street: "R. Vinte e Cinco de Março", Number: "1000"}, nil)
+				mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 2).Return(&model.Resource{ID: 2, Name: "Arroz",
+					Amount: 5, Measurement: "Kg"}, nil)
+				mockReceiptRepository.EXPECT().FindOrCreate(gomock.Any(), "Ipanema Box", 1).Return(&model.Receipt{ID: 1,
+					CreatedAt: DATETIME, Organization: "Ipanema Box", Number: 12, DonationID: 1}, nil)
+			},
+		},
+		"should throw not found exception when donation does not exist": {
+			inputDonationID: 1,
+			expectedErr:     &exception.NotFoundException{Err: fmt.Errorf("donation 1 not found")},
+			prepareMock: func(mockReceiptRepository *mock.MockReceiptRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository,
+				mockFamilyRepository *mock.MockFamilyRepository, mockResourceRepository *mock.MockResourceRepository) {
+				mockDonateResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(nil, &exception.NotFoundException{Err: fmt.Errorf("donation 1 not found")})
+			},
+		},
+		"should throw error when receipt is not numbered": {
+			inputDonationID: 1,
+			expectedErr:     fmt.Errorf("error"),
+			prepareMock: func(mockReceiptRepository *mock.MockReceiptRepository, mockDonateResourceRepository *mock.MockDonateResourceRepository,
+				mockFamilyRepository *mock.MockFamilyRepository, mockResourceRepository *mock.MockResourceRepository) {
+				mockDonateResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).Return(&DONATION, nil)
+				mockFamilyRepository.EXPECT().FindOneById(gomock.Any(), 3).Return(&model.Family{ID: 3}, nil)
+				mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 2).Return(&model.Resource{ID: 2}, nil)
+				mockReceiptRepository.EXPECT().FindOrCreate(gomock.Any(), "Ipanema Box", 1).Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mockReceiptRepository := mock.NewMockReceiptRepository(ctrl)
+			mockDonateResourceRepository := mock.NewMockDonateResourceRepository(ctrl)
+			mockFamilyRepository := mock.NewMockFamilyRepository(ctrl)
+			mockResourceRepository := mock.NewMockResourceRepository(ctrl)
+
+			impl := &service.ReceiptServiceImpl{
+				ReceiptRepository:        mockReceiptRepository,
+				DonateResourceRepository: mockDonateResourceRepository,
+				FamilyRepository:         mockFamilyRepository,
+				ResourceRepository:       mockResourceRepository,
+				Organization:             service.ReceiptOrganization{Name: "Ipanema Box", Document: "00.000.000/0001-00"},
+			}
+
+			cs.prepareMock(mockReceiptRepository, mockDonateResourceRepository, mockFamilyRepository, mockResourceRepository)
+
+			// when
+			res, err := impl.Donation(ctx, cs.inputDonationID)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedNumber, res.Number)
+			assert.Equal(t, cs.expectedFilename, res.Filename)
+			assert.Equal(t, cs.expectedErr == nil, bytes.HasPrefix(res.Content, []byte("%PDF-")))
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+var distributionGroups = []model.DistributionGroup{model.DistributionGroupResource,
+	model.DistributionGroupNeighborhood, model.DistributionGroupCity, model.DistributionGroupMonth}
+
+//go:generate mockgen -destination ../../mock/report_service_mock.go -package mock . ReportService
+type ReportService interface {
+	Distributions(ctx context.Context, dto DistributionReportDto) (DistributionReportResponse, error)
+	PublishLastMonth(ctx context.Context) (DistributionReportResponse, error)
+}
+
+type ReportServiceImpl struct {
+	ReportRepository repository.ReportRepository
+	Webhooks         WebhookPublisher
+}
+
+// Distributions reports the donations of the period grouped by resource by default. The period
+// defaults to the current month until today.
+func (impl *ReportServiceImpl) Distributions(ctx context.Context, dto DistributionReportDto) (DistributionReportResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.report.distributions")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.report.distributions"})
+
+	group := model.DistributionGroup(dto.GroupBy)
+	if group == "" {
+		group = model.DistributionGroupResource
+	}
+	valid := false
+	for _, g := range distributionGroups {
+		valid = valid || g == group
+	}
+	if !valid {
+		err := &exception.InvalidQueryException{
+			Err: fmt.Errorf("group_by must be one of resource, neighborhood, city or month"),
+		}
+		log.Error(err.Error())
+		return DistributionReportResponse{}, err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from, err := parseReportDate("from", dto.From, today.AddDate(0, 0, 1-today.Day()))
+	if err != nil {
+		log.Error(err.Error())
+		return DistributionReportResponse{}, err
+	}
+	to, err := parseReportDate("to", dto.To, today)
+	if err != nil {
+		log.Error(err.Error())
+		return DistributionReportResponse{}, err
+	}
+	if to.Before(from) {
+		err := &exception.InvalidQueryException{Err: fmt.Errorf("from must not be after to")}
+		log.Error(err.Error())
+		return DistributionReportResponse{}, err
+	}
+
+	reports, err := impl.ReportRepository.Distributions(ctx,
+		model.DistributionPeriod{From: from, To: to.AddDate(0, 0, 1)}, group)
+	if err != nil {
+		log.Error(err.Error())
+		return DistributionReportResponse{}, err
+	}
+
+	data := make([]DistributionReport, len(reports))
+	for i, r := range reports {
+		totals := make([]DistributionTotal, len(r.Totals))
+		for j, t := range r.Totals {
+			totals[j] = DistributionTotal{Measurement: t.Measurement, Amount: t.Amount}
+		}
+
+		data[i] = DistributionReport{
+			Group:      r.Group,
+			ResourceID: r.ResourceID,
+			Quantity:   r.Quantity,
+			Donations:  r.Donations,
+			Families:   r.Families,
+			Totals:     totals,
+		}
+	}
+
+	return DistributionReportResponse{
+		Meta: DistributionReportMeta{
+			From:    from.Format("2006-01-02"),
+			To:      to.Format("2006-01-02"),
+			GroupBy: string(group),
+		},
+		Data: data,
+	}, nil
+}
+
+// PublishLastMonth reports the distributions of the last month by resource to the webhooks
+// subscribed to report.distributions
+func (impl *ReportServiceImpl) PublishLastMonth(ctx context.Context) (DistributionReportResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.report.publish_last_month")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.report.publish_last_month"})
+
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	res, err := impl.Distributions(ctx, DistributionReportDto{
+		From: first.AddDate(0, -1, 0).Format("2006-01-02"),
+		To:   first.AddDate(0, 0, -1).Format("2006-01-02"),
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return DistributionReportResponse{}, err
+	}
+
+	if impl.Webhooks != nil {
+		impl.Webhooks.Publish(ctx, model.WebhookEventReportDistributions, res)
+	}
+
+	return res, nil
+}
+
+func parseReportDate(name, value string, def time.Time) (time.Time, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return t, &exception.InvalidQueryException{Err: fmt.Errorf("%s must be a date as 2006-01-02", name)}
+	}
+
+	return t, nil
+}
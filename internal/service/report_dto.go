@@ -0,0 +1,33 @@
+package service
+
+type DistributionReportDto struct {
+	// From and To are dates as 2006-01-02, both included
+	From    string
+	To      string
+	GroupBy string
+}
+
+type DistributionTotal struct {
+	Measurement string  `json:"measurement" example:"Kg"`
+	Amount      float64 `json:"amount" example:"250"`
+}
+
+type DistributionReport struct {
+	Group      string              `json:"group" example:"Arroz"`
+	ResourceID int                 `json:"resource_id,omitempty" example:"1"`
+	Quantity   float64             `json:"quantity" example:"50"`
+	Donations  int                 `json:"donations" example:"48"`
+	Families   int                 `json:"families" example:"40"`
+	Totals     []DistributionTotal `json:"totals"`
+}
+
+type DistributionReportMeta struct {
+	From    string `json:"from" example:"2000-01-01"`
+	To      string `json:"to" example:"2000-01-31"`
+	GroupBy string `json:"group_by" example:"resource"`
+}
+
+type DistributionReportResponse struct {
+	Meta DistributionReportMeta `json:"meta"`
+	Data []DistributionReport   `json:"data"`
+}
@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_ReportService_Distributions(t *testing.T) {
+	PERIOD := model.DistributionPeriod{
+		From: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	cases := map[string]struct {
+		inputDto    service.DistributionReportDto
+		expectedRes service.DistributionReportResponse
+		expectedErr error
+		prepareMock func(mockReportRepository *mock.MockReportRepository)
+	}{
+		"should return distributions grouped by resource": {
+			inputDto: service.DistributionReportDto{From: "2000-01-01", To: "2000-01-31"},
+			expectedRes: service.DistributionReportResponse{
+				Meta: service.DistributionReportMeta{From: "2000-01-01", To: "2000-01-31", GroupBy: "resource"},
+				Data: []service.DistributionReport{{Group: "Arroz", ResourceID: 1, Quantity: 50, Donations: 48, Families: 40,
+					Totals: []service.DistributionTotal{{Measurement: "Kg", Amount: 250}}}},
+			},
+			prepareMock: func(mockReportRepository *mock.MockReportRepository) {
+				mockReportRepository.EXPECT().Distributions(gomock.Any(), PERIOD, model.DistributionGroupResource).
+					Return([]model.DistributionReport{{Group: "Arroz", ResourceID: 1, Quantity: 50, Donations: 48, Families: 40,
+						Totals: []model.DistributionTotal{{Measurement: "Kg", Amount: 250}}}}, nil)
+			},
+		},
+		"should return empty distributions grouped by neighborhood": {
+			inputDto: service.DistributionReportDto{From: "2000-01-01", To: "2000-01-31", GroupBy: "neighborhood"},
+			expectedRes: service.DistributionReportResponse{
+				Meta: service.DistributionReportMeta{From: "2000-01-01", To: "2000-01-31", GroupBy: "neighborhood"},
+				Data: []service.DistributionReport{},
+			},
+			prepareMock: func(mockReportRepository *mock.MockReportRepository) {
+				mockReportRepository.EXPECT().Distributions(gomock.Any(), PERIOD, model.DistributionGroupNeighborhood).
+					Return([]model.DistributionReport{}, nil)
+			},
+		},
+		"should throw invalid query exception when group is unknown": {
+			inputDto:    service.DistributionReportDto{GroupBy: "state"},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("group_by must be one of resource, neighborhood, city or month")},
+			prepareMock: func(mockReportRepository *mock.MockReportRepository) {},
+		},
+		"should throw invalid query exception when date is invalid": {
+			inputDto:    service.DistributionReportDto{From: "01/01/2000"},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("from must be a date as 2006-01-02")},
+			prepareMock: func(mockReportRepository *mock.MockReportRepository) {},
+		},
+		"should throw invalid query exception when from is after to": {
+			inputDto:    service.DistributionReportDto{From: "2000-02-01", To: "2000-01-31"},
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("from must not be after to")},
+			prepareMock: func(mockReportRepository *mock.MockReportRepository) {},
+		},
+		"should throw error": {
+			inputDto:    service.DistributionReportDto{From: "2000-01-01", To: "2000-01-31"},
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockReportRepository *mock.MockReportRepository) {
+				mockReportRepository.EXPECT().Distributions(gomock.Any(), PERIOD, model.DistributionGroupResource).
+					Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mockReportRepository := mock.NewMockReportRepository(ctrl)
+
+			impl := &service.ReportServiceImpl{ReportRepository: mockReportRepository}
+
+			cs.prepareMock(mockReportRepository)
+
+			// when
+			res, err := impl.Distributions(ctx, cs.inputDto)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_ReportService_PublishLastMonth(t *testing.T) {
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	PERIOD := model.DistributionPeriod{From: first.AddDate(0, -1, 0), To: first}
+
+	cases := map[string]struct {
+		expectedPublished bool
+		expectedErr       error
+		prepareMock       func(mockReportRepository *mock.MockReportRepository)
+	}{
+		"should publish the distributions of the last month": {
+			expectedPublished: true,
+			prepareMock: func(mockReportRepository *mock.MockReportRepository) {
+				mockReportRepository.EXPECT().Distributions(gomock.Any(), PERIOD, model.DistributionGroupResource).
+					Return([]model.DistributionReport{{Group: "Arroz", ResourceID: 1, Quantity: 50, Donations: 48, Families: 40,
+						Totals: []model.DistributionTotal{{Measurement: "Kg", Amount: 250}}}}, nil)
+			},
+		},
+		"should throw error": {
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockReportRepository *mock.MockReportRepository) {
+				mockReportRepository.EXPECT().Distributions(gomock.Any(), PERIOD, model.DistributionGroupResource).
+					Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockReportRepository := mock.NewMockReportRepository(ctrl)
+			cs.prepareMock(mockReportRepository)
+
+			mockWebhookPublisher := mock.NewMockWebhookPublisher(ctrl)
+			if cs.expectedPublished {
+				mockWebhookPublisher.EXPECT().Publish(gomock.Any(), model.WebhookEventReportDistributions, service.DistributionReportResponse{
+					Meta: service.DistributionReportMeta{From: PERIOD.From.Format("2006-01-02"),
+						To: first.AddDate(0, 0, -1).Format("2006-01-02"), GroupBy: "resource"},
+					Data: []service.DistributionReport{{Group: "Arroz", ResourceID: 1, Quantity: 50, Donations: 48, Families: 40,
+						Totals: []service.DistributionTotal{{Measurement: "Kg", Amount: 250}}}},
+				})
+			}
+
+			impl := &service.ReportServiceImpl{ReportRepository: mockReportRepository, Webhooks: mockWebhookPublisher}
+
+			// when
+			_, err := impl.PublishLastMonth(ctx)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -4,31 +4,49 @@ import (
 	"context"
 
 	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
 	"github.com/viniosilva/socialassistanceapi/internal/model"
 	"github.com/viniosilva/socialassistanceapi/internal/repository"
 )
 
+//go:generate mockgen -destination ../../mock/resource_service_mock.go -package mock . ResourceService
 type ResourceService interface {
-	FindAll(ctx context.Context) (ResourcesResponse, error)
+	FindAll(ctx context.Context, query model.Query) (ResourcesResponse, error)
 	FindOneById(ctx context.Context, resourceID int) (ResourceResponse, error)
 	Create(ctx context.Context, dto CreateResourceDto) (ResourceResponse, error)
 	Update(ctx context.Context, dto UpdateResourceDto) error
 	UpdateQuantity(ctx context.Context, resourceID int, dto UpdateResourceQuantityDto) error
+	Reconcile(ctx context.Context, fix bool) (ResourceReconcileResponse, error)
 }
 
 type ResourceServiceImpl struct {
 	ResourceRepository repository.ResourceRepository
+	Stock              StockPublisher
+	Webhooks           WebhookPublisher
+	// LowStockQuantity publishes resource.low_stock when an update leaves the resource at or below it
+	LowStockQuantity float64
 }
 
-func (impl *ResourceServiceImpl) FindAll(ctx context.Context) (ResourcesResponse, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.resource.find_all"})
+func (impl *ResourceServiceImpl) FindAll(ctx context.Context, query model.Query) (ResourcesResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.resource.find_all")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.resource.find_all"})
 
-	resources, err := impl.ResourceRepository.FindAll(ctx)
+	resources, pagination, err := impl.ResourceRepository.FindAll(ctx, query)
 	if err != nil {
 		log.Error(err.Error())
 		return ResourcesResponse{}, err
 	}
 
+	if query.Total {
+		total, err := impl.ResourceRepository.Count(ctx, query)
+		if err != nil {
+			log.Error(err.Error())
+			return ResourcesResponse{}, err
+		}
+		pagination.Total = &total
+	}
+
 	res := []Resource{}
 	for _, resource := range resources {
 		res = append(res, Resource{
@@ -42,11 +60,17 @@ func (impl *ResourceServiceImpl) FindAll(ctx context.Context) (ResourcesResponse
 		})
 	}
 
-	return ResourcesResponse{Data: res}, nil
+	return ResourcesResponse{
+		PaginationResponse: NewPaginationResponse(pagination),
+		Meta:               NewQueryMeta(query),
+		Data:               res,
+	}, nil
 }
 
 func (impl *ResourceServiceImpl) FindOneById(ctx context.Context, resourceID int) (ResourceResponse, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.resource.find_one_by_id"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.resource.find_one_by_id")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.resource.find_one_by_id"})
 
 	resource, err := impl.ResourceRepository.FindOneById(ctx, resourceID)
 	if err != nil || resource == nil {
@@ -68,7 +92,9 @@ func (impl *ResourceServiceImpl) FindOneById(ctx context.Context, resourceID int
 }
 
 func (impl *ResourceServiceImpl) Create(ctx context.Context, dto CreateResourceDto) (ResourceResponse, error) {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.resource.create"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.resource.create")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.resource.create"})
 
 	resource, err := impl.ResourceRepository.Create(ctx, model.Resource{
 		Name:        dto.Name,
@@ -95,7 +121,9 @@ func (impl *ResourceServiceImpl) Create(ctx context.Context, dto CreateResourceD
 }
 
 func (impl *ResourceServiceImpl) Update(ctx context.Context, dto UpdateResourceDto) error {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.resource.update"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.resource.update")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.resource.update"})
 
 	if err := impl.ResourceRepository.Update(ctx, model.Resource{
 		ID:          dto.ID,
@@ -111,12 +139,100 @@ func (impl *ResourceServiceImpl) Update(ctx context.Context, dto UpdateResourceD
 }
 
 func (impl *ResourceServiceImpl) UpdateQuantity(ctx context.Context, resourceID int, dto UpdateResourceQuantityDto) error {
-	log := logrus.WithFields(logrus.Fields{"span_id": ctx.Value("span_id"), "path": "internal.service.resource.find_all"})
+	ctx, span := infra.StartSpan(ctx, "internal.service.resource.update_quantity")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.resource.update_quantity"})
+
+	// the quantity before tells whether the update crossed the low stock threshold
+	var before *model.Resource
+	if impl.Webhooks != nil {
+		resource, err := impl.ResourceRepository.FindOneById(ctx, resourceID)
+		if err != nil {
+			log.Error(err.Error())
+			return err
+		}
+		before = resource
+	}
 
 	if err := impl.ResourceRepository.UpdateQuantity(ctx, resourceID, dto.Quantity); err != nil {
 		log.Error(err.Error())
 		return err
 	}
+	if impl.Stock == nil && impl.Webhooks == nil {
+		return nil
+	}
+
+	resource, err := impl.ResourceRepository.FindOneById(ctx, resourceID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil
+	}
+
+	if impl.Stock != nil {
+		impl.Stock.Publish(ctx, *resource, StockReasonUpdate)
+	}
+	if before != nil {
+		publishLowStock(ctx, impl.Webhooks, impl.LowStockQuantity, *resource, before.Quantity)
+	}
 
 	return nil
 }
+
+// publishLowStock publishes resource.low_stock when the quantity of the resource crossed the
+// threshold from the quantity before, not on every change after it
+func publishLowStock(ctx context.Context, webhooks WebhookPublisher, threshold float64, resource model.Resource, before float64) {
+	if webhooks == nil || resource.Quantity > threshold || before <= threshold {
+		return
+	}
+
+	webhooks.Publish(ctx, model.WebhookEventResourceLow, Resource{
+		ID:          resource.ID,
+		CreatedAt:   resource.CreatedAt.Format("2006-01-02T15:04:05"),
+		UpdatedAt:   resource.UpdatedAt.Format("2006-01-02T15:04:05"),
+		Name:        resource.Name,
+		Amount:      resource.Amount,
+		Measurement: resource.Measurement,
+		Quantity:    resource.Quantity,
+	})
+}
+
+// Reconcile checks the stored quantities, which no request leaves negative but a manual change
+// of the database can. With fix the negative quantities are set to zero by UpdateQuantity, which
+// publishes the events of the change. There is no record of the stock received, so the quantities
+// cannot be recomputed from the donations.
+func (impl *ResourceServiceImpl) Reconcile(ctx context.Context, fix bool) (ResourceReconcileResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.resource.reconcile")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.resource.reconcile"})
+
+	res := ResourceReconcileResponse{Data: []ResourceReconcile{}}
+	err := impl.ResourceRepository.FindEach(ctx, model.Query{}, func(resource model.Resource) error {
+		res.Checked++
+		if resource.Quantity < 0 {
+			res.Data = append(res.Data, ResourceReconcile{
+				ID:          resource.ID,
+				Name:        resource.Name,
+				Measurement: resource.Measurement,
+				Quantity:    resource.Quantity,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return ResourceReconcileResponse{}, err
+	}
+
+	if !fix {
+		return res, nil
+	}
+	for i, resource := range res.Data {
+		if err := impl.UpdateQuantity(ctx, resource.ID, UpdateResourceQuantityDto{Quantity: 0}); err != nil {
+			log.Error(err.Error())
+			return res, err
+		}
+		res.Data[i].Fixed = true
+	}
+
+	return res, nil
+}
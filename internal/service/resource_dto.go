@@ -16,6 +16,8 @@ type ResourceResponse struct {
 }
 
 type ResourcesResponse struct {
+	PaginationResponse
+	Meta QueryMeta  `json:"meta"`
 	Data []Resource `json:"data"`
 }
 
@@ -36,3 +38,17 @@ type UpdateResourceDto struct {
 type UpdateResourceQuantityDto struct {
 	Quantity float64 `json:"quantity" example:"10" binding:"required,gte=0"`
 }
+
+// ResourceReconcile is a resource found with a negative quantity
+type ResourceReconcile struct {
+	ID          int     `json:"id" example:"1"`
+	Name        string  `json:"name" example:"Arroz"`
+	Measurement string  `json:"measurement" example:"Kg"`
+	Quantity    float64 `json:"quantity" example:"-2"`
+	Fixed       bool    `json:"fixed" example:"true"`
+}
+
+type ResourceReconcileResponse struct {
+	Checked int                 `json:"checked" example:"30"`
+	Data    []ResourceReconcile `json:"data"`
+}
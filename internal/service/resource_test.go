@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
 	"github.com/viniosilva/socialassistanceapi/internal/model"
 	"github.com/viniosilva/socialassistanceapi/internal/service"
 	"github.com/viniosilva/socialassistanceapi/mock"
@@ -18,27 +19,64 @@ func Test_ResourceService_FindAll(t *testing.T) {
 	const DATE = "2000-01-01T12:03:00"
 	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
 
+	EMPTY_META := service.QueryMeta{Filters: []service.FilterMeta{}, Sort: []string{}}
+	TOTAL := 3
+
 	cases := map[string]struct {
+		inputQuery  model.Query
 		expectedRes service.ResourcesResponse
 		expectedErr error
 		prepareMock func(mockResourceRepository *mock.MockResourceRepository)
 	}{
 		"should return resource list": {
-			expectedRes: service.ResourcesResponse{Data: []service.Resource{{ID: 1, CreatedAt: DATE, UpdatedAt: DATE, Name: "Test"}}},
+			expectedRes: service.ResourcesResponse{Meta: EMPTY_META, Data: []service.Resource{{ID: 1, CreatedAt: DATE, UpdatedAt: DATE, Name: "Test"}}},
+			prepareMock: func(mockResourceRepository *mock.MockResourceRepository) {
+				mockResourceRepository.EXPECT().FindAll(gomock.Any(), model.Query{}).Return([]model.Resource{{ID: 1, CreatedAt: DATETIME, UpdatedAt: DATETIME, Name: "Test"}}, model.Pagination{}, nil)
+			},
+		},
+		"should return filtered resource list with applied query": {
+			inputQuery: model.Query{
+				Filters: []model.Filter{{Field: "name", Operator: model.FilterOperatorContains, Value: "Arr"}},
+				Sorts:   []model.Sort{{Field: "created_at", Desc: true}},
+			},
+			expectedRes: service.ResourcesResponse{
+				Meta: service.QueryMeta{
+					Filters: []service.FilterMeta{{Field: "name", Operator: "contains", Value: "Arr"}},
+					Sort:    []string{"-created_at"},
+				},
+				Data: []service.Resource{{ID: 1, CreatedAt: DATE, UpdatedAt: DATE, Name: "Arroz"}},
+			},
 			prepareMock: func(mockResourceRepository *mock.MockResourceRepository) {
-				mockResourceRepository.EXPECT().FindAll(gomock.Any()).Return([]model.Resource{{ID: 1, CreatedAt: DATETIME, UpdatedAt: DATETIME, Name: "Test"}}, nil)
+				mockResourceRepository.EXPECT().FindAll(gomock.Any(), model.Query{
+					Filters: []model.Filter{{Field: "name", Operator: model.FilterOperatorContains, Value: "Arr"}},
+					Sorts:   []model.Sort{{Field: "created_at", Desc: true}},
+				}).Return([]model.Resource{{ID: 1, CreatedAt: DATETIME, UpdatedAt: DATETIME, Name: "Arroz"}}, model.Pagination{}, nil)
+			},
+		},
+		"should return resource page with cursors and total": {
+			inputQuery: model.Query{Limit: 1, Total: true},
+			expectedRes: service.ResourcesResponse{
+				PaginationResponse: service.PaginationResponse{PreviousCursor: "prev", NextCursor: "next", Total: &TOTAL},
+				Meta:               EMPTY_META,
+				Data:               []service.Resource{{ID: 2, CreatedAt: DATE, UpdatedAt: DATE, Name: "Feijão"}},
+			},
+			prepareMock: func(mockResourceRepository *mock.MockResourceRepository) {
+				mockResourceRepository.EXPECT().FindAll(gomock.Any(), model.Query{Limit: 1, Total: true}).
+					Return([]model.Resource{{ID: 2, CreatedAt: DATETIME, UpdatedAt: DATETIME, Name: "Feijão"}},
+						model.Pagination{PreviousCursor: "prev", NextCursor: "next"}, nil)
+				mockResourceRepository.EXPECT().Count(gomock.Any(), model.Query{Limit: 1, Total: true}).Return(3, nil)
 			},
 		},
 		"should return empty resource list": {
-			expectedRes: service.ResourcesResponse{Data: []service.Resource{}},
+			expectedRes: service.ResourcesResponse{Meta: EMPTY_META, Data: []service.Resource{}},
 			prepareMock: func(mockResourceRepository *mock.MockResourceRepository) {
-				mockResourceRepository.EXPECT().FindAll(gomock.Any()).Return([]model.Resource{}, nil)
+				mockResourceRepository.EXPECT().FindAll(gomock.Any(), model.Query{}).Return([]model.Resource{}, model.Pagination{}, nil)
 			},
 		},
 		"should throw error": {
 			expectedErr: fmt.Errorf("error"),
 			prepareMock: func(mockResourceRepository *mock.MockResourceRepository) {
-				mockResourceRepository.EXPECT().FindAll(gomock.Any()).Return(nil, fmt.Errorf("error"))
+				mockResourceRepository.EXPECT().FindAll(gomock.Any(), model.Query{}).Return(nil, model.Pagination{}, fmt.Errorf("error"))
 			},
 		},
 	}
@@ -54,7 +92,7 @@ func Test_ResourceService_FindAll(t *testing.T) {
 			impl := &service.ResourceServiceImpl{ResourceRepository: mockResourceRepository}
 
 			// when
-			res, err := impl.FindAll(ctx)
+			res, err := impl.FindAll(ctx, cs.inputQuery)
 
 			// then
 			assert.Equal(t, cs.expectedRes, res)
@@ -294,3 +332,195 @@ func Test_ResourceService_UpdateQuantity(t *testing.T) {
 		})
 	}
 }
+
+func Test_ResourceService_UpdateQuantity_Stock(t *testing.T) {
+	t.Run("should stream the updated quantity", func(t *testing.T) {
+		// given
+		ctrl, ctx := gomock.WithContext(context.Background(), t)
+		defer ctrl.Finish()
+
+		mockResourceRepository := mock.NewMockResourceRepository(ctrl)
+		mockResourceRepository.EXPECT().UpdateQuantity(gomock.Any(), 1, 2.0).Return(nil)
+		mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).
+			Return(&model.Resource{ID: 1, Name: "Arroz", Measurement: "Kg", Quantity: 2}, nil)
+
+		stock := &service.StockServiceImpl{Broker: infra.NewBroker[service.StockEvent](10)}
+		subscription := stock.Subscribe(ctx, 0)
+		defer subscription.Close()
+
+		impl := &service.ResourceServiceImpl{ResourceRepository: mockResourceRepository, Stock: stock}
+
+		// when
+		err := impl.UpdateQuantity(ctx, 1, service.UpdateResourceQuantityDto{Quantity: 2})
+
+		// then
+		assert.Nil(t, err)
+		event := <-subscription.Events
+		assert.Equal(t, int64(1), event.ID)
+		assert.Equal(t, 1, event.Data.ResourceID)
+		assert.Equal(t, 2.0, event.Data.Quantity)
+		assert.Equal(t, service.StockReasonUpdate, event.Data.Reason)
+	})
+}
+
+func Test_ResourceService_UpdateQuantity_Webhooks(t *testing.T) {
+	cases := map[string]struct {
+		inputQuantity  float64
+		beforeQuantity float64
+		expectedLow    bool
+	}{
+		"should publish low stock when quantity crosses the threshold": {
+			inputQuantity:  4,
+			beforeQuantity: 10,
+			expectedLow:    true,
+		},
+		"should not publish low stock again when quantity was already low": {
+			inputQuantity:  2,
+			beforeQuantity: 3,
+		},
+		"should not publish low stock when quantity is above the threshold": {
+			inputQuantity:  8,
+			beforeQuantity: 10,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockResourceRepository := mock.NewMockResourceRepository(ctrl)
+			mockWebhooks := mock.NewMockWebhookPublisher(ctrl)
+			gomock.InOrder(
+				mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(&model.Resource{ID: 1, Name: "Arroz", Quantity: cs.beforeQuantity}, nil),
+				mockResourceRepository.EXPECT().UpdateQuantity(gomock.Any(), 1, cs.inputQuantity).Return(nil),
+				mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 1).
+					Return(&model.Resource{ID: 1, Name: "Arroz", Quantity: cs.inputQuantity}, nil),
+			)
+			if cs.expectedLow {
+				mockWebhooks.EXPECT().Publish(gomock.Any(), model.WebhookEventResourceLow, gomock.Any()).
+					Do(func(ctx context.Context, event string, data interface{}) {
+						assert.Equal(t, cs.inputQuantity, data.(service.Resource).Quantity)
+					})
+			}
+
+			impl := &service.ResourceServiceImpl{
+				ResourceRepository: mockResourceRepository,
+				Webhooks:           mockWebhooks,
+				LowStockQuantity:   5,
+			}
+
+			// when
+			err := impl.UpdateQuantity(ctx, 1, service.UpdateResourceQuantityDto{Quantity: cs.inputQuantity})
+
+			// then
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func Test_ResourceService_Reconcile(t *testing.T) {
+	RESOURCES := []model.Resource{
+		{ID: 1, Name: "Arroz", Measurement: "Kg", Quantity: 5},
+		{ID: 2, Name: "Feijão", Measurement: "Kg", Quantity: -2},
+	}
+
+	cases := map[string]struct {
+		inputFix    bool
+		expectedRes service.ResourceReconcileResponse
+		expectedErr error
+		prepareMock func(mockResourceRepository *mock.MockResourceRepository)
+	}{
+		"should list resources with negative quantity": {
+			expectedRes: service.ResourceReconcileResponse{Checked: 2, Data: []service.ResourceReconcile{
+				{ID: 2, Name: "Feijão", Measurement: "Kg", Quantity: -2},
+			}},
+			prepareMock: func(mockResourceRepository *mock.MockResourceRepository) {
+				mockResourceRepository.EXPECT().FindEach(gomock.Any(), model.Query{}, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, query model.Query, fn func(model.Resource) error) error {
+						for _, resource := range RESOURCES {
+							fn(resource)
+						}
+						return nil
+					})
+			},
+		},
+		"should set negative quantities to zero": {
+			inputFix: true,
+			expectedRes: service.ResourceReconcileResponse{Checked: 2, Data: []service.ResourceReconcile{
+				{ID: 2, Name: "Feijão", Measurement: "Kg", Quantity: -2, Fixed: true},
+			}},
+			prepareMock: func(mockResourceRepository *mock.MockResourceRepository) {
+				mockResourceRepository.EXPECT().FindEach(gomock.Any(), model.Query{}, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, query model.Query, fn func(model.Resource) error) error {
+						for _, resource := range RESOURCES {
+							fn(resource)
+						}
+						return nil
+					})
+				mockResourceRepository.EXPECT().UpdateQuantity(gomock.Any(), 2, 0.0).Return(nil)
+			},
+		},
+		"should throw error": {
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockResourceRepository *mock.MockResourceRepository) {
+				mockResourceRepository.EXPECT().FindEach(gomock.Any(), model.Query{}, gomock.Any()).Return(fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockResourceRepository := mock.NewMockResourceRepository(ctrl)
+			cs.prepareMock(mockResourceRepository)
+
+			impl := &service.ResourceServiceImpl{ResourceRepository: mockResourceRepository}
+
+			// when
+			res, err := impl.Reconcile(ctx, cs.inputFix)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_ResourceService_Reconcile_Webhooks(t *testing.T) {
+	t.Run("should not publish low stock when fixed quantity was already low", func(t *testing.T) {
+		// given
+		ctrl, ctx := gomock.WithContext(context.Background(), t)
+		defer ctrl.Finish()
+
+		mockResourceRepository := mock.NewMockResourceRepository(ctrl)
+		mockWebhooks := mock.NewMockWebhookPublisher(ctrl)
+		mockResourceRepository.EXPECT().FindEach(gomock.Any(), model.Query{}, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, query model.Query, fn func(model.Resource) error) error {
+				return fn(model.Resource{ID: 2, Name: "Feijão", Measurement: "Kg", Quantity: -2})
+			})
+		gomock.InOrder(
+			mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 2).
+				Return(&model.Resource{ID: 2, Name: "Feijão", Quantity: -2}, nil),
+			mockResourceRepository.EXPECT().UpdateQuantity(gomock.Any(), 2, 0.0).Return(nil),
+			mockResourceRepository.EXPECT().FindOneById(gomock.Any(), 2).
+				Return(&model.Resource{ID: 2, Name: "Feijão", Quantity: 0}, nil),
+		)
+
+		impl := &service.ResourceServiceImpl{
+			ResourceRepository: mockResourceRepository,
+			Webhooks:           mockWebhooks,
+			LowStockQuantity:   5,
+		}
+
+		// when
+		res, err := impl.Reconcile(ctx, true)
+
+		// then
+		assert.Nil(t, err)
+		assert.True(t, res.Data[0].Fixed)
+	})
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+var searchWordRegexp = regexp.MustCompile(`[\pL\pN]+`)
+
+//go:generate mockgen -destination ../../mock/search_service_mock.go -package mock . SearchService
+type SearchService interface {
+	Search(ctx context.Context, terms string, limit int) (SearchResponse, error)
+}
+
+type SearchServiceImpl struct {
+	SearchRepository repository.SearchRepository
+}
+
+func (impl *SearchServiceImpl) Search(ctx context.Context, terms string, limit int) (SearchResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.search.search")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.search.search"})
+
+	tokens := infra.Tokenize(terms)
+	if len(tokens) == 0 {
+		err := &exception.InvalidQueryException{Err: fmt.Errorf("search must have at least one word")}
+		log.Error(err.Error())
+		return SearchResponse{}, err
+	}
+
+	hits, err := impl.SearchRepository.Search(ctx, terms, limit)
+	if err != nil {
+		log.Error(err.Error())
+		return SearchResponse{}, err
+	}
+
+	words := map[string]bool{}
+	for _, token := range tokens {
+		words[token] = true
+	}
+	documents := map[string]bool{}
+	for _, word := range strings.Fields(terms) {
+		documents[normalizeDocument(word)] = true
+	}
+
+	res := []SearchHit{}
+	for _, hit := range hits {
+		h := SearchHit{Type: hit.Type, Score: hit.Score, Highlights: map[string]string{}}
+
+		if hit.Family != nil {
+			h.Family = &Family{
+				ID:           hit.Family.ID,
+				CreatedAt:    hit.Family.CreatedAt.Format("2006-01-02T15:04:05"),
+				UpdatedAt:    hit.Family.UpdatedAt.Format("2006-01-02T15:04:05"),
+				Name:         hit.Family.Name,
+				Country:      hit.Family.Country,
+				State:        hit.Family.State,
+				City:         hit.Family.City,
+				Neighborhood: hit.Family.Neighborhood,
+				Street:       hit.Family.Street,
+				Number:       hit.Family.Number,
+				Complement:   hit.Family.Complement,
+				Zipcode:      hit.Family.Zipcode,
+			}
+
+			for field, value := range map[string]string{
+				"name":         hit.Family.Name,
+				"city":         hit.Family.City,
+				"neighborhood": hit.Family.Neighborhood,
+				"street":       hit.Family.Street,
+				"number":       hit.Family.Number,
+				"complement":   hit.Family.Complement,
+				"zipcode":      hit.Family.Zipcode,
+			} {
+				if highlighted, ok := highlight(value, words); ok {
+					h.Highlights[field] = highlighted
+				}
+			}
+		}
+
+		if hit.Person != nil {
+			h.Person = &Person{
+				ID:        hit.Person.ID,
+				CreatedAt: hit.Person.CreatedAt.Format("2006-01-02T15:04:05"),
+				UpdatedAt: hit.Person.UpdatedAt.Format("2006-01-02T15:04:05"),
+				FamilyID:  hit.Person.FamilyID,
+				Name:      hit.Person.Name,
+				Document:  hit.Person.Document,
+			}
+
+			if highlighted, ok := highlight(hit.Person.Name, words); ok {
+				h.Highlights["name"] = highlighted
+			}
+			if hit.Person.Document != "" && documents[normalizeDocument(hit.Person.Document)] {
+				h.Highlights["document"] = "<em>" + html.EscapeString(hit.Person.Document) + "</em>"
+			}
+		}
+
+		res = append(res, h)
+	}
+
+	return SearchResponse{Data: res}, nil
+}
+
+// highlight wraps the words of value found in words with <em> and escapes the rest as HTML,
+// returning false when no word is found
+func highlight(value string, words map[string]bool) (string, bool) {
+	var res strings.Builder
+	found := false
+	last := 0
+
+	for _, loc := range searchWordRegexp.FindAllStringIndex(value, -1) {
+		res.WriteString(html.EscapeString(value[last:loc[0]]))
+		last = loc[1]
+
+		word := value[loc[0]:loc[1]]
+		tokens := infra.Tokenize(word)
+		if len(tokens) > 0 && words[tokens[0]] {
+			found = true
+			res.WriteString("<em>" + html.EscapeString(word) + "</em>")
+		} else {
+			res.WriteString(html.EscapeString(word))
+		}
+	}
+	res.WriteString(html.EscapeString(value[last:]))
+
+	return res.String(), found
+}
+
+func normalizeDocument(document string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, document)
+}
@@ -0,0 +1,14 @@
+package service
+
+type SearchHit struct {
+	Type  string `json:"type" example:"family"`
+	Score int    `json:"score" example:"3"`
+	// Highlights has the matched fields with the matched words wrapped in <em>, escaped as HTML
+	Highlights map[string]string `json:"highlights"`
+	Family     *Family           `json:"family,omitempty"`
+	Person     *Person           `json:"person,omitempty"`
+}
+
+type SearchResponse struct {
+	Data []SearchHit `json:"data"`
+}
@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/exception"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_SearchService_Search(t *testing.T) {
+	const DATE = "2000-01-01T12:03:00"
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		inputTerms  string
+		expectedRes service.SearchResponse
+		expectedErr error
+		prepareMock func(mockSearchRepository *mock.MockSearchRepository)
+	}{
+		"should return hits with highlights": {
+			inputTerms: "maria rua 25",
+			expectedRes: service.SearchResponse{Data: []service.SearchHit{
+				{
+					Type:  "family",
+					Score: 3,
+					Highlights: map[string]string{
+						"street": "<em>Rua</em> São João",
+						"number": "<em>25</em>",
+					},
+					Family: &service.Family{ID: 1, CreatedAt: DATE, UpdatedAt: DATE, Name: "Silva",
+						City: "São Paulo", Street: "Rua São João", Number: "25"},
+				},
+				{
+					Type:       "person",
+					Score:      1,
+					Highlights: map[string]string{"name": "<em>María</em> &amp; Silva"},
+					Person:     &service.Person{ID: 2, CreatedAt: DATE, UpdatedAt: DATE, FamilyID: 1, Name: "María & Silva"},
+				},
+			}},
+			prepareMock: func(mockSearchRepository *mock.MockSearchRepository) {
+				mockSearchRepository.EXPECT().Search(gomock.Any(), "maria rua 25", 20).Return([]model.SearchHit{
+					{Type: model.SearchHitFamily, Score: 3, Family: &model.Family{ID: 1, CreatedAt: DATETIME,
+						UpdatedAt: DATETIME, Name: "Silva", City: "São Paulo", Street: "Rua São João", Number: "25"}},
+					{Type: model.SearchHitPerson, Score: 1, Person: &model.Person{ID: 2, CreatedAt: DATETIME,
+						UpdatedAt: DATETIME, FamilyID: 1, Name: "María & Silva"}},
+				}, nil)
+			},
+		},
+		"should highlight document": {
+			inputTerms: "12345678900",
+			expectedRes: service.SearchResponse{Data: []service.SearchHit{{
+				Type:       "person",
+				Score:      1,
+				Highlights: map[string]string{"document": "<em>123.456.789-00</em>"},
+				Person: &service.Person{ID: 2, CreatedAt: DATE, UpdatedAt: DATE, FamilyID: 1, Name: "Maria",
+					Document: "123.456.789-00"},
+			}}},
+			prepareMock: func(mockSearchRepository *mock.MockSearchRepository) {
+				mockSearchRepository.EXPECT().Search(gomock.Any(), "12345678900", 20).Return([]model.SearchHit{
+					{Type: model.SearchHitPerson, Score: 1, Person: &model.Person{ID: 2, CreatedAt: DATETIME,
+						UpdatedAt: DATETIME, FamilyID: 1, Name: "Maria", Document: "123.456.789-00"}},
+				}, nil)
+			},
+		},
+		"should throw invalid query exception when terms have no words": {
+			inputTerms:  " - ",
+			expectedErr: &exception.InvalidQueryException{Err: fmt.Errorf("search must have at least one word")},
+			prepareMock: func(mockSearchRepository *mock.MockSearchRepository) {},
+		},
+		"should throw error": {
+			inputTerms:  "maria",
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockSearchRepository *mock.MockSearchRepository) {
+				mockSearchRepository.EXPECT().Search(gomock.Any(), "maria", 20).Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockSearchRepository := mock.NewMockSearchRepository(ctrl)
+			cs.prepareMock(mockSearchRepository)
+
+			impl := &service.SearchServiceImpl{SearchRepository: mockSearchRepository}
+
+			// when
+			res, err := impl.Search(ctx, cs.inputTerms, 20)
+
+			// then
+			assert.Equal(t, cs.expectedRes, res)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
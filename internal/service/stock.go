@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+//go:generate mockgen -destination ../../mock/stock_service_mock.go -package mock . StockService
+type StockService interface {
+	StockPublisher
+	Subscribe(ctx context.Context, lastEventID int64) *StockSubscription
+}
+
+// StockPublisher broadcasts the quantity of a resource after it changes
+//
+//go:generate mockgen -destination ../../mock/stock_publisher_mock.go -package mock . StockPublisher
+type StockPublisher interface {
+	Publish(ctx context.Context, resource model.Resource, reason string)
+}
+
+// StockServiceImpl only reaches the subscribers connected to this instance
+type StockServiceImpl struct {
+	Broker *infra.Broker[StockEvent]
+}
+
+func (impl *StockServiceImpl) Publish(ctx context.Context, resource model.Resource, reason string) {
+	impl.Broker.Publish(StockEvent{
+		ResourceID:  resource.ID,
+		Name:        resource.Name,
+		Measurement: resource.Measurement,
+		Quantity:    resource.Quantity,
+		Reason:      reason,
+		ChangedAt:   time.Now().Format("2006-01-02T15:04:05"),
+	})
+}
+
+func (impl *StockServiceImpl) Subscribe(ctx context.Context, lastEventID int64) *StockSubscription {
+	events, missed, cancel := impl.Broker.Subscribe(lastEventID)
+
+	return &StockSubscription{Missed: missed, Events: events, Close: cancel}
+}
@@ -0,0 +1,27 @@
+package service
+
+import "github.com/viniosilva/socialassistanceapi/internal/infra"
+
+const (
+	StockReasonUpdate = "update"
+	StockReasonDonate = "donate"
+	StockReasonReturn = "return"
+)
+
+// StockEvent is sent by the resources stream when the quantity of a resource changes
+type StockEvent struct {
+	ResourceID  int     `json:"resource_id" example:"1"`
+	Name        string  `json:"name" example:"Arroz"`
+	Measurement string  `json:"measurement" example:"Kg"`
+	Quantity    float64 `json:"quantity" example:"10"`
+	Reason      string  `json:"reason" example:"donate"`
+	ChangedAt   string  `json:"changed_at" example:"2000-01-01T12:03:00"`
+}
+
+// StockSubscription receives the events missed since the Last-Event-ID and then the next ones,
+// until Close is called or Events is closed for falling behind
+type StockSubscription struct {
+	Missed []infra.BrokerEvent[StockEvent]
+	Events <-chan infra.BrokerEvent[StockEvent]
+	Close  func()
+}
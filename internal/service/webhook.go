@@ -0,0 +1,332 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/viniosilva/socialassistanceapi/internal/infra"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/repository"
+)
+
+// webhookMaxBackoff caps the wait between attempts of a delivery
+const webhookMaxBackoff = 24 * time.Hour
+
+//go:generate mockgen -destination ../../mock/webhook_service_mock.go -package mock . WebhookService
+type WebhookService interface {
+	WebhookPublisher
+	FindAll(ctx context.Context) (WebhooksResponse, error)
+	FindOneById(ctx context.Context, webhookID int) (WebhookResponse, error)
+	Create(ctx context.Context, dto WebhookCreateDto) (WebhookResponse, error)
+	Update(ctx context.Context, dto WebhookUpdateDto) error
+	Delete(ctx context.Context, webhookID int) error
+	FindDeliveries(ctx context.Context, webhookID, limit int) (WebhookDeliveriesResponse, error)
+	Deliver(ctx context.Context) (int, error)
+}
+
+// WebhookPublisher queues domain events to the webhooks subscribed to them. Failing to
+// queue an event is logged and does not fail the change that caused it.
+//
+//go:generate mockgen -destination ../../mock/webhook_publisher_mock.go -package mock . WebhookPublisher
+type WebhookPublisher interface {
+	Publish(ctx context.Context, event string, data interface{})
+}
+
+type WebhookServiceImpl struct {
+	WebhookRepository repository.WebhookRepository
+	HttpClient        *http.Client
+	// BatchSize deliveries are claimed by each Deliver, which gives up on one after MaxAttempts
+	// attempts. The n-th retry waits Backoff * 2^(n-1).
+	BatchSize   int
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+func (impl *WebhookServiceImpl) FindAll(ctx context.Context) (WebhooksResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.webhook.find_all")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.webhook.find_all"})
+
+	subscriptions, err := impl.WebhookRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(err.Error())
+		return WebhooksResponse{}, err
+	}
+
+	res := []Webhook{}
+	for _, subscription := range subscriptions {
+		res = append(res, *impl.Scan(subscription))
+	}
+
+	return WebhooksResponse{Data: res}, nil
+}
+
+func (impl *WebhookServiceImpl) FindOneById(ctx context.Context, webhookID int) (WebhookResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.webhook.find_one_by_id")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.webhook.find_one_by_id"})
+
+	subscription, err := impl.WebhookRepository.FindOneById(ctx, webhookID)
+	if err != nil {
+		log.Error(err.Error())
+		return WebhookResponse{}, err
+	}
+
+	return WebhookResponse{Data: impl.Scan(*subscription)}, nil
+}
+
+func (impl *WebhookServiceImpl) Create(ctx context.Context, dto WebhookCreateDto) (WebhookResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.webhook.create")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.webhook.create"})
+
+	secret := dto.Secret
+	if secret == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			log.Error(err.Error())
+			return WebhookResponse{}, err
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	subscription, err := impl.WebhookRepository.Create(ctx, model.WebhookSubscription{
+		URL:    dto.URL,
+		Events: dto.Events,
+		Secret: secret,
+		Active: true,
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return WebhookResponse{}, err
+	}
+
+	res := impl.Scan(*subscription)
+	res.Secret = subscription.Secret
+
+	return WebhookResponse{Data: res}, nil
+}
+
+func (impl *WebhookServiceImpl) Update(ctx context.Context, dto WebhookUpdateDto) error {
+	ctx, span := infra.StartSpan(ctx, "internal.service.webhook.update")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.webhook.update"})
+
+	subscription, err := impl.WebhookRepository.FindOneById(ctx, dto.ID)
+	if err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	if dto.URL != "" {
+		subscription.URL = dto.URL
+	}
+	if len(dto.Events) > 0 {
+		subscription.Events = dto.Events
+	}
+	if dto.Active != nil {
+		subscription.Active = *dto.Active
+	}
+
+	if err := impl.WebhookRepository.Update(ctx, *subscription); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (impl *WebhookServiceImpl) Delete(ctx context.Context, webhookID int) error {
+	ctx, span := infra.StartSpan(ctx, "internal.service.webhook.delete")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.webhook.delete"})
+
+	if err := impl.WebhookRepository.Delete(ctx, webhookID); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (impl *WebhookServiceImpl) FindDeliveries(ctx context.Context, webhookID, limit int) (WebhookDeliveriesResponse, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.webhook.find_deliveries")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.webhook.find_deliveries"})
+
+	if _, err := impl.WebhookRepository.FindOneById(ctx, webhookID); err != nil {
+		log.Error(err.Error())
+		return WebhookDeliveriesResponse{}, err
+	}
+
+	deliveries, err := impl.WebhookRepository.FindDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		log.Error(err.Error())
+		return WebhookDeliveriesResponse{}, err
+	}
+
+	res := []WebhookDelivery{}
+	for _, d := range deliveries {
+		history := []WebhookAttempt{}
+		for _, a := range d.History {
+			history = append(history, WebhookAttempt{
+				CreatedAt:  a.CreatedAt.Format("2006-01-02T15:04:05"),
+				StatusCode: a.StatusCode,
+				Error:      a.Error,
+				DurationMs: a.DurationMs,
+			})
+		}
+
+		res = append(res, WebhookDelivery{
+			ID:            d.ID,
+			CreatedAt:     d.CreatedAt.Format("2006-01-02T15:04:05"),
+			Event:         d.Event,
+			Payload:       json.RawMessage(d.Payload),
+			Status:        d.Status,
+			Attempts:      d.Attempts,
+			NextAttemptAt: d.NextAttemptAt.Format("2006-01-02T15:04:05"),
+			History:       history,
+		})
+	}
+
+	return WebhookDeliveriesResponse{Data: res}, nil
+}
+
+func (impl *WebhookServiceImpl) Publish(ctx context.Context, event string, data interface{}) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.webhook.publish")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.webhook.publish"})
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+
+	if _, err := impl.WebhookRepository.Enqueue(ctx, event, string(payload)); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+// Deliver sends a batch of the queued deliveries that are due, returning how many were sent.
+// Deliveries refused by the subscriber or failing to reach it are retried with exponential backoff.
+func (impl *WebhookServiceImpl) Deliver(ctx context.Context) (int, error) {
+	ctx, span := infra.StartSpan(ctx, "internal.service.webhook.deliver")
+	defer span.End()
+	log := infra.Logger(ctx).WithFields(logrus.Fields{"path": "internal.service.webhook.deliver"})
+
+	// the claimed deliveries are sent one by one, so the lease covers each of them timing out
+	lease := time.Duration(impl.BatchSize)*impl.HttpClient.Timeout + time.Minute
+
+	deliveries, err := impl.WebhookRepository.Claim(ctx, impl.BatchSize, lease)
+	if err != nil {
+		log.Error(err.Error())
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		attempt := impl.send(ctx, delivery)
+
+		delivery.Attempts++
+		if attempt.Error == "" && attempt.StatusCode >= 200 && attempt.StatusCode < 300 {
+			delivery.Status = model.WebhookDeliverySucceeded
+		} else if delivery.Attempts >= impl.MaxAttempts {
+			delivery.Status = model.WebhookDeliveryFailed
+		} else {
+			delivery.NextAttemptAt = time.Now().Add(impl.backoff(delivery.Attempts))
+		}
+
+		if err := impl.WebhookRepository.SaveAttempt(ctx, delivery, attempt); err != nil {
+			log.Error(err.Error())
+			return 0, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (impl *WebhookServiceImpl) send(ctx context.Context, delivery model.WebhookDelivery) model.WebhookAttempt {
+	start := time.Now()
+	attempt := model.WebhookAttempt{CreatedAt: start, DeliveryID: delivery.ID}
+
+	body, err := json.Marshal(WebhookMessage{
+		ID:        delivery.ID,
+		Event:     delivery.Event,
+		CreatedAt: delivery.CreatedAt.Format("2006-01-02T15:04:05"),
+		Data:      json.RawMessage(delivery.Payload),
+	})
+	if err != nil {
+		attempt.Error = truncate(err.Error(), 1000)
+		return attempt
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = truncate(err.Error(), 1000)
+		return attempt
+	}
+
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Webhook-Id", strconv.Itoa(delivery.ID))
+	req.Header.Set("Webhook-Event", delivery.Event)
+	req.Header.Set("Webhook-Timestamp", timestamp)
+	req.Header.Set("Webhook-Signature", WebhookSignature(delivery.Secret, timestamp, body))
+
+	res, err := impl.HttpClient.Do(req)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		attempt.Error = truncate(err.Error(), 1000)
+		return attempt
+	}
+	defer res.Body.Close()
+
+	// only the status is kept, the body of the subscriber is neither stored nor shown
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1000))
+	attempt.StatusCode = res.StatusCode
+
+	return attempt
+}
+
+func (impl *WebhookServiceImpl) backoff(attempts int) time.Duration {
+	wait := impl.Backoff
+	for i := 1; i < attempts && wait < webhookMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+
+	return wait
+}
+
+func (impl *WebhookServiceImpl) Scan(data model.WebhookSubscription) *Webhook {
+	return &Webhook{
+		ID:        data.ID,
+		CreatedAt: data.CreatedAt.Format("2006-01-02T15:04:05"),
+		UpdatedAt: data.UpdatedAt.Format("2006-01-02T15:04:05"),
+		URL:       data.URL,
+		Events:    data.Events,
+		Active:    data.Active,
+	}
+}
+
+// WebhookSignature signs "timestamp.body" with HMAC-SHA256, as sent in the Webhook-Signature header
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%s.", timestamp)))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
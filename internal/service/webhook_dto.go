@@ -0,0 +1,73 @@
+package service
+
+import "encoding/json"
+
+type Webhook struct {
+	ID        int      `json:"id" example:"1"`
+	CreatedAt string   `json:"created_at" example:"2000-01-01T12:03:00"`
+	UpdatedAt string   `json:"updated_at" example:"2000-01-01T12:03:00"`
+	URL       string   `json:"url" example:"https://partner.org/webhooks"`
+	Events    []string `json:"events" example:"family.created,donation.created"`
+	Active    bool     `json:"active" example:"true"`
+	// Secret is only returned when the webhook is created
+	Secret string `json:"secret,omitempty" example:"2f1c0e7d9a8b4c3d2f1c0e7d9a8b4c3d"`
+}
+
+type WebhookResponse struct {
+	Data *Webhook `json:"data"`
+}
+
+type WebhooksResponse struct {
+	Data []Webhook `json:"data"`
+}
+
+type WebhookCreateDto struct {
+	URL    string   `json:"url" example:"https://partner.org/webhooks" binding:"required,http_url"`
+	Events []string `json:"events" example:"family.created,donation.created" binding:"required,min=1,dive,oneof=family.created donation.created resource.low_stock report.distributions"`
+	// Secret signs the deliveries, a random one is generated when empty
+	Secret string `json:"secret" example:"2f1c0e7d9a8b4c3d2f1c0e7d9a8b4c3d" binding:"omitempty,min=16,max=128"`
+}
+
+type WebhookUpdateDto struct {
+	ID     int      `json:"-"`
+	URL    string   `json:"url" example:"https://partner.org/webhooks" binding:"omitempty,http_url"`
+	Events []string `json:"events" example:"resource.low_stock" binding:"omitempty,min=1,dive,oneof=family.created donation.created resource.low_stock report.distributions"`
+	Active *bool    `json:"active" example:"false"`
+}
+
+type WebhookAttempt struct {
+	CreatedAt  string `json:"created_at" example:"2000-01-01T12:03:00"`
+	StatusCode int    `json:"status_code" example:"500"`
+	Error      string `json:"error" example:""`
+	DurationMs int64  `json:"duration_ms" example:"120"`
+}
+
+type WebhookDelivery struct {
+	ID            int              `json:"id" example:"1"`
+	CreatedAt     string           `json:"created_at" example:"2000-01-01T12:03:00"`
+	Event         string           `json:"event" example:"family.created"`
+	Payload       json.RawMessage  `json:"payload" swaggertype:"object"`
+	Status        string           `json:"status" example:"pending"`
+	Attempts      int              `json:"attempts" example:"1"`
+	NextAttemptAt string           `json:"next_attempt_at" example:"2000-01-01T12:03:30"`
+	History       []WebhookAttempt `json:"history"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Data []WebhookDelivery `json:"data"`
+}
+
+// WebhookMessage is the body posted to the subscribers
+type WebhookMessage struct {
+	ID        int             `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt string          `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DonationEvent is the data of the donation.created event
+type DonationEvent struct {
+	ResourceID int     `json:"resource_id" example:"1"`
+	FamilyID   int     `json:"family_id" example:"1"`
+	Quantity   float64 `json:"quantity" example:"10"`
+}
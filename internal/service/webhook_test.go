@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/socialassistanceapi/internal/model"
+	"github.com/viniosilva/socialassistanceapi/internal/service"
+	"github.com/viniosilva/socialassistanceapi/mock"
+)
+
+func Test_WebhookService_Deliver(t *testing.T) {
+	DATETIME := time.Date(2000, 1, 1, 12, 3, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		inputAttempts      int
+		inputPath          string
+		receiverStatus     int
+		receiverDown       bool
+		expectedStatus     string
+		expectedAttempt    model.WebhookAttempt
+		expectedRetryAfter time.Duration
+	}{
+		"should deliver signed message": {
+			receiverStatus:  http.StatusOK,
+			expectedStatus:  model.WebhookDeliverySucceeded,
+			expectedAttempt: model.WebhookAttempt{DeliveryID: 1, StatusCode: http.StatusOK},
+		},
+		"should retry with backoff when receiver fails": {
+			inputAttempts:      2,
+			receiverStatus:     http.StatusInternalServerError,
+			expectedStatus:     model.WebhookDeliveryPending,
+			expectedAttempt:    model.WebhookAttempt{DeliveryID: 1, StatusCode: http.StatusInternalServerError},
+			expectedRetryAfter: 4 * time.Second,
+		},
+		"should give up after the last attempt": {
+			inputAttempts:   2,
+			receiverStatus:  http.StatusGone,
+			expectedStatus:  model.WebhookDeliveryFailed,
+			expectedAttempt: model.WebhookAttempt{DeliveryID: 1, StatusCode: http.StatusGone},
+		},
+		"should cut long error on a rune boundary": {
+			inputPath:          "/" + strings.Repeat("é", 600),
+			receiverDown:       true,
+			expectedStatus:     model.WebhookDeliveryPending,
+			expectedAttempt:    model.WebhookAttempt{DeliveryID: 1},
+			expectedRetryAfter: time.Second,
+		},
+		"should retry when receiver is down": {
+			receiverDown:       true,
+			expectedStatus:     model.WebhookDeliveryPending,
+			expectedAttempt:    model.WebhookAttempt{DeliveryID: 1},
+			expectedRetryAfter: time.Second,
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			var received *http.Request
+			var body []byte
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(cs.receiverStatus)
+				w.Write([]byte("ok"))
+			}))
+			defer receiver.Close()
+			if cs.receiverDown {
+				receiver.Close()
+			}
+
+			maxAttempts := 5
+			if cs.expectedStatus == model.WebhookDeliveryFailed {
+				maxAttempts = cs.inputAttempts + 1
+			}
+
+			delivery := model.WebhookDelivery{
+				ID:            1,
+				CreatedAt:     DATETIME,
+				Event:         model.WebhookEventFamilyCreated,
+				Payload:       `{"id":1}`,
+				Status:        model.WebhookDeliveryPending,
+				Attempts:      cs.inputAttempts,
+				NextAttemptAt: DATETIME,
+				URL:           receiver.URL + cs.inputPath,
+				Secret:        "secret",
+			}
+
+			mockWebhookRepository := mock.NewMockWebhookRepository(ctrl)
+			mockWebhookRepository.EXPECT().Claim(gomock.Any(), 10, gomock.Any()).Return([]model.WebhookDelivery{delivery}, nil)
+
+			var saved model.WebhookDelivery
+			var attempt model.WebhookAttempt
+			mockWebhookRepository.EXPECT().SaveAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+				Do(func(ctx context.Context, d model.WebhookDelivery, a model.WebhookAttempt) {
+					saved, attempt = d, a
+				}).Return(nil)
+
+			impl := &service.WebhookServiceImpl{
+				WebhookRepository: mockWebhookRepository,
+				HttpClient:        &http.Client{Timeout: time.Second},
+				BatchSize:         10,
+				MaxAttempts:       maxAttempts,
+				Backoff:           time.Second,
+			}
+
+			// when
+			start := time.Now()
+			total, err := impl.Deliver(ctx)
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, 1, total)
+			assert.Equal(t, cs.expectedStatus, saved.Status)
+			assert.Equal(t, cs.inputAttempts+1, saved.Attempts)
+			assert.Equal(t, cs.expectedAttempt.DeliveryID, attempt.DeliveryID)
+			assert.Equal(t, cs.expectedAttempt.StatusCode, attempt.StatusCode)
+
+			if cs.receiverDown {
+				assert.NotEmpty(t, attempt.Error)
+				assert.LessOrEqual(t, len(attempt.Error), 1000)
+				assert.True(t, utf8.ValidString(attempt.Error))
+			} else {
+				assert.Equal(t, `{"id":1,"event":"family.created","created_at":"2000-01-01T12:03:00","data":{"id":1}}`, string(body))
+				assert.Equal(t, "family.created", received.Header.Get("Webhook-Event"))
+				assert.Equal(t, "1", received.Header.Get("Webhook-Id"))
+				assert.Equal(t, service.WebhookSignature("secret", received.Header.Get("Webhook-Timestamp"), body),
+					received.Header.Get("Webhook-Signature"))
+			}
+
+			if cs.expectedRetryAfter > 0 {
+				assert.WithinDuration(t, start.Add(cs.expectedRetryAfter), saved.NextAttemptAt, 500*time.Millisecond)
+			}
+		})
+	}
+}
+
+func Test_WebhookService_Create(t *testing.T) {
+	cases := map[string]struct {
+		inputDto    service.WebhookCreateDto
+		expectedErr error
+		prepareMock func(mockWebhookRepository *mock.MockWebhookRepository)
+	}{
+		"should create webhook with generated secret": {
+			inputDto: service.WebhookCreateDto{URL: "https://partner.org/webhooks", Events: []string{"family.created"}},
+			prepareMock: func(mockWebhookRepository *mock.MockWebhookRepository) {
+				mockWebhookRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, data model.WebhookSubscription) (*model.WebhookSubscription, error) {
+						assert.Len(t, data.Secret, 32)
+						assert.True(t, data.Active)
+						data.ID = 1
+						return &data, nil
+					})
+			},
+		},
+		"should throw error": {
+			inputDto:    service.WebhookCreateDto{URL: "https://partner.org/webhooks", Events: []string{"family.created"}},
+			expectedErr: fmt.Errorf("error"),
+			prepareMock: func(mockWebhookRepository *mock.MockWebhookRepository) {
+				mockWebhookRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// given
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			defer ctrl.Finish()
+
+			mockWebhookRepository := mock.NewMockWebhookRepository(ctrl)
+			cs.prepareMock(mockWebhookRepository)
+
+			impl := &service.WebhookServiceImpl{WebhookRepository: mockWebhookRepository}
+
+			// when
+			res, err := impl.Create(ctx, cs.inputDto)
+
+			// then
+			assert.Equal(t, cs.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, 1, res.Data.ID)
+				assert.Len(t, res.Data.Secret, 32)
+			}
+		})
+	}
+}
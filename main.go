@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"os"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"github.com/viniosilva/socialassistanceapi/internal/api"
 	"github.com/viniosilva/socialassistanceapi/internal/configuration"
 	"github.com/viniosilva/socialassistanceapi/internal/infra"
-	"github.com/viniosilva/socialassistanceapi/internal/repository"
 	"github.com/viniosilva/socialassistanceapi/internal/service"
 )
 
@@ -19,32 +22,128 @@ func main() {
 	if err != nil {
 		log.Fatal("cannot load config: ", err)
 	}
+	if err := infra.LoggerConfigure(cfg.Log.Level, cfg.Log.Format, cfg.Log.Redact); err != nil {
+		log.Fatal("cannot configure log: ", err)
+	}
 
-	mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-		cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
-	defer mysql.DB.Close()
+	shutdownTracing, err := infra.TracingConfigure(context.Background(), cfg.Tracing.ServiceName,
+		cfg.Tracing.Exporter, cfg.Tracing.Endpoint, cfg.Tracing.Insecure, cfg.Tracing.SampleRatio)
+	if err != nil {
+		log.Fatal("cannot configure tracing: ", err)
+	}
 
-	healthRepository := &repository.HealthRepositoryImpl{DB: mysql}
-	personRepository := &repository.PersonRepositoryImpl{DB: mysql}
-	resourceRepository := &repository.ResourceRepositoryImpl{DB: mysql}
-	familyRepository := &repository.FamilyRepositoryImpl{DB: mysql}
-	donateResourceRepository := &repository.DonateResourceRepositoryImpl{DB: mysql}
+	err = run(cfg, os.Args[1:])
+	shutdownTracing(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+}
 
-	healthService := &service.HealthServiceImpl{HealthRepository: healthRepository}
-	personService := &service.PersonServiceImpl{PersonRepository: personRepository}
-	resourceService := &service.ResourceServiceImpl{ResourceRepository: resourceRepository}
-	familyService := &service.FamilyServiceImpl{FamilyRepository: familyRepository}
-	donateResourceService := &service.DonateResourceServiceImpl{DonateResourceRepository: donateResourceRepository}
+// serve runs the HTTP and gRPC servers, and the scheduler where it is enabled, until ctx is done
+func serve(ctx context.Context, cfg configuration.Config, a *app) error {
+	var metricsHandler http.Handler
+	if cfg.Metrics.Enabled {
+		registry := infra.NewMetricsRegistry(a.MySQL.DB, &service.StockCollector{
+			ResourceRepository: a.ResourceRepository,
+			Timeout:            time.Duration(cfg.Metrics.StockTimeoutMs) * time.Millisecond,
+		})
+		metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
 
-	api := &api.ApiImpl{
+	httpApi := &api.ApiImpl{
 		Addr:                  fmt.Sprintf("%s:%d", cfg.Http.Host, cfg.Http.Port),
-		HealthService:         healthService,
-		PersonService:         personService,
-		FamilyService:         familyService,
-		ResourceService:       resourceService,
-		DonateResourceService: donateResourceService,
+		HealthService:         a.HealthService,
+		PersonService:         a.PersonService,
+		FamilyService:         a.FamilyService,
+		ResourceService:       a.ResourceService,
+		DonateResourceService: a.DonateResourceService,
+		IdempotencyService:    a.IdempotencyService,
+		SearchService:         a.SearchService,
+		ImportService:         a.ImportService,
+		ExportService:         a.ExportService,
+		ReportService:         a.ReportService,
+		ReceiptService:        a.ReceiptService,
+		DashboardService:      a.DashboardService,
+		WebhookService:        a.WebhookService,
+		StockService:          a.StockService,
+		NotificationService:   a.NotificationService,
+		JobService:            a.JobService,
+		MetricsHandler:        metricsHandler,
+		StreamHeartbeat:       time.Duration(cfg.Stream.HeartbeatMs) * time.Millisecond,
+		ReadTimeout:           time.Duration(cfg.Http.ReadTimeoutMs) * time.Millisecond,
+		ReadHeaderTimeout:     time.Duration(cfg.Http.ReadHeaderTimeoutMs) * time.Millisecond,
+		WriteTimeout:          time.Duration(cfg.Http.WriteTimeoutMs) * time.Millisecond,
+		IdleTimeout:           time.Duration(cfg.Http.IdleTimeoutMs) * time.Millisecond,
+		MaxHeaderBytes:        cfg.Http.MaxHeaderBytes,
+		MaxBodyBytes:          cfg.Http.MaxBodyBytes,
+	}
+
+	grpcApi := &api.GrpcApiImpl{
+		Addr:                  fmt.Sprintf("%s:%d", cfg.Grpc.Host, cfg.Grpc.Port),
+		FamilyService:         a.FamilyService,
+		PersonService:         a.PersonService,
+		ResourceService:       a.ResourceService,
+		DonateResourceService: a.DonateResourceService,
+	}
+	grpcApi.Configure()
+
+	// the scheduler outlives ctx, to be stopped by the shutdown once the servers are drained
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if cfg.Scheduler.Enabled {
+		if err := a.JobService.Start(jobsCtx); err != nil {
+			return fmt.Errorf("cannot start scheduler: %w", err)
+		}
 	}
 
-	api.Configure()
-	api.Start()
+	errs := make(chan error, 2)
+	go func() {
+		if err := grpcApi.Start(); err != nil {
+			errs <- fmt.Errorf("cannot start grpc server: %w", err)
+		}
+	}()
+
+	httpApi.Configure()
+	go func() {
+		if err := httpApi.Start(); err != nil {
+			errs <- fmt.Errorf("cannot start http server: %w", err)
+		}
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+	shutdown(cfg.Http, httpApi, grpcApi, a.JobService, stopJobs)
+
+	return err
+}
+
+// shutdown drains the servers, then stops the scheduler with stopJobs and waits for the jobs it
+// is running. The MySQL pool is closed and the spans are flushed by the caller afterwards.
+func shutdown(cfg configuration.HttpConfig, httpApi api.Api, grpcApi api.GrpcApi, jobService service.JobService,
+	stopJobs context.CancelFunc) {
+	log.Info("shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	if err := httpApi.Shutdown(ctx); err != nil {
+		log.Error("cannot drain http server: ", err)
+	}
+	if err := grpcApi.Shutdown(ctx); err != nil {
+		log.Error("cannot drain grpc server: ", err)
+	}
+
+	stopJobs()
+	jobsDone := make(chan struct{})
+	go func() {
+		jobService.Wait()
+		close(jobsDone)
+	}()
+	select {
+	case <-jobsDone:
+	case <-ctx.Done():
+		log.Error("jobs still running on shutdown")
+	}
 }
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: DashboardApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDashboardApi is a mock of DashboardApi interface.
+type MockDashboardApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockDashboardApiMockRecorder
+}
+
+// MockDashboardApiMockRecorder is the mock recorder for MockDashboardApi.
+type MockDashboardApiMockRecorder struct {
+	mock *MockDashboardApi
+}
+
+// NewMockDashboardApi creates a new mock instance.
+func NewMockDashboardApi(ctrl *gomock.Controller) *MockDashboardApi {
+	mock := &MockDashboardApi{ctrl: ctrl}
+	mock.recorder = &MockDashboardApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDashboardApi) EXPECT() *MockDashboardApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockDashboardApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockDashboardApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockDashboardApi)(nil).Configure))
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/repository (interfaces: DashboardRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// MockDashboardRepository is a mock of DashboardRepository interface.
+type MockDashboardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDashboardRepositoryMockRecorder
+}
+
+// MockDashboardRepositoryMockRecorder is the mock recorder for MockDashboardRepository.
+type MockDashboardRepositoryMockRecorder struct {
+	mock *MockDashboardRepository
+}
+
+// NewMockDashboardRepository creates a new mock instance.
+func NewMockDashboardRepository(ctrl *gomock.Controller) *MockDashboardRepository {
+	mock := &MockDashboardRepository{ctrl: ctrl}
+	mock.recorder = &MockDashboardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDashboardRepository) EXPECT() *MockDashboardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Days mocks base method.
+func (m *MockDashboardRepository) Days(arg0 context.Context, arg1, arg2 time.Time) ([]model.DashboardDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Days", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.DashboardDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Days indicates an expected call of Days.
+func (mr *MockDashboardRepositoryMockRecorder) Days(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Days", reflect.TypeOf((*MockDashboardRepository)(nil).Days), arg0, arg1, arg2)
+}
+
+// Summary mocks base method.
+func (m *MockDashboardRepository) Summary(arg0 context.Context, arg1 time.Time, arg2 int) (*model.Dashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Dashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summary indicates an expected call of Summary.
+func (mr *MockDashboardRepositoryMockRecorder) Summary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summary", reflect.TypeOf((*MockDashboardRepository)(nil).Summary), arg0, arg1, arg2)
+}
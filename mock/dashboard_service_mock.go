@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: DashboardService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockDashboardService is a mock of DashboardService interface.
+type MockDashboardService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDashboardServiceMockRecorder
+}
+
+// MockDashboardServiceMockRecorder is the mock recorder for MockDashboardService.
+type MockDashboardServiceMockRecorder struct {
+	mock *MockDashboardService
+}
+
+// NewMockDashboardService creates a new mock instance.
+func NewMockDashboardService(ctrl *gomock.Controller) *MockDashboardService {
+	mock := &MockDashboardService{ctrl: ctrl}
+	mock.recorder = &MockDashboardServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDashboardService) EXPECT() *MockDashboardServiceMockRecorder {
+	return m.recorder
+}
+
+// Dashboard mocks base method.
+func (m *MockDashboardService) Dashboard(arg0 context.Context, arg1 string) (service.DashboardResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dashboard", arg0, arg1)
+	ret0, _ := ret[0].(service.DashboardResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dashboard indicates an expected call of Dashboard.
+func (mr *MockDashboardServiceMockRecorder) Dashboard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dashboard", reflect.TypeOf((*MockDashboardService)(nil).Dashboard), arg0, arg1)
+}
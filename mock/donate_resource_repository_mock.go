@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
 )
 
 // MockDonateResourceRepository is a mock of DonateResourceRepository interface.
@@ -48,6 +49,50 @@ func (mr *MockDonateResourceRepositoryMockRecorder) Donate(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Donate", reflect.TypeOf((*MockDonateResourceRepository)(nil).Donate), arg0, arg1, arg2, arg3)
 }
 
+// FindAllByFamilyIDs mocks base method.
+func (m *MockDonateResourceRepository) FindAllByFamilyIDs(arg0 context.Context, arg1 []int) ([]model.ResourceToFamily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByFamilyIDs", arg0, arg1)
+	ret0, _ := ret[0].([]model.ResourceToFamily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByFamilyIDs indicates an expected call of FindAllByFamilyIDs.
+func (mr *MockDonateResourceRepositoryMockRecorder) FindAllByFamilyIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByFamilyIDs", reflect.TypeOf((*MockDonateResourceRepository)(nil).FindAllByFamilyIDs), arg0, arg1)
+}
+
+// FindEach mocks base method.
+func (m *MockDonateResourceRepository) FindEach(arg0 context.Context, arg1 model.Query, arg2 func(model.ResourceToFamily) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEach", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindEach indicates an expected call of FindEach.
+func (mr *MockDonateResourceRepositoryMockRecorder) FindEach(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEach", reflect.TypeOf((*MockDonateResourceRepository)(nil).FindEach), arg0, arg1, arg2)
+}
+
+// FindOneById mocks base method.
+func (m *MockDonateResourceRepository) FindOneById(arg0 context.Context, arg1 int) (*model.ResourceToFamily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneById", arg0, arg1)
+	ret0, _ := ret[0].(*model.ResourceToFamily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneById indicates an expected call of FindOneById.
+func (mr *MockDonateResourceRepositoryMockRecorder) FindOneById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneById", reflect.TypeOf((*MockDonateResourceRepository)(nil).FindOneById), arg0, arg1)
+}
+
 // Return mocks base method.
 func (m *MockDonateResourceRepository) Return(arg0 context.Context, arg1 int) error {
 	m.ctrl.T.Helper()
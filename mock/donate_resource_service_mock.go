@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: DonateResourceService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockDonateResourceService is a mock of DonateResourceService interface.
+type MockDonateResourceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDonateResourceServiceMockRecorder
+}
+
+// MockDonateResourceServiceMockRecorder is the mock recorder for MockDonateResourceService.
+type MockDonateResourceServiceMockRecorder struct {
+	mock *MockDonateResourceService
+}
+
+// NewMockDonateResourceService creates a new mock instance.
+func NewMockDonateResourceService(ctrl *gomock.Controller) *MockDonateResourceService {
+	mock := &MockDonateResourceService{ctrl: ctrl}
+	mock.recorder = &MockDonateResourceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDonateResourceService) EXPECT() *MockDonateResourceServiceMockRecorder {
+	return m.recorder
+}
+
+// Donate mocks base method.
+func (m *MockDonateResourceService) Donate(arg0 context.Context, arg1 service.DonateResourceDonateDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Donate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Donate indicates an expected call of Donate.
+func (mr *MockDonateResourceServiceMockRecorder) Donate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Donate", reflect.TypeOf((*MockDonateResourceService)(nil).Donate), arg0, arg1)
+}
+
+// Return mocks base method.
+func (m *MockDonateResourceService) Return(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Return", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Return indicates an expected call of Return.
+func (mr *MockDonateResourceServiceMockRecorder) Return(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockDonateResourceService)(nil).Return), arg0, arg1)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/repository (interfaces: EncryptionRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEncryptionRepository is a mock of EncryptionRepository interface.
+type MockEncryptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptionRepositoryMockRecorder
+}
+
+// MockEncryptionRepositoryMockRecorder is the mock recorder for MockEncryptionRepository.
+type MockEncryptionRepositoryMockRecorder struct {
+	mock *MockEncryptionRepository
+}
+
+// NewMockEncryptionRepository creates a new mock instance.
+func NewMockEncryptionRepository(ctrl *gomock.Controller) *MockEncryptionRepository {
+	mock := &MockEncryptionRepository{ctrl: ctrl}
+	mock.recorder = &MockEncryptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptionRepository) EXPECT() *MockEncryptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// RotateKeys mocks base method.
+func (m *MockEncryptionRepository) RotateKeys(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKeys", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKeys indicates an expected call of RotateKeys.
+func (mr *MockEncryptionRepositoryMockRecorder) RotateKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKeys", reflect.TypeOf((*MockEncryptionRepository)(nil).RotateKeys), arg0)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: EncryptionService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEncryptionService is a mock of EncryptionService interface.
+type MockEncryptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptionServiceMockRecorder
+}
+
+// MockEncryptionServiceMockRecorder is the mock recorder for MockEncryptionService.
+type MockEncryptionServiceMockRecorder struct {
+	mock *MockEncryptionService
+}
+
+// NewMockEncryptionService creates a new mock instance.
+func NewMockEncryptionService(ctrl *gomock.Controller) *MockEncryptionService {
+	mock := &MockEncryptionService{ctrl: ctrl}
+	mock.recorder = &MockEncryptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptionService) EXPECT() *MockEncryptionServiceMockRecorder {
+	return m.recorder
+}
+
+// RotateKeys mocks base method.
+func (m *MockEncryptionService) RotateKeys(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKeys", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKeys indicates an expected call of RotateKeys.
+func (mr *MockEncryptionServiceMockRecorder) RotateKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKeys", reflect.TypeOf((*MockEncryptionService)(nil).RotateKeys), arg0)
+}
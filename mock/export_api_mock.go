@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: ExportApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExportApi is a mock of ExportApi interface.
+type MockExportApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportApiMockRecorder
+}
+
+// MockExportApiMockRecorder is the mock recorder for MockExportApi.
+type MockExportApiMockRecorder struct {
+	mock *MockExportApi
+}
+
+// NewMockExportApi creates a new mock instance.
+func NewMockExportApi(ctrl *gomock.Controller) *MockExportApi {
+	mock := &MockExportApi{ctrl: ctrl}
+	mock.recorder = &MockExportApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportApi) EXPECT() *MockExportApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockExportApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockExportApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockExportApi)(nil).Configure))
+}
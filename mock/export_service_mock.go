@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: ExportService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockExportService is a mock of ExportService interface.
+type MockExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportServiceMockRecorder
+}
+
+// MockExportServiceMockRecorder is the mock recorder for MockExportService.
+type MockExportServiceMockRecorder struct {
+	mock *MockExportService
+}
+
+// NewMockExportService creates a new mock instance.
+func NewMockExportService(ctrl *gomock.Controller) *MockExportService {
+	mock := &MockExportService{ctrl: ctrl}
+	mock.recorder = &MockExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportService) EXPECT() *MockExportServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockExportService) Export(arg0 context.Context, arg1 service.ExportDto, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockExportServiceMockRecorder) Export(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockExportService)(nil).Export), arg0, arg1, arg2)
+}
@@ -36,18 +36,18 @@ func (m *MockFamilyRepository) EXPECT() *MockFamilyRepositoryMockRecorder {
 }
 
 // Count mocks base method.
-func (m *MockFamilyRepository) Count(arg0 context.Context) (int, error) {
+func (m *MockFamilyRepository) Count(arg0 context.Context, arg1 model.Query) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count", arg0)
+	ret := m.ctrl.Call(m, "Count", arg0, arg1)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Count indicates an expected call of Count.
-func (mr *MockFamilyRepositoryMockRecorder) Count(arg0 interface{}) *gomock.Call {
+func (mr *MockFamilyRepositoryMockRecorder) Count(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockFamilyRepository)(nil).Count), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockFamilyRepository)(nil).Count), arg0, arg1)
 }
 
 // Create mocks base method.
@@ -80,18 +80,33 @@ func (mr *MockFamilyRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomo
 }
 
 // FindAll mocks base method.
-func (m *MockFamilyRepository) FindAll(arg0 context.Context, arg1, arg2 int) ([]model.Family, error) {
+func (m *MockFamilyRepository) FindAll(arg0 context.Context, arg1 model.Query) ([]model.Family, model.Pagination, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
 	ret0, _ := ret[0].([]model.Family)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(model.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockFamilyRepositoryMockRecorder) FindAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockFamilyRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFamilyRepository)(nil).FindAll), arg0, arg1)
+}
+
+// FindEach mocks base method.
+func (m *MockFamilyRepository) FindEach(arg0 context.Context, arg1 model.Query, arg2 func(model.Family) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEach", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindEach indicates an expected call of FindEach.
+func (mr *MockFamilyRepositoryMockRecorder) FindEach(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFamilyRepository)(nil).FindAll), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEach", reflect.TypeOf((*MockFamilyRepository)(nil).FindEach), arg0, arg1, arg2)
 }
 
 // FindOneById mocks base method.
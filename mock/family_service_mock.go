@@ -66,34 +66,48 @@ func (mr *MockFamilyServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.
 }
 
 // FindAll mocks base method.
-func (m *MockFamilyService) FindAll(arg0 context.Context, arg1, arg2 int) ([]model.Family, int, error) {
+func (m *MockFamilyService) FindAll(arg0 context.Context, arg1 model.Query) ([]model.Family, model.Pagination, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
 	ret0, _ := ret[0].([]model.Family)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(model.Pagination)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockFamilyServiceMockRecorder) FindAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockFamilyServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFamilyService)(nil).FindAll), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFamilyService)(nil).FindAll), arg0, arg1)
 }
 
 // FindOneById mocks base method.
-func (m *MockFamilyService) FindOneById(arg0 context.Context, arg1 int) (*model.Family, error) {
+func (m *MockFamilyService) FindOneById(arg0 context.Context, arg1 int, arg2 []string) (*model.Family, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOneById", arg0, arg1)
+	ret := m.ctrl.Call(m, "FindOneById", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.Family)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindOneById indicates an expected call of FindOneById.
-func (mr *MockFamilyServiceMockRecorder) FindOneById(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockFamilyServiceMockRecorder) FindOneById(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneById", reflect.TypeOf((*MockFamilyService)(nil).FindOneById), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneById", reflect.TypeOf((*MockFamilyService)(nil).FindOneById), arg0, arg1, arg2)
+}
+
+// Include mocks base method.
+func (m *MockFamilyService) Include(arg0 context.Context, arg1 []model.Family, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Include", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Include indicates an expected call of Include.
+func (mr *MockFamilyServiceMockRecorder) Include(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Include", reflect.TypeOf((*MockFamilyService)(nil).Include), arg0, arg1, arg2)
 }
 
 // Update mocks base method.
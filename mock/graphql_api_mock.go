@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: GraphqlApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGraphqlApi is a mock of GraphqlApi interface.
+type MockGraphqlApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockGraphqlApiMockRecorder
+}
+
+// MockGraphqlApiMockRecorder is the mock recorder for MockGraphqlApi.
+type MockGraphqlApiMockRecorder struct {
+	mock *MockGraphqlApi
+}
+
+// NewMockGraphqlApi creates a new mock instance.
+func NewMockGraphqlApi(ctrl *gomock.Controller) *MockGraphqlApi {
+	mock := &MockGraphqlApi{ctrl: ctrl}
+	mock.recorder = &MockGraphqlApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGraphqlApi) EXPECT() *MockGraphqlApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockGraphqlApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockGraphqlApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockGraphqlApi)(nil).Configure))
+}
@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: GrpcApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGrpcApi is a mock of GrpcApi interface.
+type MockGrpcApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrpcApiMockRecorder
+}
+
+// MockGrpcApiMockRecorder is the mock recorder for MockGrpcApi.
+type MockGrpcApiMockRecorder struct {
+	mock *MockGrpcApi
+}
+
+// NewMockGrpcApi creates a new mock instance.
+func NewMockGrpcApi(ctrl *gomock.Controller) *MockGrpcApi {
+	mock := &MockGrpcApi{ctrl: ctrl}
+	mock.recorder = &MockGrpcApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrpcApi) EXPECT() *MockGrpcApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockGrpcApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockGrpcApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockGrpcApi)(nil).Configure))
+}
+
+// Shutdown mocks base method.
+func (m *MockGrpcApi) Shutdown(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockGrpcApiMockRecorder) Shutdown(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockGrpcApi)(nil).Shutdown), arg0)
+}
+
+// Start mocks base method.
+func (m *MockGrpcApi) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockGrpcApiMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockGrpcApi)(nil).Start))
+}
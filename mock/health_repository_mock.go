@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,6 +35,37 @@ func (m *MockHealthRepository) EXPECT() *MockHealthRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountOverdue mocks base method.
+func (m *MockHealthRepository) CountOverdue(arg0 context.Context, arg1 string, arg2 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverdue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverdue indicates an expected call of CountOverdue.
+func (mr *MockHealthRepositoryMockRecorder) CountOverdue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverdue", reflect.TypeOf((*MockHealthRepository)(nil).CountOverdue), arg0, arg1, arg2)
+}
+
+// MigrationVersion mocks base method.
+func (m *MockHealthRepository) MigrationVersion(arg0 context.Context) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrationVersion", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MigrationVersion indicates an expected call of MigrationVersion.
+func (mr *MockHealthRepositoryMockRecorder) MigrationVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrationVersion", reflect.TypeOf((*MockHealthRepository)(nil).MigrationVersion), arg0)
+}
+
 // Ping mocks base method.
 func (m *MockHealthRepository) Ping(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -35,6 +35,20 @@ func (m *MockHealthService) EXPECT() *MockHealthServiceMockRecorder {
 	return m.recorder
 }
 
+// Live mocks base method.
+func (m *MockHealthService) Live(arg0 context.Context) service.HealthResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Live", arg0)
+	ret0, _ := ret[0].(service.HealthResponse)
+	return ret0
+}
+
+// Live indicates an expected call of Live.
+func (mr *MockHealthServiceMockRecorder) Live(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Live", reflect.TypeOf((*MockHealthService)(nil).Live), arg0)
+}
+
 // Ping mocks base method.
 func (m *MockHealthService) Ping(arg0 context.Context) service.HealthResponse {
 	m.ctrl.T.Helper()
@@ -48,3 +62,17 @@ func (mr *MockHealthServiceMockRecorder) Ping(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockHealthService)(nil).Ping), arg0)
 }
+
+// Ready mocks base method.
+func (m *MockHealthService) Ready(arg0 context.Context) service.ReadinessResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", arg0)
+	ret0, _ := ret[0].(service.ReadinessResponse)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockHealthServiceMockRecorder) Ready(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockHealthService)(nil).Ready), arg0)
+}
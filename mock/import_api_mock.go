@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: ImportApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockImportApi is a mock of ImportApi interface.
+type MockImportApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportApiMockRecorder
+}
+
+// MockImportApiMockRecorder is the mock recorder for MockImportApi.
+type MockImportApiMockRecorder struct {
+	mock *MockImportApi
+}
+
+// NewMockImportApi creates a new mock instance.
+func NewMockImportApi(ctrl *gomock.Controller) *MockImportApi {
+	mock := &MockImportApi{ctrl: ctrl}
+	mock.recorder = &MockImportApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportApi) EXPECT() *MockImportApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockImportApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockImportApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockImportApi)(nil).Configure))
+}
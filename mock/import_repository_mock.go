@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/repository (interfaces: ImportRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// MockImportRepository is a mock of ImportRepository interface.
+type MockImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepositoryMockRecorder
+}
+
+// MockImportRepositoryMockRecorder is the mock recorder for MockImportRepository.
+type MockImportRepositoryMockRecorder struct {
+	mock *MockImportRepository
+}
+
+// NewMockImportRepository creates a new mock instance.
+func NewMockImportRepository(ctrl *gomock.Controller) *MockImportRepository {
+	mock := &MockImportRepository{ctrl: ctrl}
+	mock.recorder = &MockImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepository) EXPECT() *MockImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImportRepository) Create(arg0 context.Context, arg1 model.Import, arg2 []model.Family) (*model.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImportRepositoryMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImportRepository)(nil).Create), arg0, arg1, arg2)
+}
+
+// FindOneById mocks base method.
+func (m *MockImportRepository) FindOneById(arg0 context.Context, arg1 int) (*model.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneById", arg0, arg1)
+	ret0, _ := ret[0].(*model.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneById indicates an expected call of FindOneById.
+func (mr *MockImportRepositoryMockRecorder) FindOneById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneById", reflect.TypeOf((*MockImportRepository)(nil).FindOneById), arg0, arg1)
+}
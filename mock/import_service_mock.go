@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: ImportService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockImportService is a mock of ImportService interface.
+type MockImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportServiceMockRecorder
+}
+
+// MockImportServiceMockRecorder is the mock recorder for MockImportService.
+type MockImportServiceMockRecorder struct {
+	mock *MockImportService
+}
+
+// NewMockImportService creates a new mock instance.
+func NewMockImportService(ctrl *gomock.Controller) *MockImportService {
+	mock := &MockImportService{ctrl: ctrl}
+	mock.recorder = &MockImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportService) EXPECT() *MockImportServiceMockRecorder {
+	return m.recorder
+}
+
+// FindOneById mocks base method.
+func (m *MockImportService) FindOneById(arg0 context.Context, arg1 int) (service.ImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneById", arg0, arg1)
+	ret0, _ := ret[0].(service.ImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneById indicates an expected call of FindOneById.
+func (mr *MockImportServiceMockRecorder) FindOneById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneById", reflect.TypeOf((*MockImportService)(nil).FindOneById), arg0, arg1)
+}
+
+// Import mocks base method.
+func (m *MockImportService) Import(arg0 context.Context, arg1 service.ImportCreateDto) (service.ImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1)
+	ret0, _ := ret[0].(service.ImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockImportServiceMockRecorder) Import(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockImportService)(nil).Import), arg0, arg1)
+}
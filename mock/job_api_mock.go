@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: JobApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockJobApi is a mock of JobApi interface.
+type MockJobApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobApiMockRecorder
+}
+
+// MockJobApiMockRecorder is the mock recorder for MockJobApi.
+type MockJobApiMockRecorder struct {
+	mock *MockJobApi
+}
+
+// NewMockJobApi creates a new mock instance.
+func NewMockJobApi(ctrl *gomock.Controller) *MockJobApi {
+	mock := &MockJobApi{ctrl: ctrl}
+	mock.recorder = &MockJobApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobApi) EXPECT() *MockJobApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockJobApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockJobApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockJobApi)(nil).Configure))
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/repository (interfaces: JobRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockJobRepository) Acquire(arg0 context.Context, arg1, arg2 string, arg3 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockJobRepositoryMockRecorder) Acquire(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockJobRepository)(nil).Acquire), arg0, arg1, arg2, arg3)
+}
+
+// CreateRun mocks base method.
+func (m *MockJobRepository) CreateRun(arg0 context.Context, arg1 model.JobRun) (*model.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", arg0, arg1)
+	ret0, _ := ret[0].(*model.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockJobRepositoryMockRecorder) CreateRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockJobRepository)(nil).CreateRun), arg0, arg1)
+}
+
+// DeleteRuns mocks base method.
+func (m *MockJobRepository) DeleteRuns(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRuns", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRuns indicates an expected call of DeleteRuns.
+func (mr *MockJobRepositoryMockRecorder) DeleteRuns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuns", reflect.TypeOf((*MockJobRepository)(nil).DeleteRuns), arg0, arg1)
+}
+
+// FindRuns mocks base method.
+func (m *MockJobRepository) FindRuns(arg0 context.Context, arg1 string, arg2 int) ([]model.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRuns", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRuns indicates an expected call of FindRuns.
+func (mr *MockJobRepositoryMockRecorder) FindRuns(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRuns", reflect.TypeOf((*MockJobRepository)(nil).FindRuns), arg0, arg1, arg2)
+}
+
+// FinishRun mocks base method.
+func (m *MockJobRepository) FinishRun(arg0 context.Context, arg1 model.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishRun indicates an expected call of FinishRun.
+func (mr *MockJobRepositoryMockRecorder) FinishRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRun", reflect.TypeOf((*MockJobRepository)(nil).FinishRun), arg0, arg1)
+}
+
+// Release mocks base method.
+func (m *MockJobRepository) Release(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockJobRepositoryMockRecorder) Release(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockJobRepository)(nil).Release), arg0, arg1, arg2)
+}
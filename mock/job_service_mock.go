@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: JobService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockJobService is a mock of JobService interface.
+type MockJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobServiceMockRecorder
+}
+
+// MockJobServiceMockRecorder is the mock recorder for MockJobService.
+type MockJobServiceMockRecorder struct {
+	mock *MockJobService
+}
+
+// NewMockJobService creates a new mock instance.
+func NewMockJobService(ctrl *gomock.Controller) *MockJobService {
+	mock := &MockJobService{ctrl: ctrl}
+	mock.recorder = &MockJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobService) EXPECT() *MockJobServiceMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockJobService) FindAll(arg0 context.Context) (service.JobsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].(service.JobsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockJobServiceMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockJobService)(nil).FindAll), arg0)
+}
+
+// FindRuns mocks base method.
+func (m *MockJobService) FindRuns(arg0 context.Context, arg1 string, arg2 int) (service.JobRunsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRuns", arg0, arg1, arg2)
+	ret0, _ := ret[0].(service.JobRunsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRuns indicates an expected call of FindRuns.
+func (mr *MockJobServiceMockRecorder) FindRuns(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRuns", reflect.TypeOf((*MockJobService)(nil).FindRuns), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *MockJobService) Purge(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockJobServiceMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockJobService)(nil).Purge), arg0)
+}
+
+// Start mocks base method.
+func (m *MockJobService) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockJobServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockJobService)(nil).Start), arg0)
+}
+
+// Started mocks base method.
+func (m *MockJobService) Started() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Started")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Started indicates an expected call of Started.
+func (mr *MockJobServiceMockRecorder) Started() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Started", reflect.TypeOf((*MockJobService)(nil).Started))
+}
+
+// Trigger mocks base method.
+func (m *MockJobService) Trigger(arg0 context.Context, arg1 string) (service.JobRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trigger", arg0, arg1)
+	ret0, _ := ret[0].(service.JobRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trigger indicates an expected call of Trigger.
+func (mr *MockJobServiceMockRecorder) Trigger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockJobService)(nil).Trigger), arg0, arg1)
+}
+
+// Wait mocks base method.
+func (m *MockJobService) Wait() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Wait")
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockJobServiceMockRecorder) Wait() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockJobService)(nil).Wait))
+}
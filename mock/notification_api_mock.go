@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: NotificationApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationApi is a mock of NotificationApi interface.
+type MockNotificationApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationApiMockRecorder
+}
+
+// MockNotificationApiMockRecorder is the mock recorder for MockNotificationApi.
+type MockNotificationApiMockRecorder struct {
+	mock *MockNotificationApi
+}
+
+// NewMockNotificationApi creates a new mock instance.
+func NewMockNotificationApi(ctrl *gomock.Controller) *MockNotificationApi {
+	mock := &MockNotificationApi{ctrl: ctrl}
+	mock.recorder = &MockNotificationApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationApi) EXPECT() *MockNotificationApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockNotificationApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockNotificationApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockNotificationApi)(nil).Configure))
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/repository (interfaces: NotificationRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockNotificationRepository) Claim(arg0 context.Context, arg1 int, arg2 time.Duration) ([]model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockNotificationRepositoryMockRecorder) Claim(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockNotificationRepository)(nil).Claim), arg0, arg1, arg2)
+}
+
+// Count mocks base method.
+func (m *MockNotificationRepository) Count(arg0 context.Context, arg1 model.Query) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockNotificationRepositoryMockRecorder) Count(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockNotificationRepository)(nil).Count), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockNotificationRepository) Create(arg0 context.Context, arg1 []model.Notification) ([]model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].([]model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotificationRepository)(nil).Create), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockNotificationRepository) FindAll(arg0 context.Context, arg1 model.Query) ([]model.Notification, model.Pagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].([]model.Notification)
+	ret1, _ := ret[1].(model.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockNotificationRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockNotificationRepository)(nil).FindAll), arg0, arg1)
+}
+
+// FindContacts mocks base method.
+func (m *MockNotificationRepository) FindContacts(arg0 context.Context, arg1 []int) ([]model.PersonContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindContacts", arg0, arg1)
+	ret0, _ := ret[0].([]model.PersonContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindContacts indicates an expected call of FindContacts.
+func (mr *MockNotificationRepositoryMockRecorder) FindContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindContacts", reflect.TypeOf((*MockNotificationRepository)(nil).FindContacts), arg0, arg1)
+}
+
+// FindPersonsToRemind mocks base method.
+func (m *MockNotificationRepository) FindPersonsToRemind(arg0 context.Context, arg1, arg2 string, arg3 time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPersonsToRemind", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPersonsToRemind indicates an expected call of FindPersonsToRemind.
+func (mr *MockNotificationRepositoryMockRecorder) FindPersonsToRemind(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPersonsToRemind", reflect.TypeOf((*MockNotificationRepository)(nil).FindPersonsToRemind), arg0, arg1, arg2, arg3)
+}
+
+// Save mocks base method.
+func (m *MockNotificationRepository) Save(arg0 context.Context, arg1 model.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockNotificationRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockNotificationRepository)(nil).Save), arg0, arg1)
+}
+
+// SaveContact mocks base method.
+func (m *MockNotificationRepository) SaveContact(arg0 context.Context, arg1 model.PersonContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveContact", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveContact indicates an expected call of SaveContact.
+func (mr *MockNotificationRepositoryMockRecorder) SaveContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveContact", reflect.TypeOf((*MockNotificationRepository)(nil).SaveContact), arg0, arg1)
+}
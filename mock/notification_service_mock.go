@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: NotificationService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockNotificationService is a mock of NotificationService interface.
+type MockNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceMockRecorder
+}
+
+// MockNotificationServiceMockRecorder is the mock recorder for MockNotificationService.
+type MockNotificationServiceMockRecorder struct {
+	mock *MockNotificationService
+}
+
+// NewMockNotificationService creates a new mock instance.
+func NewMockNotificationService(ctrl *gomock.Controller) *MockNotificationService {
+	mock := &MockNotificationService{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// Deliver mocks base method.
+func (m *MockNotificationService) Deliver(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockNotificationServiceMockRecorder) Deliver(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockNotificationService)(nil).Deliver), arg0)
+}
+
+// FindAll mocks base method.
+func (m *MockNotificationService) FindAll(arg0 context.Context, arg1 model.Query) (service.NotificationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].(service.NotificationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockNotificationServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockNotificationService)(nil).FindAll), arg0, arg1)
+}
+
+// FindContact mocks base method.
+func (m *MockNotificationService) FindContact(arg0 context.Context, arg1 int) (service.PersonContactResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindContact", arg0, arg1)
+	ret0, _ := ret[0].(service.PersonContactResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindContact indicates an expected call of FindContact.
+func (mr *MockNotificationServiceMockRecorder) FindContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindContact", reflect.TypeOf((*MockNotificationService)(nil).FindContact), arg0, arg1)
+}
+
+// Notify mocks base method.
+func (m *MockNotificationService) Notify(arg0 context.Context, arg1 service.NotifyDto) (service.NotifyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", arg0, arg1)
+	ret0, _ := ret[0].(service.NotifyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationServiceMockRecorder) Notify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotificationService)(nil).Notify), arg0, arg1)
+}
+
+// RemindPickups mocks base method.
+func (m *MockNotificationService) RemindPickups(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemindPickups", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemindPickups indicates an expected call of RemindPickups.
+func (mr *MockNotificationServiceMockRecorder) RemindPickups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemindPickups", reflect.TypeOf((*MockNotificationService)(nil).RemindPickups), arg0)
+}
+
+// SaveContact mocks base method.
+func (m *MockNotificationService) SaveContact(arg0 context.Context, arg1 service.SavePersonContactDto) (service.PersonContactResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveContact", arg0, arg1)
+	ret0, _ := ret[0].(service.PersonContactResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveContact indicates an expected call of SaveContact.
+func (mr *MockNotificationServiceMockRecorder) SaveContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveContact", reflect.TypeOf((*MockNotificationService)(nil).SaveContact), arg0, arg1)
+}
@@ -35,6 +35,21 @@ func (m *MockPersonRepository) EXPECT() *MockPersonRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockPersonRepository) Count(arg0 context.Context, arg1 model.Query) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockPersonRepositoryMockRecorder) Count(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockPersonRepository)(nil).Count), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockPersonRepository) Create(arg0 context.Context, arg1 model.Person) (*model.Person, error) {
 	m.ctrl.T.Helper()
@@ -65,18 +80,48 @@ func (mr *MockPersonRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomo
 }
 
 // FindAll mocks base method.
-func (m *MockPersonRepository) FindAll(arg0 context.Context) ([]model.Person, error) {
+func (m *MockPersonRepository) FindAll(arg0 context.Context, arg1 model.Query) ([]model.Person, model.Pagination, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].([]model.Person)
+	ret1, _ := ret[1].(model.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPersonRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPersonRepository)(nil).FindAll), arg0, arg1)
+}
+
+// FindAllByFamilyIDs mocks base method.
+func (m *MockPersonRepository) FindAllByFamilyIDs(arg0 context.Context, arg1 []int) ([]model.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByFamilyIDs", arg0, arg1)
 	ret0, _ := ret[0].([]model.Person)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAll indicates an expected call of FindAll.
-func (mr *MockPersonRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+// FindAllByFamilyIDs indicates an expected call of FindAllByFamilyIDs.
+func (mr *MockPersonRepositoryMockRecorder) FindAllByFamilyIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByFamilyIDs", reflect.TypeOf((*MockPersonRepository)(nil).FindAllByFamilyIDs), arg0, arg1)
+}
+
+// FindEach mocks base method.
+func (m *MockPersonRepository) FindEach(arg0 context.Context, arg1 model.Query, arg2 func(model.Person) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEach", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindEach indicates an expected call of FindEach.
+func (mr *MockPersonRepositoryMockRecorder) FindEach(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPersonRepository)(nil).FindAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEach", reflect.TypeOf((*MockPersonRepository)(nil).FindEach), arg0, arg1, arg2)
 }
 
 // FindOneById mocks base method.
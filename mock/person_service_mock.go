@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
 	service "github.com/viniosilva/socialassistanceapi/internal/service"
 )
 
@@ -65,18 +66,18 @@ func (mr *MockPersonServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.
 }
 
 // FindAll mocks base method.
-func (m *MockPersonService) FindAll(arg0 context.Context) (service.PersonsResponse, error) {
+func (m *MockPersonService) FindAll(arg0 context.Context, arg1 model.Query) (service.PersonsResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
 	ret0, _ := ret[0].(service.PersonsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockPersonServiceMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+func (mr *MockPersonServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPersonService)(nil).FindAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPersonService)(nil).FindAll), arg0, arg1)
 }
 
 // FindOneById mocks base method.
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: ReceiptApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReceiptApi is a mock of ReceiptApi interface.
+type MockReceiptApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptApiMockRecorder
+}
+
+// MockReceiptApiMockRecorder is the mock recorder for MockReceiptApi.
+type MockReceiptApiMockRecorder struct {
+	mock *MockReceiptApi
+}
+
+// NewMockReceiptApi creates a new mock instance.
+func NewMockReceiptApi(ctrl *gomock.Controller) *MockReceiptApi {
+	mock := &MockReceiptApi{ctrl: ctrl}
+	mock.recorder = &MockReceiptApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptApi) EXPECT() *MockReceiptApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockReceiptApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockReceiptApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockReceiptApi)(nil).Configure))
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/repository (interfaces: ReceiptRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// MockReceiptRepository is a mock of ReceiptRepository interface.
+type MockReceiptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptRepositoryMockRecorder
+}
+
+// MockReceiptRepositoryMockRecorder is the mock recorder for MockReceiptRepository.
+type MockReceiptRepositoryMockRecorder struct {
+	mock *MockReceiptRepository
+}
+
+// NewMockReceiptRepository creates a new mock instance.
+func NewMockReceiptRepository(ctrl *gomock.Controller) *MockReceiptRepository {
+	mock := &MockReceiptRepository{ctrl: ctrl}
+	mock.recorder = &MockReceiptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptRepository) EXPECT() *MockReceiptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindOrCreate mocks base method.
+func (m *MockReceiptRepository) FindOrCreate(arg0 context.Context, arg1 string, arg2 int) (*model.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreate indicates an expected call of FindOrCreate.
+func (mr *MockReceiptRepositoryMockRecorder) FindOrCreate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreate", reflect.TypeOf((*MockReceiptRepository)(nil).FindOrCreate), arg0, arg1, arg2)
+}
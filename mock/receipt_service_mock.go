@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: ReceiptService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockReceiptService is a mock of ReceiptService interface.
+type MockReceiptService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptServiceMockRecorder
+}
+
+// MockReceiptServiceMockRecorder is the mock recorder for MockReceiptService.
+type MockReceiptServiceMockRecorder struct {
+	mock *MockReceiptService
+}
+
+// NewMockReceiptService creates a new mock instance.
+func NewMockReceiptService(ctrl *gomock.Controller) *MockReceiptService {
+	mock := &MockReceiptService{ctrl: ctrl}
+	mock.recorder = &MockReceiptServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptService) EXPECT() *MockReceiptServiceMockRecorder {
+	return m.recorder
+}
+
+// Donation mocks base method.
+func (m *MockReceiptService) Donation(arg0 context.Context, arg1 int) (service.ReceiptPdf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Donation", arg0, arg1)
+	ret0, _ := ret[0].(service.ReceiptPdf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Donation indicates an expected call of Donation.
+func (mr *MockReceiptServiceMockRecorder) Donation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Donation", reflect.TypeOf((*MockReceiptService)(nil).Donation), arg0, arg1)
+}
@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: ReportApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportApi is a mock of ReportApi interface.
+type MockReportApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportApiMockRecorder
+}
+
+// MockReportApiMockRecorder is the mock recorder for MockReportApi.
+type MockReportApiMockRecorder struct {
+	mock *MockReportApi
+}
+
+// NewMockReportApi creates a new mock instance.
+func NewMockReportApi(ctrl *gomock.Controller) *MockReportApi {
+	mock := &MockReportApi{ctrl: ctrl}
+	mock.recorder = &MockReportApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportApi) EXPECT() *MockReportApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockReportApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockReportApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockReportApi)(nil).Configure))
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/repository (interfaces: ReportRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Distributions mocks base method.
+func (m *MockReportRepository) Distributions(arg0 context.Context, arg1 model.DistributionPeriod, arg2 model.DistributionGroup) ([]model.DistributionReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distributions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.DistributionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Distributions indicates an expected call of Distributions.
+func (mr *MockReportRepositoryMockRecorder) Distributions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distributions", reflect.TypeOf((*MockReportRepository)(nil).Distributions), arg0, arg1, arg2)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: ReportService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockReportService is a mock of ReportService interface.
+type MockReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServiceMockRecorder
+}
+
+// MockReportServiceMockRecorder is the mock recorder for MockReportService.
+type MockReportServiceMockRecorder struct {
+	mock *MockReportService
+}
+
+// NewMockReportService creates a new mock instance.
+func NewMockReportService(ctrl *gomock.Controller) *MockReportService {
+	mock := &MockReportService{ctrl: ctrl}
+	mock.recorder = &MockReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportService) EXPECT() *MockReportServiceMockRecorder {
+	return m.recorder
+}
+
+// Distributions mocks base method.
+func (m *MockReportService) Distributions(arg0 context.Context, arg1 service.DistributionReportDto) (service.DistributionReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distributions", arg0, arg1)
+	ret0, _ := ret[0].(service.DistributionReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Distributions indicates an expected call of Distributions.
+func (mr *MockReportServiceMockRecorder) Distributions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distributions", reflect.TypeOf((*MockReportService)(nil).Distributions), arg0, arg1)
+}
+
+// PublishLastMonth mocks base method.
+func (m *MockReportService) PublishLastMonth(arg0 context.Context) (service.DistributionReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishLastMonth", arg0)
+	ret0, _ := ret[0].(service.DistributionReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishLastMonth indicates an expected call of PublishLastMonth.
+func (mr *MockReportServiceMockRecorder) PublishLastMonth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishLastMonth", reflect.TypeOf((*MockReportService)(nil).PublishLastMonth), arg0)
+}
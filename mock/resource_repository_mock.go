@@ -35,6 +35,21 @@ func (m *MockResourceRepository) EXPECT() *MockResourceRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockResourceRepository) Count(arg0 context.Context, arg1 model.Query) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockResourceRepositoryMockRecorder) Count(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockResourceRepository)(nil).Count), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockResourceRepository) Create(arg0 context.Context, arg1 model.Resource) (*model.Resource, error) {
 	m.ctrl.T.Helper()
@@ -51,18 +66,33 @@ func (mr *MockResourceRepositoryMockRecorder) Create(arg0, arg1 interface{}) *go
 }
 
 // FindAll mocks base method.
-func (m *MockResourceRepository) FindAll(arg0 context.Context) ([]model.Resource, error) {
+func (m *MockResourceRepository) FindAll(arg0 context.Context, arg1 model.Query) ([]model.Resource, model.Pagination, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
 	ret0, _ := ret[0].([]model.Resource)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(model.Pagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockResourceRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+func (mr *MockResourceRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockResourceRepository)(nil).FindAll), arg0, arg1)
+}
+
+// FindEach mocks base method.
+func (m *MockResourceRepository) FindEach(arg0 context.Context, arg1 model.Query, arg2 func(model.Resource) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEach", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindEach indicates an expected call of FindEach.
+func (mr *MockResourceRepositoryMockRecorder) FindEach(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockResourceRepository)(nil).FindAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEach", reflect.TypeOf((*MockResourceRepository)(nil).FindEach), arg0, arg1, arg2)
 }
 
 // FindOneById mocks base method.
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: ResourceService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockResourceService is a mock of ResourceService interface.
+type MockResourceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceServiceMockRecorder
+}
+
+// MockResourceServiceMockRecorder is the mock recorder for MockResourceService.
+type MockResourceServiceMockRecorder struct {
+	mock *MockResourceService
+}
+
+// NewMockResourceService creates a new mock instance.
+func NewMockResourceService(ctrl *gomock.Controller) *MockResourceService {
+	mock := &MockResourceService{ctrl: ctrl}
+	mock.recorder = &MockResourceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceService) EXPECT() *MockResourceServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockResourceService) Create(arg0 context.Context, arg1 service.CreateResourceDto) (service.ResourceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(service.ResourceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockResourceServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockResourceService)(nil).Create), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockResourceService) FindAll(arg0 context.Context, arg1 model.Query) (service.ResourcesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].(service.ResourcesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockResourceServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockResourceService)(nil).FindAll), arg0, arg1)
+}
+
+// FindOneById mocks base method.
+func (m *MockResourceService) FindOneById(arg0 context.Context, arg1 int) (service.ResourceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneById", arg0, arg1)
+	ret0, _ := ret[0].(service.ResourceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneById indicates an expected call of FindOneById.
+func (mr *MockResourceServiceMockRecorder) FindOneById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneById", reflect.TypeOf((*MockResourceService)(nil).FindOneById), arg0, arg1)
+}
+
+// Reconcile mocks base method.
+func (m *MockResourceService) Reconcile(arg0 context.Context, arg1 bool) (service.ResourceReconcileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1)
+	ret0, _ := ret[0].(service.ResourceReconcileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockResourceServiceMockRecorder) Reconcile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockResourceService)(nil).Reconcile), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockResourceService) Update(arg0 context.Context, arg1 service.UpdateResourceDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockResourceServiceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockResourceService)(nil).Update), arg0, arg1)
+}
+
+// UpdateQuantity mocks base method.
+func (m *MockResourceService) UpdateQuantity(arg0 context.Context, arg1 int, arg2 service.UpdateResourceQuantityDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuantity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuantity indicates an expected call of UpdateQuantity.
+func (mr *MockResourceServiceMockRecorder) UpdateQuantity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuantity", reflect.TypeOf((*MockResourceService)(nil).UpdateQuantity), arg0, arg1, arg2)
+}
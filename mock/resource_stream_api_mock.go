@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: ResourceStreamApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockResourceStreamApi is a mock of ResourceStreamApi interface.
+type MockResourceStreamApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceStreamApiMockRecorder
+}
+
+// MockResourceStreamApiMockRecorder is the mock recorder for MockResourceStreamApi.
+type MockResourceStreamApiMockRecorder struct {
+	mock *MockResourceStreamApi
+}
+
+// NewMockResourceStreamApi creates a new mock instance.
+func NewMockResourceStreamApi(ctrl *gomock.Controller) *MockResourceStreamApi {
+	mock := &MockResourceStreamApi{ctrl: ctrl}
+	mock.recorder = &MockResourceStreamApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceStreamApi) EXPECT() *MockResourceStreamApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockResourceStreamApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockResourceStreamApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockResourceStreamApi)(nil).Configure))
+}
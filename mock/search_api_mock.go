@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: SearchApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchApi is a mock of SearchApi interface.
+type MockSearchApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchApiMockRecorder
+}
+
+// MockSearchApiMockRecorder is the mock recorder for MockSearchApi.
+type MockSearchApiMockRecorder struct {
+	mock *MockSearchApi
+}
+
+// NewMockSearchApi creates a new mock instance.
+func NewMockSearchApi(ctrl *gomock.Controller) *MockSearchApi {
+	mock := &MockSearchApi{ctrl: ctrl}
+	mock.recorder = &MockSearchApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchApi) EXPECT() *MockSearchApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockSearchApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockSearchApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockSearchApi)(nil).Configure))
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/repository (interfaces: SearchRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// MockSearchRepository is a mock of SearchRepository interface.
+type MockSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchRepositoryMockRecorder
+}
+
+// MockSearchRepositoryMockRecorder is the mock recorder for MockSearchRepository.
+type MockSearchRepositoryMockRecorder struct {
+	mock *MockSearchRepository
+}
+
+// NewMockSearchRepository creates a new mock instance.
+func NewMockSearchRepository(ctrl *gomock.Controller) *MockSearchRepository {
+	mock := &MockSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchRepository) EXPECT() *MockSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchRepository) Search(arg0 context.Context, arg1 string, arg2 int) ([]model.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchRepositoryMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchRepository)(nil).Search), arg0, arg1, arg2)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: SearchService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockSearchService is a mock of SearchService interface.
+type MockSearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchServiceMockRecorder
+}
+
+// MockSearchServiceMockRecorder is the mock recorder for MockSearchService.
+type MockSearchServiceMockRecorder struct {
+	mock *MockSearchService
+}
+
+// NewMockSearchService creates a new mock instance.
+func NewMockSearchService(ctrl *gomock.Controller) *MockSearchService {
+	mock := &MockSearchService{ctrl: ctrl}
+	mock.recorder = &MockSearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchService) EXPECT() *MockSearchServiceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchService) Search(arg0 context.Context, arg1 string, arg2 int) (service.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].(service.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchServiceMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchService)(nil).Search), arg0, arg1, arg2)
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: StockPublisher)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+)
+
+// MockStockPublisher is a mock of StockPublisher interface.
+type MockStockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockPublisherMockRecorder
+}
+
+// MockStockPublisherMockRecorder is the mock recorder for MockStockPublisher.
+type MockStockPublisherMockRecorder struct {
+	mock *MockStockPublisher
+}
+
+// NewMockStockPublisher creates a new mock instance.
+func NewMockStockPublisher(ctrl *gomock.Controller) *MockStockPublisher {
+	mock := &MockStockPublisher{ctrl: ctrl}
+	mock.recorder = &MockStockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockPublisher) EXPECT() *MockStockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockStockPublisher) Publish(arg0 context.Context, arg1 model.Resource, arg2 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", arg0, arg1, arg2)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockStockPublisherMockRecorder) Publish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockStockPublisher)(nil).Publish), arg0, arg1, arg2)
+}
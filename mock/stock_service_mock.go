@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: StockService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/viniosilva/socialassistanceapi/internal/model"
+	service "github.com/viniosilva/socialassistanceapi/internal/service"
+)
+
+// MockStockService is a mock of StockService interface.
+type MockStockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockServiceMockRecorder
+}
+
+// MockStockServiceMockRecorder is the mock recorder for MockStockService.
+type MockStockServiceMockRecorder struct {
+	mock *MockStockService
+}
+
+// NewMockStockService creates a new mock instance.
+func NewMockStockService(ctrl *gomock.Controller) *MockStockService {
+	mock := &MockStockService{ctrl: ctrl}
+	mock.recorder = &MockStockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockService) EXPECT() *MockStockServiceMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockStockService) Publish(arg0 context.Context, arg1 model.Resource, arg2 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", arg0, arg1, arg2)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockStockServiceMockRecorder) Publish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockStockService)(nil).Publish), arg0, arg1, arg2)
+}
+
+// Subscribe mocks base method.
+func (m *MockStockService) Subscribe(arg0 context.Context, arg1 int64) *service.StockSubscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1)
+	ret0, _ := ret[0].(*service.StockSubscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockStockServiceMockRecorder) Subscribe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockStockService)(nil).Subscribe), arg0, arg1)
+}
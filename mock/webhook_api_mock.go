@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/api (interfaces: WebhookApi)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhookApi is a mock of WebhookApi interface.
+type MockWebhookApi struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookApiMockRecorder
+}
+
+// MockWebhookApiMockRecorder is the mock recorder for MockWebhookApi.
+type MockWebhookApiMockRecorder struct {
+	mock *MockWebhookApi
+}
+
+// NewMockWebhookApi creates a new mock instance.
+func NewMockWebhookApi(ctrl *gomock.Controller) *MockWebhookApi {
+	mock := &MockWebhookApi{ctrl: ctrl}
+	mock.recorder = &MockWebhookApiMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookApi) EXPECT() *MockWebhookApiMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockWebhookApi) Configure() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Configure")
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockWebhookApiMockRecorder) Configure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockWebhookApi)(nil).Configure))
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/viniosilva/socialassistanceapi/internal/service (interfaces: WebhookPublisher)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhookPublisher is a mock of WebhookPublisher interface.
+type MockWebhookPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookPublisherMockRecorder
+}
+
+// MockWebhookPublisherMockRecorder is the mock recorder for MockWebhookPublisher.
+type MockWebhookPublisherMockRecorder struct {
+	mock *MockWebhookPublisher
+}
+
+// NewMockWebhookPublisher creates a new mock instance.
+func NewMockWebhookPublisher(ctrl *gomock.Controller) *MockWebhookPublisher {
+	mock := &MockWebhookPublisher{ctrl: ctrl}
+	mock.recorder = &MockWebhookPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookPublisher) EXPECT() *MockWebhookPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockWebhookPublisher) Publish(arg0 context.Context, arg1 string, arg2 interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", arg0, arg1, arg2)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockWebhookPublisherMockRecorder) Publish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockWebhookPublisher)(nil).Publish), arg0, arg1, arg2)
+}
@@ -27,7 +27,7 @@ func Test_DonateResourceApi_Donate(t *testing.T) {
 		inputResourceID string
 		inputDto        service.DonateResourceDonateDto
 		expectedCode    int
-		expectedErr     *api.HttpError
+		expectedErr     *api.Problem
 	}{
 		"should donate resource": {
 			before: func(db *sql.DB) {
@@ -51,7 +51,14 @@ func Test_DonateResourceApi_Donate(t *testing.T) {
 			inputResourceID: "1",
 			inputDto:        service.DonateResourceDonateDto{FamilyID: 1, Quantity: 1},
 			expectedCode:    http.StatusNotFound,
-			expectedErr:     &api.HttpError{Code: http.StatusNotFound, Message: "resource 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "resource 1 not found",
+				Instance: "/api/v1/resources/1/donate",
+				Code:     "resource.not_found",
+			},
 		},
 		"should throw not found error when family is not found": {
 			before: func(db *sql.DB) {
@@ -64,7 +71,14 @@ func Test_DonateResourceApi_Donate(t *testing.T) {
 			inputResourceID: "1",
 			inputDto:        service.DonateResourceDonateDto{FamilyID: 1, Quantity: 1},
 			expectedCode:    http.StatusNotFound,
-			expectedErr:     &api.HttpError{Code: http.StatusNotFound, Message: "family 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "family 1 not found",
+				Instance: "/api/v1/resources/1/donate",
+				Code:     "family.not_found",
+			},
 		},
 		"should throw bad request error when quantity is negative": {
 			before: func(db *sql.DB) {
@@ -77,24 +91,44 @@ func Test_DonateResourceApi_Donate(t *testing.T) {
 			inputResourceID: "1",
 			inputDto:        service.DonateResourceDonateDto{FamilyID: 1, Quantity: 1.5},
 			expectedCode:    http.StatusBadRequest,
-			expectedErr:     &api.HttpError{Code: http.StatusBadRequest, Message: "resource 1 quantity is 1.0"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "resource 1 quantity is 1.0",
+				Instance: "/api/v1/resources/1/donate",
+				Code:     "resource.insufficient_stock",
+			},
 		},
 		"should throw bad request error when resourceID is not a number": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "a",
 			expectedCode:    http.StatusBadRequest,
-			expectedErr:     &api.HttpError{Code: http.StatusBadRequest, Message: "invalid resourceID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid resourceID",
+				Instance: "/api/v1/resources/a/donate",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "resourceID", Code: "invalid", Message: "invalid resourceID"}},
+			},
 		},
 		"should throw bad request error": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "1",
 			expectedCode:    http.StatusBadRequest,
-			expectedErr: &api.HttpError{
-				Code: http.StatusBadRequest,
-				Message: strings.Join([]string{
-					"Key: 'DonateResourceDonateDto.FamilyID' Error:Field validation for 'FamilyID' failed on the 'required' tag",
-					"Key: 'DonateResourceDonateDto.Quantity' Error:Field validation for 'Quantity' failed on the 'required' tag",
-				}, "\n"),
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "the request has invalid fields",
+				Instance: "/api/v1/resources/1/donate",
+				Code:     "invalid_request",
+				Errors: []api.ProblemField{
+					{Field: "family_id", Code: "required", Message: "family_id is required"},
+					{Field: "quantity", Code: "required", Message: "quantity is required"},
+				},
 			},
 		},
 	}
@@ -107,7 +141,7 @@ func Test_DonateResourceApi_Donate(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			donateResourceRepository := &repository.DonateResourceRepositoryImpl{DB: mysql}
@@ -124,12 +158,12 @@ func Test_DonateResourceApi_Donate(t *testing.T) {
 			req, _ := http.NewRequest("POST", url, strings.NewReader(string(b)))
 			impl.Gin.ServeHTTP(rec, req)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM resources_to_families`)
@@ -149,7 +183,7 @@ func Test_DonateResourceApi_Return(t *testing.T) {
 		before          func(db *sql.DB)
 		inputResourceID string
 		expectedCode    int
-		expectedErr     *api.HttpError
+		expectedErr     *api.Problem
 	}{
 		"should return resource": {
 			before: func(db *sql.DB) {
@@ -175,13 +209,28 @@ func Test_DonateResourceApi_Return(t *testing.T) {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "1",
 			expectedCode:    http.StatusNotFound,
-			expectedErr:     &api.HttpError{Code: http.StatusNotFound, Message: "resource 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "resource 1 not found",
+				Instance: "/api/v1/resources/1/return",
+				Code:     "resource.not_found",
+			},
 		},
 		"should throw bad request error when resourceID is not a number": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "a",
 			expectedCode:    http.StatusBadRequest,
-			expectedErr:     &api.HttpError{Code: http.StatusBadRequest, Message: "invalid resourceID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid resourceID",
+				Instance: "/api/v1/resources/a/return",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "resourceID", Code: "invalid", Message: "invalid resourceID"}},
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -193,7 +242,7 @@ func Test_DonateResourceApi_Return(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			donateResourceRepository := &repository.DonateResourceRepositoryImpl{DB: mysql}
@@ -209,12 +258,12 @@ func Test_DonateResourceApi_Return(t *testing.T) {
 			req, _ := http.NewRequest("DELETE", url, nil)
 			impl.Gin.ServeHTTP(rec, req)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM resources_to_families`)
@@ -65,7 +65,7 @@ func Test_FamilyApi_FindAll(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			familyRepository := &repository.FamilyRepositoryImpl{DB: mysql}
@@ -108,7 +108,7 @@ func Test_FamilyApi_FindOneByID(t *testing.T) {
 		inputFamilyID string
 		expectedCode  int
 		expectedBody  *service.FamilyResponse
-		expectedErr   *api.HttpError
+		expectedErr   *api.Problem
 	}{
 		"should return family when families exists": {
 			before: func(db *sql.DB) {
@@ -134,21 +134,36 @@ func Test_FamilyApi_FindOneByID(t *testing.T) {
 				Complement:   "1",
 				Zipcode:      "02180110",
 			}},
-			expectedErr: &api.HttpError{},
+			expectedErr: &api.Problem{},
 		},
 		"should throw bad request error when familyID is not a number": {
 			before:        func(db *sql.DB) {},
 			inputFamilyID: "a",
 			expectedCode:  http.StatusBadRequest,
 			expectedBody:  &service.FamilyResponse{},
-			expectedErr:   &api.HttpError{Code: 400, Message: "invalid familyID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid familyID",
+				Instance: "/api/v1/families/a",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "familyID", Code: "invalid", Message: "invalid familyID"}},
+			},
 		},
 		"should throw not found error when families not exists": {
 			before:        func(db *sql.DB) {},
 			inputFamilyID: "1",
 			expectedCode:  http.StatusNotFound,
 			expectedBody:  &service.FamilyResponse{},
-			expectedErr:   &api.HttpError{Code: http.StatusNotFound, Message: "family 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "family 1 not found",
+				Instance: "/api/v1/families/1",
+				Code:     "family.not_found",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -160,7 +175,7 @@ func Test_FamilyApi_FindOneByID(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			familyRepository := &repository.FamilyRepositoryImpl{DB: mysql}
@@ -179,8 +194,8 @@ func Test_FamilyApi_FindOneByID(t *testing.T) {
 			var body *service.FamilyResponse
 			json.Unmarshal(rec.Body.Bytes(), &body)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// clean
 			if body.Data != nil {
@@ -191,7 +206,7 @@ func Test_FamilyApi_FindOneByID(t *testing.T) {
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
 			assert.Equal(t, cs.expectedBody, body)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM families`)
@@ -205,7 +220,7 @@ func Test_FamilyApi_Create(t *testing.T) {
 		inputDto     service.FamilyCreateDto
 		expectedCode int
 		expectedBody *service.FamilyResponse
-		expectedErr  *api.HttpError
+		expectedErr  *api.Problem
 	}{
 		"should return created family": {
 			inputDto: service.FamilyCreateDto{
@@ -232,23 +247,28 @@ func Test_FamilyApi_Create(t *testing.T) {
 				Complement:   "1",
 				Zipcode:      "02180110",
 			}},
-			expectedErr: &api.HttpError{},
+			expectedErr: &api.Problem{},
 		},
 		"should throw bad request error": {
 			expectedCode: http.StatusBadRequest,
 			expectedBody: &service.FamilyResponse{},
-			expectedErr: &api.HttpError{
-				Code: http.StatusBadRequest,
-				Message: strings.Join([]string{
-					"Key: 'FamilyCreateDto.Name' Error:Field validation for 'Name' failed on the 'required' tag",
-					"Key: 'FamilyCreateDto.Country' Error:Field validation for 'Country' failed on the 'required' tag",
-					"Key: 'FamilyCreateDto.State' Error:Field validation for 'State' failed on the 'required' tag",
-					"Key: 'FamilyCreateDto.City' Error:Field validation for 'City' failed on the 'required' tag",
-					"Key: 'FamilyCreateDto.Neighborhood' Error:Field validation for 'Neighborhood' failed on the 'required' tag",
-					"Key: 'FamilyCreateDto.Street' Error:Field validation for 'Street' failed on the 'required' tag",
-					"Key: 'FamilyCreateDto.Number' Error:Field validation for 'Number' failed on the 'required' tag",
-					"Key: 'FamilyCreateDto.Zipcode' Error:Field validation for 'Zipcode' failed on the 'required' tag",
-				}, "\n"),
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "the request has invalid fields",
+				Instance: "/api/v1/families",
+				Code:     "invalid_request",
+				Errors: []api.ProblemField{
+					{Field: "name", Code: "required", Message: "name is required"},
+					{Field: "country", Code: "required", Message: "country is required"},
+					{Field: "state", Code: "required", Message: "state is required"},
+					{Field: "city", Code: "required", Message: "city is required"},
+					{Field: "neighborhood", Code: "required", Message: "neighborhood is required"},
+					{Field: "street", Code: "required", Message: "street is required"},
+					{Field: "number", Code: "required", Message: "number is required"},
+					{Field: "zipcode", Code: "required", Message: "zipcode is required"},
+				},
 			},
 		},
 	}
@@ -261,7 +281,7 @@ func Test_FamilyApi_Create(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			familyRepository := &repository.FamilyRepositoryImpl{DB: mysql}
@@ -283,13 +303,13 @@ func Test_FamilyApi_Create(t *testing.T) {
 				body.Data.UpdatedAt = ""
 			}
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
 			assert.Equal(t, cs.expectedBody, body)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM families`)
@@ -306,7 +326,7 @@ func Test_FamilyApi_Update(t *testing.T) {
 		inputFamilyID string
 		inputDto      service.FamilyCreateDto
 		expectedCode  int
-		expectedErr   *api.HttpError
+		expectedErr   *api.Problem
 	}{
 		"should update family": {
 			before: func(db *sql.DB) {
@@ -346,13 +366,28 @@ func Test_FamilyApi_Update(t *testing.T) {
 			before:        func(db *sql.DB) {},
 			inputFamilyID: "a",
 			expectedCode:  http.StatusBadRequest,
-			expectedErr:   &api.HttpError{Code: http.StatusBadRequest, Message: "invalid familyID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid familyID",
+				Instance: "/api/v1/families/a",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "familyID", Code: "invalid", Message: "invalid familyID"}},
+			},
 		},
 		"should throw bad request error": {
 			before:        func(db *sql.DB) {},
 			inputFamilyID: "1",
 			expectedCode:  http.StatusBadRequest,
-			expectedErr:   &api.HttpError{Code: http.StatusBadRequest, Message: "empty family model"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "empty family model",
+				Instance: "/api/v1/families/1",
+				Code:     "family.empty_update",
+			},
 		},
 		"should throw not found error when families not exists": {
 			before:        func(db *sql.DB) {},
@@ -369,7 +404,14 @@ func Test_FamilyApi_Update(t *testing.T) {
 				Zipcode:      "02180110",
 			},
 			expectedCode: http.StatusNotFound,
-			expectedErr:  &api.HttpError{Code: http.StatusNotFound, Message: "family 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "family 1 not found",
+				Instance: "/api/v1/families/1",
+				Code:     "family.not_found",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -381,7 +423,7 @@ func Test_FamilyApi_Update(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			familyRepository := &repository.FamilyRepositoryImpl{DB: mysql}
@@ -398,12 +440,12 @@ func Test_FamilyApi_Update(t *testing.T) {
 			req, _ := http.NewRequest("PATCH", url, strings.NewReader(string(b)))
 			impl.Gin.ServeHTTP(rec, req)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM families`)
@@ -420,7 +462,7 @@ func Test_FamilyApi_Delete(t *testing.T) {
 		inputFamilyID string
 		expectedCode  int
 		expectedBody  *service.FamilyResponse
-		expectedErr   *api.HttpError
+		expectedErr   *api.Problem
 	}{
 		"should be successfull": {
 			before: func(db *sql.DB) {
@@ -438,7 +480,15 @@ func Test_FamilyApi_Delete(t *testing.T) {
 			before:        func(db *sql.DB) {},
 			inputFamilyID: "a",
 			expectedCode:  http.StatusBadRequest,
-			expectedErr:   &api.HttpError{Code: http.StatusBadRequest, Message: "invalid familyID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid familyID",
+				Instance: "/api/v1/families/a",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "familyID", Code: "invalid", Message: "invalid familyID"}},
+			},
 		},
 		"should be successfull when families not exists": {
 			before:        func(db *sql.DB) {},
@@ -456,7 +506,7 @@ func Test_FamilyApi_Delete(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			familyRepository := &repository.FamilyRepositoryImpl{DB: mysql}
@@ -472,12 +522,12 @@ func Test_FamilyApi_Delete(t *testing.T) {
 			req, _ := http.NewRequest("DELETE", url, nil)
 			impl.Gin.ServeHTTP(rec, req)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM families`)
@@ -35,7 +35,7 @@ func Test_HealthApi_Ping(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			healthRepository := &repository.HealthRepositoryImpl{DB: mysql}
@@ -42,13 +42,14 @@ func Test_PersonApi_FindAll(t *testing.T) {
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: &service.PersonsResponse{
+				Meta: service.QueryMeta{Filters: []service.FilterMeta{}, Sort: []string{}},
 				Data: []service.Person{{ID: 1, CreatedAt: DATE, UpdatedAt: DATE, FamilyID: 1, Name: "Test"}},
 			},
 		},
 		"should return empty list when persons not exists": {
 			before:       func(db *sql.DB) {},
 			expectedCode: http.StatusOK,
-			expectedBody: &service.PersonsResponse{Data: []service.Person{}},
+			expectedBody: &service.PersonsResponse{Meta: service.QueryMeta{Filters: []service.FilterMeta{}, Sort: []string{}}, Data: []service.Person{}},
 		},
 	}
 	for name, cs := range cases {
@@ -60,7 +61,7 @@ func Test_PersonApi_FindAll(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			personRepository := &repository.PersonRepositoryImpl{DB: mysql}
@@ -99,7 +100,7 @@ func Test_PersonApi_FindOneByID(t *testing.T) {
 		inputPersonID string
 		expectedCode  int
 		expectedBody  *service.PersonResponse
-		expectedErr   *api.HttpError
+		expectedErr   *api.Problem
 	}{
 		"should return person when persons exists": {
 			before: func(db *sql.DB) {
@@ -120,21 +121,36 @@ func Test_PersonApi_FindOneByID(t *testing.T) {
 			expectedBody: &service.PersonResponse{
 				Data: &service.Person{ID: 1, CreatedAt: DATE, UpdatedAt: DATE, FamilyID: 1, Name: "Test"},
 			},
-			expectedErr: &api.HttpError{},
+			expectedErr: &api.Problem{},
 		},
 		"should throw bad request error when personID is not a number": {
 			before:        func(db *sql.DB) {},
 			inputPersonID: "a",
 			expectedCode:  http.StatusBadRequest,
 			expectedBody:  &service.PersonResponse{},
-			expectedErr:   &api.HttpError{Code: http.StatusBadRequest, Message: "invalid personID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid personID",
+				Instance: "/api/v1/persons/a",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "personID", Code: "invalid", Message: "invalid personID"}},
+			},
 		},
 		"should throw not found error when persons not exists": {
 			before:        func(db *sql.DB) {},
 			inputPersonID: "1",
 			expectedCode:  http.StatusNotFound,
 			expectedBody:  &service.PersonResponse{},
-			expectedErr:   &api.HttpError{Code: http.StatusNotFound, Message: "person 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "person 1 not found",
+				Instance: "/api/v1/persons/1",
+				Code:     "person.not_found",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -146,7 +162,7 @@ func Test_PersonApi_FindOneByID(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			personRepository := &repository.PersonRepositoryImpl{DB: mysql}
@@ -165,13 +181,13 @@ func Test_PersonApi_FindOneByID(t *testing.T) {
 			var body *service.PersonResponse
 			json.Unmarshal(rec.Body.Bytes(), &body)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
 			assert.Equal(t, cs.expectedBody, body)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM persons`)
@@ -188,7 +204,7 @@ func Test_PersonApi_Create(t *testing.T) {
 		inputDto     service.PersonCreateDto
 		expectedCode int
 		expectedBody *service.PersonResponse
-		expectedErr  *api.HttpError
+		expectedErr  *api.Problem
 	}{
 		"should return created person": {
 			before: func(db *sql.DB) {
@@ -202,18 +218,23 @@ func Test_PersonApi_Create(t *testing.T) {
 			inputDto:     service.PersonCreateDto{FamilyID: 1, Name: "Test"},
 			expectedCode: http.StatusCreated,
 			expectedBody: &service.PersonResponse{Data: &service.Person{ID: 1, FamilyID: 1, Name: "Test"}},
-			expectedErr:  &api.HttpError{},
+			expectedErr:  &api.Problem{},
 		},
 		"should throw bad request error": {
 			before:       func(db *sql.DB) {},
 			expectedCode: http.StatusBadRequest,
 			expectedBody: &service.PersonResponse{},
-			expectedErr: &api.HttpError{
-				Code: http.StatusBadRequest,
-				Message: strings.Join([]string{
-					"Key: 'PersonCreateDto.FamilyID' Error:Field validation for 'FamilyID' failed on the 'required' tag",
-					"Key: 'PersonCreateDto.Name' Error:Field validation for 'Name' failed on the 'required' tag",
-				}, "\n"),
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "the request has invalid fields",
+				Instance: "/api/v1/persons",
+				Code:     "invalid_request",
+				Errors: []api.ProblemField{
+					{Field: "family_id", Code: "required", Message: "family_id is required"},
+					{Field: "name", Code: "required", Message: "name is required"},
+				},
 			},
 		},
 	}
@@ -226,7 +247,7 @@ func Test_PersonApi_Create(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			personRepository := &repository.PersonRepositoryImpl{DB: mysql}
@@ -250,13 +271,13 @@ func Test_PersonApi_Create(t *testing.T) {
 				body.Data.UpdatedAt = ""
 			}
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
 			assert.Equal(t, cs.expectedBody, body)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM persons`)
@@ -275,7 +296,7 @@ func Test_PersonApi_Update(t *testing.T) {
 		inputPersonID string
 		inputDto      service.PersonCreateDto
 		expectedCode  int
-		expectedErr   *api.HttpError
+		expectedErr   *api.Problem
 	}{
 		"should update person": {
 			before: func(db *sql.DB) {
@@ -299,20 +320,42 @@ func Test_PersonApi_Update(t *testing.T) {
 			before:        func(db *sql.DB) {},
 			inputPersonID: "a",
 			expectedCode:  http.StatusBadRequest,
-			expectedErr:   &api.HttpError{Code: http.StatusBadRequest, Message: "invalid personID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid personID",
+				Instance: "/api/v1/persons/a",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "personID", Code: "invalid", Message: "invalid personID"}},
+			},
 		},
 		"should throw bad request error": {
 			before:        func(db *sql.DB) {},
 			inputPersonID: "1",
 			expectedCode:  http.StatusBadRequest,
-			expectedErr:   &api.HttpError{Code: http.StatusBadRequest, Message: "empty person model"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "empty person model",
+				Instance: "/api/v1/persons/1",
+				Code:     "person.empty_update",
+			},
 		},
 		"should throw not found error when persons not exists": {
 			before:        func(db *sql.DB) {},
 			inputPersonID: "1",
 			inputDto:      service.PersonCreateDto{Name: "Test update"},
 			expectedCode:  http.StatusNotFound,
-			expectedErr:   &api.HttpError{Code: http.StatusNotFound, Message: "person 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "person 1 not found",
+				Instance: "/api/v1/persons/1",
+				Code:     "person.not_found",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -324,7 +367,7 @@ func Test_PersonApi_Update(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			personRepository := &repository.PersonRepositoryImpl{DB: mysql}
@@ -341,12 +384,12 @@ func Test_PersonApi_Update(t *testing.T) {
 			req, _ := http.NewRequest("PATCH", url, strings.NewReader(string(b)))
 			impl.Gin.ServeHTTP(rec, req)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM persons`)
@@ -365,7 +408,7 @@ func Test_PersonApi_Delete(t *testing.T) {
 		inputPersonID string
 		expectedCode  int
 		expectedBody  *service.PersonResponse
-		expectedErr   *api.HttpError
+		expectedErr   *api.Problem
 	}{
 		"should be successfull": {
 			before: func(db *sql.DB) {
@@ -389,7 +432,15 @@ func Test_PersonApi_Delete(t *testing.T) {
 			before:        func(db *sql.DB) {},
 			inputPersonID: "a",
 			expectedCode:  http.StatusBadRequest,
-			expectedErr:   &api.HttpError{Code: 400, Message: "invalid personID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid personID",
+				Instance: "/api/v1/persons/a",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "personID", Code: "invalid", Message: "invalid personID"}},
+			},
 		},
 		"should be successfull when persons not exists": {
 			before:        func(db *sql.DB) {},
@@ -407,7 +458,7 @@ func Test_PersonApi_Delete(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			personRepository := &repository.PersonRepositoryImpl{DB: mysql}
@@ -423,12 +474,12 @@ func Test_PersonApi_Delete(t *testing.T) {
 			req, _ := http.NewRequest("DELETE", url, nil)
 			impl.Gin.ServeHTTP(rec, req)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM persons`)
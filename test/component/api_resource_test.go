@@ -36,7 +36,7 @@ func Test_ResourceApi_FindAll(t *testing.T) {
 				`, date, date)
 			},
 			expectedCode: http.StatusOK,
-			expectedBody: &service.ResourcesResponse{Data: []service.Resource{{
+			expectedBody: &service.ResourcesResponse{Meta: service.QueryMeta{Filters: []service.FilterMeta{}, Sort: []string{}}, Data: []service.Resource{{
 				ID: 1, CreatedAt: DATE, UpdatedAt: DATE,
 				Name:        "Test",
 				Amount:      1,
@@ -47,7 +47,7 @@ func Test_ResourceApi_FindAll(t *testing.T) {
 		"should return empty list when resource not exists": {
 			before:       func(bd *sql.DB) {},
 			expectedCode: http.StatusOK,
-			expectedBody: &service.ResourcesResponse{Data: []service.Resource{}},
+			expectedBody: &service.ResourcesResponse{Meta: service.QueryMeta{Filters: []service.FilterMeta{}, Sort: []string{}}, Data: []service.Resource{}},
 		},
 	}
 	for name, cs := range cases {
@@ -59,7 +59,7 @@ func Test_ResourceApi_FindAll(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			resourceRepository := &repository.ResourceRepositoryImpl{DB: mysql}
@@ -96,7 +96,7 @@ func Test_ResourceApi_FindOneByID(t *testing.T) {
 		inputResourceID string
 		expectedCode    int
 		expectedBody    *service.ResourceResponse
-		expectedErr     *api.HttpError
+		expectedErr     *api.Problem
 	}{
 		"shouldl return resource when resource exists": {
 			before: func(db *sql.DB) {
@@ -115,21 +115,36 @@ func Test_ResourceApi_FindOneByID(t *testing.T) {
 				Measurement: "Kg",
 				Quantity:    1,
 			}},
-			expectedErr: &api.HttpError{},
+			expectedErr: &api.Problem{},
 		},
 		"should throw bad request error when reosurceID is not a number": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "a",
 			expectedCode:    http.StatusBadRequest,
 			expectedBody:    &service.ResourceResponse{},
-			expectedErr:     &api.HttpError{Code: http.StatusBadRequest, Message: "invalid resourceID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid resourceID",
+				Instance: "/api/v1/resources/a",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "resourceID", Code: "invalid", Message: "invalid resourceID"}},
+			},
 		},
 		"should throw not found error when resource not exists": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "1",
 			expectedCode:    http.StatusNotFound,
 			expectedBody:    &service.ResourceResponse{},
-			expectedErr:     &api.HttpError{Code: http.StatusNotFound, Message: "resource 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "resource 1 not found",
+				Instance: "/api/v1/resources/1",
+				Code:     "resource.not_found",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -141,7 +156,7 @@ func Test_ResourceApi_FindOneByID(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			resourceRepository := &repository.ResourceRepositoryImpl{DB: mysql}
@@ -160,13 +175,13 @@ func Test_ResourceApi_FindOneByID(t *testing.T) {
 			var body *service.ResourceResponse
 			json.Unmarshal(rec.Body.Bytes(), &body)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
 			assert.Equal(t, cs.expectedBody, body)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM resources`)
@@ -180,7 +195,7 @@ func Test_ResourceApi_Create(t *testing.T) {
 		inputDto     service.CreateResourceDto
 		expectedCode int
 		expectedBody *service.ResourceResponse
-		expectedErr  *api.HttpError
+		expectedErr  *api.Problem
 	}{
 		"should return create resource": {
 			inputDto:     service.CreateResourceDto{Name: "Test", Amount: 1, Measurement: "Kg", Quantity: 1},
@@ -188,17 +203,25 @@ func Test_ResourceApi_Create(t *testing.T) {
 			expectedBody: &service.ResourceResponse{Data: &service.Resource{
 				ID: 1, Name: "Test", Amount: 1, Measurement: "Kg", Quantity: 1,
 			}},
-			expectedErr: &api.HttpError{},
+			expectedErr: &api.Problem{},
 		},
 		"should throw bad request error": {
 			expectedCode: http.StatusBadRequest,
 			expectedBody: &service.ResourceResponse{},
-			expectedErr: &api.HttpError{Code: http.StatusBadRequest, Message: strings.Join([]string{
-				"Key: 'CreateResourceDto.Name' Error:Field validation for 'Name' failed on the 'required' tag",
-				"Key: 'CreateResourceDto.Amount' Error:Field validation for 'Amount' failed on the 'required' tag",
-				"Key: 'CreateResourceDto.Measurement' Error:Field validation for 'Measurement' failed on the 'required' tag",
-				"Key: 'CreateResourceDto.Quantity' Error:Field validation for 'Quantity' failed on the 'required' tag",
-			}, "\n")},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "the request has invalid fields",
+				Instance: "/api/v1/resources",
+				Code:     "invalid_request",
+				Errors: []api.ProblemField{
+					{Field: "name", Code: "required", Message: "name is required"},
+					{Field: "amount", Code: "required", Message: "amount is required"},
+					{Field: "measurement", Code: "required", Message: "measurement is required"},
+					{Field: "quantity", Code: "required", Message: "quantity is required"},
+				},
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -210,7 +233,7 @@ func Test_ResourceApi_Create(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			resourceRepository := &repository.ResourceRepositoryImpl{DB: mysql}
@@ -232,13 +255,13 @@ func Test_ResourceApi_Create(t *testing.T) {
 				body.Data.UpdatedAt = ""
 			}
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
 			assert.Equal(t, cs.expectedBody, body)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM resources`)
@@ -255,7 +278,7 @@ func Test_ResourceApi_Update(t *testing.T) {
 		inputResourceID string
 		inputDto        service.UpdateResourceDto
 		expectedCode    int
-		expectedErr     *api.HttpError
+		expectedErr     *api.Problem
 	}{
 		"should update resource": {
 			before: func(db *sql.DB) {
@@ -273,20 +296,42 @@ func Test_ResourceApi_Update(t *testing.T) {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "a",
 			expectedCode:    http.StatusBadRequest,
-			expectedErr:     &api.HttpError{Code: http.StatusBadRequest, Message: "invalid resourceID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid resourceID",
+				Instance: "/api/v1/resources/a",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "resourceID", Code: "invalid", Message: "invalid resourceID"}},
+			},
 		},
 		"should throw bad request error": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "1",
 			expectedCode:    http.StatusBadRequest,
-			expectedErr:     &api.HttpError{Code: http.StatusBadRequest, Message: "empty resource model"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "empty resource model",
+				Instance: "/api/v1/resources/1",
+				Code:     "resource.empty_update",
+			},
 		},
 		"should throw not found error when resources not exists": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "1",
 			inputDto:        service.UpdateResourceDto{Name: "Test update"},
 			expectedCode:    http.StatusNotFound,
-			expectedErr:     &api.HttpError{Code: http.StatusNotFound, Message: "resource 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "resource 1 not found",
+				Instance: "/api/v1/resources/1",
+				Code:     "resource.not_found",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -298,7 +343,7 @@ func Test_ResourceApi_Update(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			resourceRepository := &repository.ResourceRepositoryImpl{DB: mysql}
@@ -315,12 +360,12 @@ func Test_ResourceApi_Update(t *testing.T) {
 			req, _ := http.NewRequest("PATCH", url, strings.NewReader(string(b)))
 			impl.Gin.ServeHTTP(rec, req)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM resources`)
@@ -337,7 +382,7 @@ func Test_ResourceApi_UpdateQuantity(t *testing.T) {
 		inputResourceID string
 		inputDto        service.UpdateResourceQuantityDto
 		expectedCode    int
-		expectedErr     *api.HttpError
+		expectedErr     *api.Problem
 	}{
 		"should update resource": {
 			before: func(db *sql.DB) {
@@ -355,24 +400,47 @@ func Test_ResourceApi_UpdateQuantity(t *testing.T) {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "a",
 			expectedCode:    http.StatusBadRequest,
-			expectedErr:     &api.HttpError{Code: http.StatusBadRequest, Message: "invalid resourceID"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "invalid resourceID",
+				Instance: "/api/v1/resources/a/quantity",
+				Code:     "invalid_param",
+				Errors:   []api.ProblemField{{Field: "resourceID", Code: "invalid", Message: "invalid resourceID"}},
+			},
 		},
 		"should throw bad request error when quantity is less than zero": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "1",
 			inputDto:        service.UpdateResourceQuantityDto{Quantity: -1.5},
 			expectedCode:    http.StatusBadRequest,
-			expectedErr: &api.HttpError{
-				Code:    http.StatusBadRequest,
-				Message: "Key: 'UpdateResourceQuantityDto.Quantity' Error:Field validation for 'Quantity' failed on the 'gte' tag"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "the request has invalid fields",
+				Instance: "/api/v1/resources/1/quantity",
+				Code:     "invalid_request",
+				Errors: []api.ProblemField{
+					{Field: "quantity", Code: "gte", Message: "quantity must be at least 0"},
+				},
+			},
 		},
 		"should throw bad request error": {
 			before:          func(db *sql.DB) {},
 			inputResourceID: "1",
 			expectedCode:    http.StatusBadRequest,
-			expectedErr: &api.HttpError{
-				Code:    http.StatusBadRequest,
-				Message: "Key: 'UpdateResourceQuantityDto.Quantity' Error:Field validation for 'Quantity' failed on the 'required' tag",
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "the request has invalid fields",
+				Instance: "/api/v1/resources/1/quantity",
+				Code:     "invalid_request",
+				Errors: []api.ProblemField{
+					{Field: "quantity", Code: "required", Message: "quantity is required"},
+				},
 			},
 		},
 		"should throw not found error when resources not exists": {
@@ -380,7 +448,14 @@ func Test_ResourceApi_UpdateQuantity(t *testing.T) {
 			inputResourceID: "1",
 			inputDto:        service.UpdateResourceQuantityDto{Quantity: 2},
 			expectedCode:    http.StatusNotFound,
-			expectedErr:     &api.HttpError{Code: http.StatusNotFound, Message: "resource 1 not found"},
+			expectedErr: &api.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "resource 1 not found",
+				Instance: "/api/v1/resources/1/quantity",
+				Code:     "resource.not_found",
+			},
 		},
 	}
 	for name, cs := range cases {
@@ -392,7 +467,7 @@ func Test_ResourceApi_UpdateQuantity(t *testing.T) {
 			}
 
 			mysql := infra.MySQLConfigure(cfg.MySQL.Host, cfg.MySQL.Port, cfg.MySQL.Database, cfg.MySQL.Username,
-				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs), cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
+				cfg.MySQL.Password, time.Duration(cfg.MySQL.ConnMaxLifetimeMs)*time.Millisecond, cfg.MySQL.MaxOpenConns, cfg.MySQL.MaxIdleConns)
 			defer mysql.DB.Close()
 
 			resourceRepository := &repository.ResourceRepositoryImpl{DB: mysql}
@@ -409,12 +484,12 @@ func Test_ResourceApi_UpdateQuantity(t *testing.T) {
 			req, _ := http.NewRequest("PATCH", url, strings.NewReader(string(b)))
 			impl.Gin.ServeHTTP(rec, req)
 
-			var httpError *api.HttpError
-			json.Unmarshal(rec.Body.Bytes(), &httpError)
+			var problem *api.Problem
+			json.Unmarshal(rec.Body.Bytes(), &problem)
 
 			// then
 			assert.Equal(t, cs.expectedCode, rec.Code)
-			assert.Equal(t, cs.expectedErr, httpError)
+			assert.Equal(t, cs.expectedErr, problem)
 
 			// after
 			mysql.DB.Exec(`DELETE FROM resources`)
@@ -0,0 +1,17 @@
+package component
+
+import (
+	"os"
+	"testing"
+
+	"github.com/viniosilva/socialassistanceapi/internal/configuration"
+)
+
+// TestMain loads config.test.yml, unless the environment chose another profile
+func TestMain(m *testing.M) {
+	if os.Getenv(configuration.EnvPrefix+"_PROFILE") == "" {
+		os.Setenv(configuration.EnvPrefix+"_PROFILE", configuration.ProfileTest)
+	}
+
+	os.Exit(m.Run())
+}